@@ -101,7 +101,7 @@ func Run(logger *zap.Logger) {
 			}
 
 			ctx := context.Background()
-			err = f.SpecializePod(ctx, specializeReq.FetchReq, specializeReq.LoadReq)
+			_, err = f.SpecializePod(ctx, specializeReq.FetchReq, specializeReq.LoadReq)
 			if err != nil {
 				logger.Fatal("error specializing function pod", zap.Error(err))
 			}
@@ -18,14 +18,27 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"go.uber.org/zap"
 
 	"github.com/fission/fission/cmd/fetcher/app"
+	"github.com/fission/fission/pkg/fetcher"
 )
 
 // Usage: fetcher <shared volume path>
+// The job executor runs it as "fetcher jobrun" and "fetcher joboutput" too.
 func main() {
+	// the job executor runs the function of a job with the fetcher binary
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case fetcher.JobRunCommand:
+			os.Exit(fetcher.RunJob(os.Args[2:]))
+		case fetcher.JobOutputCommand:
+			os.Exit(fetcher.JobOutput(os.Args[2:]))
+		}
+	}
+
 	logger, err := zap.NewProduction()
 	if err != nil {
 		log.Fatalf("can't initialize zap logger: %v", err)
@@ -55,6 +55,22 @@ const (
 const (
 	ExecutorTypePoolmgr   ExecutorType = "poolmgr"
 	ExecutorTypeNewdeploy ExecutorType = "newdeploy"
+	ExecutorTypeContainer ExecutorType = "container"
+	ExecutorTypeJob       ExecutorType = "job"
+)
+
+const (
+	ScaleMetricTypePods     ScaleMetricType = "pods"
+	ScaleMetricTypeExternal ScaleMetricType = "external"
+
+	// The router metrics newdeploy scales on, served by the external metrics
+	// API through an adapter such as prometheus-adapter with the labels
+	// "namespace" and "name" of function. Router exports the in-flight
+	// requests as a gauge, and the requests per second are the rate of
+	// fission_function_calls_total; the fission-all chart ships the rules
+	// of prometheus-adapter for both.
+	FunctionInflightRequestsMetric  = "fission_function_inflight_requests"
+	FunctionRequestsPerSecondMetric = "fission_function_requests_per_second"
 )
 
 const (
@@ -81,12 +97,44 @@ const (
 
 	FunctionReferenceTypeFunctionWeights = "function-weights"
 
+	// FunctionReferenceTypeFunctionAlias means that the function
+	// reference is by the name of a FunctionAlias.
+	FunctionReferenceTypeFunctionAlias = "function-alias"
+
+	// FunctionReferenceTypeFunctionSelector means that the function
+	// reference is by the labels of functions, requests are spread
+	// across all the matching functions.
+	FunctionReferenceTypeFunctionSelector = "function-selector"
+
 	// Other function reference types we'd like to support:
 	//   Versioned function, latest version
 	//   Versioned function. by semver "latest compatible"
 	//   Set of function references (recursively), by percentage of traffic
 )
 
+const (
+	RateLimitKeyTypeTrigger  RateLimitKeyType = "trigger"
+	RateLimitKeyTypeClientIP RateLimitKeyType = "client-ip"
+	RateLimitKeyTypeHeader   RateLimitKeyType = "header"
+)
+
+const (
+	HTTPTriggerProtocolHTTP HTTPTriggerProtocol = "http"
+	HTTPTriggerProtocolGRPC HTTPTriggerProtocol = "grpc"
+)
+
+const (
+	LoadBalancingRoundRobin    LoadBalancing = "round-robin"
+	LoadBalancingLeastInflight LoadBalancing = "least-inflight"
+)
+
+const (
+	TriggerAuthTypeAPIKey TriggerAuthType = "apikey"
+	TriggerAuthTypeHMAC   TriggerAuthType = "hmac"
+	TriggerAuthTypeBasic  TriggerAuthType = "basic"
+	TriggerAuthTypeJWT    TriggerAuthType = "jwt"
+)
+
 const (
 	// failure type currently supported is http status code. This could be extended
 	// in the future.
@@ -126,6 +174,10 @@ const (
 
 const (
 	ANNOTATION_SVC_HOST = "svcHost"
+
+	// the job executor records the result of an invocation on its job
+	ANNOTATION_JOB_EXIT_CODE = "jobExitCode"
+	ANNOTATION_JOB_REASON    = "jobReason"
 )
 
 const (
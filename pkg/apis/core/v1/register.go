@@ -68,6 +68,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PackageList{},
 		&CanaryConfig{},
 		&CanaryConfigList{},
+		&FunctionAlias{},
+		&FunctionAliasList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -183,6 +183,26 @@ type (
 		Items []CanaryConfig `json:"items"`
 	}
 
+	// FunctionAlias is a named pointer to a function, or to a set of
+	// functions by weight. Triggers referencing the alias follow it when
+	// it's moved, e.g. "prod" from one version of a function to another.
+	// +genclient
+	// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+	FunctionAlias struct {
+		metav1.TypeMeta   `json:",inline"`
+		metav1.ObjectMeta `json:"metadata"`
+		Spec              FunctionAliasSpec `json:"spec"`
+	}
+
+	// FunctionAliasList is a list of FunctionAliases.
+	// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+	FunctionAliasList struct {
+		metav1.TypeMeta `json:",inline"`
+		metav1.ListMeta `json:"metadata"`
+
+		Items []FunctionAlias `json:"items"`
+	}
+
 	//
 	// Functions and packages
 	//
@@ -312,6 +332,9 @@ type (
 	// ExecutorType is the primary executor for an environment
 	ExecutorType string
 
+	// ScaleMetricType is the type of custom metric newdeploy scales a function on
+	ScaleMetricType string
+
 	// StrategyType is the strategy to be used for function execution
 	StrategyType string
 
@@ -343,6 +366,39 @@ type (
 		// a particular function execution should be complete.
 		// This is optional. If not specified default value will be taken as 60s
 		FunctionTimeout int `json:"functionTimeout,omitempty"`
+
+		// Container is the user container image run as-is for the function
+		// by the container or job executor, in place of an environment and package.
+		Container *FunctionContainer `json:"container,omitempty"`
+	}
+
+	// FunctionContainer is a container image serving HTTP requests on a port,
+	// e.g. an existing microservice. No fetcher runs next to it and no
+	// specialize request is sent to it.
+	//
+	// The job executor runs the image to completion once per invocation
+	// instead, the request body is mounted into the container and the
+	// stdout of Command is the response, so Port is not used. The time and
+	// Kubernetes watch triggers don't wait for the job, router replies them
+	// with 202 and the name of job once it's started.
+	FunctionContainer struct {
+		// Image is the container image of function.
+		Image string `json:"image"`
+
+		// Port is the port the container listens on for HTTP requests,
+		// required by the container executor.
+		Port int32 `json:"port"`
+
+		// Command overrides the entrypoint of image, optional for the container
+		// executor and required by the job executor, which wraps it.
+		Command []string `json:"command,omitempty"`
+
+		// Args are the arguments to the entrypoint, optional.
+		Args []string `json:"args,omitempty"`
+
+		// ImagePullSecret is the secret for Kubernetes to pull the image
+		// from a private registry, optional.
+		ImagePullSecret string `json:"imagepullsecret,omitempty"`
 	}
 
 	// InvokeStrategy is a set of controls over how the function executes.
@@ -382,6 +438,8 @@ type (
 		// Available value:
 		//  - poolmgr
 		//  - newdeploy
+		//  - container
+		//  - job
 		ExecutorType ExecutorType
 
 		// This is only for newdeploy to set up minimum replicas of deployment.
@@ -395,27 +453,141 @@ type (
 
 		// This is the timeout setting for executor to wait for pod specialization.
 		SpecializationTimeout int
+
+		// This is only for poolmgr to set up the maximum number of concurrent
+		// requests a specialized pod serves. When all the pods of a function
+		// are saturated, router asks executor to specialize another pool pod
+		// and queues the request until a pod is free; the additional pods are
+		// released once idle. Zero means one pod serves all the requests.
+		//
+		// The limit is enforced by each router replica on its own, so a
+		// pod may serve up to ConcurrencyPerInstance requests from every
+		// router replica at the same time.
+		ConcurrencyPerInstance int
+
+		// This is the idle time in seconds after which executor releases the pods
+		// of function for poolmgr, or scales the deployment down to MinScale for
+		// newdeploy. Defaults to 120 seconds. The open streams of streaming
+		// triggers keep the pods of function only if it's at least 7 seconds,
+		// since router taps the pods in batches sent every 5 seconds.
+		IdleTimeout int
+
+		// This is only for poolmgr to keep the last specialized pod of function
+		// even when it's idle, so the function doesn't pay a cold start after a
+		// gap of requests. For newdeploy, set MinScale instead.
+		NeverScaleToZero bool
+
+		// This is only for newdeploy to scale on the average number of in-flight
+		// requests per pod, as reported by router. Zero disables it.
+		TargetConcurrency int
+
+		// This is only for newdeploy to scale on the average number of requests
+		// per second per pod, as reported by router. Zero disables it. Both this
+		// and TargetConcurrency need prometheus-adapter with the rules of the
+		// fission-all chart.
+		TargetRequestsPerSecond int
+
+		// This is only for newdeploy to scale on a custom metric of the function
+		// pods, or an external metric, served by the metrics APIs. HPA scales on
+		// whichever of the targets, including TargetCPUPercent, needs the most pods.
+		ScaleMetricName string
+
+		// ScaleMetricType is the type of ScaleMetricName. Defaults to "pods".
+		//
+		// Available value:
+		//  - pods
+		//  - external
+		ScaleMetricType ScaleMetricType
+
+		// ScaleMetricTarget is the target average value per pod of ScaleMetricName,
+		// as a quantity such as "100" or "500m".
+		ScaleMetricTarget string
 	}
 
 	FunctionReferenceType string
 
 	FunctionReference struct {
-		// Type indicates whether this function reference is by name or selector. For now,
-		// the only supported reference type is by "name".  Future reference types:
-		//   * Function by label or annotation
-		//   * Branch or tag of a versioned function
+		// Type indicates whether this function reference is by name, weights, alias
+		// or selector. Future reference types:
 		//   * A "rolling upgrade" from one version of a function to another
 		// Available value:
 		// - name
 		// - function-weights
+		// - function-alias
+		// - function-selector
 		Type FunctionReferenceType `json:"type"`
 
-		// Name of the function.
+		// Name of the function, or of the function alias for type "function-alias".
 		Name string `json:"name"`
 
 		// Function Reference by weight. this map contains function name as key and its weight
 		// as the value. This is for canary upgrade purpose.
 		FunctionWeights map[string]int `json:"functionweights"`
+
+		// (Optional) CanaryRules pin the requests matching a rule to a function
+		// in FunctionWeights regardless of the weights. Rules are evaluated in
+		// order, the first matching rule wins. Only for type "function-weights".
+		CanaryRules []CanaryRule `json:"canaryrules,omitempty"`
+
+		// (Optional) StickySession keeps a client on the function it was first
+		// routed to by weight, so that it doesn't flip between versions.
+		// Only for type "function-weights".
+		StickySession *StickySession `json:"stickysession,omitempty"`
+
+		// Selector matches the labels of functions in the namespace of trigger,
+		// requests are spread across all the matching functions.
+		// Only for type "function-selector".
+		Selector map[string]string `json:"selector,omitempty"`
+
+		// (Optional) LoadBalancing is how requests are spread across the
+		// functions matching Selector, "round-robin" (default) or "least-inflight".
+		LoadBalancing LoadBalancing `json:"loadbalancing,omitempty"`
+	}
+
+	// LoadBalancing is the strategy of spreading requests across functions.
+	LoadBalancing string
+
+	// CanaryRule routes the requests matching all of its conditions to Function.
+	CanaryRule struct {
+		// Function is the name of a function in FunctionWeights.
+		Function string `json:"function"`
+
+		// (Optional) Header matches the requests with the header.
+		Header *CanaryMatch `json:"header,omitempty"`
+
+		// (Optional) Cookie matches the requests with the cookie.
+		Cookie *CanaryMatch `json:"cookie,omitempty"`
+
+		// (Optional) HashBucket matches a stable percentage of clients.
+		HashBucket *CanaryHashBucket `json:"hashbucket,omitempty"`
+	}
+
+	// CanaryMatch matches a header or cookie of request.
+	CanaryMatch struct {
+		// Name of the header or cookie.
+		Name string `json:"name"`
+
+		// (Optional) Value the header or cookie must equal to.
+		// If it's empty, the header or cookie only has to be present.
+		Value string `json:"value,omitempty"`
+	}
+
+	// CanaryHashBucket hashes a header or cookie identifying the client, e.g. an
+	// user id, into 100 buckets and matches the clients in the first Percent buckets.
+	// Exactly one of Header and Cookie has to be set.
+	CanaryHashBucket struct {
+		Header  string `json:"header,omitempty"`
+		Cookie  string `json:"cookie,omitempty"`
+		Percent int    `json:"percent"`
+	}
+
+	// StickySession remembers the function a client is routed to in a cookie.
+	StickySession struct {
+		// (Optional) CookieName is the name of cookie, defaults to "fission-canary-<trigger name>".
+		CookieName string `json:"cookiename,omitempty"`
+
+		// (Optional) MaxAge of the cookie in seconds. 0 means a session cookie.
+		MaxAge int `json:"maxage,omitempty"`
 	}
 
 	//
@@ -561,11 +733,21 @@ type (
 		Host string `json:"host"`
 
 		// RelativeURL is the exposed URL for external client to access a function with.
+		// It's a gorilla/mux route template, path parameters can be declared as
+		// "{name}" or "{name:regex}", e.g. "/users/{id:[0-9]+}/orders/{order}".
+		// The value of each path parameter is forwarded to the function in
+		// the "X-Fission-Params-<name>" request header.
 		RelativeURL string `json:"relativeurl"`
 
 		// HTTP method to access a function.
+		// Deprecated: use Methods instead. Method is ignored if Methods is not empty.
 		Method string `json:"method"`
 
+		// (Optional) Methods is the list of HTTP methods to access a function.
+		// Router registers all methods on the same route, so that one trigger
+		// can serve a REST resource.
+		Methods []string `json:"methods,omitempty"`
+
 		// FunctionReference is a reference to the target function.
 		FunctionReference FunctionReference `json:"functionref"`
 
@@ -575,6 +757,78 @@ type (
 		// TODO: make IngressConfig a independent Fission resource
 		// IngressConfig for router to set up Ingress.
 		IngressConfig IngressConfig `json:"ingressconfig"`
+
+		// (Optional) RateLimit limits the request rate and the number of
+		// concurrent requests router proxies to the function for this trigger.
+		RateLimit *RateLimit `json:"ratelimit,omitempty"`
+
+		// (Optional) Transform modifies requests before router proxies them
+		// to the function, and responses before they are sent back to client.
+		Transform *HTTPTransform `json:"transform,omitempty"`
+
+		// (Optional) CORS lets router answer CORS preflight requests
+		// and add CORS headers to responses on behalf of the function.
+		CORS *CORSPolicy `json:"cors,omitempty"`
+
+		// (Optional) Auth makes router authenticate requests before
+		// invoking the function.
+		Auth *TriggerAuth `json:"auth,omitempty"`
+
+		// (Optional) ResponseCache lets router serve repeated GET requests
+		// from an in-memory cache instead of invoking the function.
+		ResponseCache *ResponseCache `json:"responsecache,omitempty"`
+
+		// (Optional) MaxRequestBodyBytes is the maximum size of request body.
+		// Router replies 413 Request Entity Too Large to requests with a
+		// larger body. 0 means no limit.
+		MaxRequestBodyBytes int64 `json:"maxrequestbodybytes,omitempty"`
+
+		// (Optional) RequestTimeout is the number of seconds router waits for
+		// the response of function, including the time to specialize a pod.
+		// Router replies 504 Gateway Timeout once it's exceeded. 0 means the
+		// request is only limited by the function timeout.
+		RequestTimeout int `json:"requesttimeout,omitempty"`
+
+		// (Optional) Mirror duplicates a sample of requests to another function,
+		// whose responses are discarded.
+		Mirror *TrafficMirror `json:"mirror,omitempty"`
+
+		// (Optional) Streaming keeps long-lived responses, e.g. WebSocket,
+		// Server-Sent Events or chunked responses, open as long as data flows
+		// instead of cutting them at the function timeout.
+		Streaming *StreamingConfig `json:"streaming,omitempty"`
+
+		// (Optional) Protocol of the requests, "http" or "grpc". Defaults to "http".
+		// A gRPC trigger proxies gRPC calls over HTTP/2 cleartext (h2c) for all
+		// the paths under RelativeURL, e.g. "/helloworld.Greeter/", to a function
+		// serving gRPC over h2c. Method has to be POST, gRPC calls are POST
+		// requests.
+		Protocol HTTPTriggerProtocol `json:"protocol,omitempty"`
+
+		// (Optional) AsyncCallbackHosts is the list of hosts router can post
+		// the results of async invocations of the trigger to, e.g.
+		// "hooks.example.com", or "*.example.com" for its subdomains. A
+		// callback URL with another host is rejected, as well as one
+		// resolving to a private, loopback or link-local address. Async
+		// invocations can't have callbacks if it's empty.
+		AsyncCallbackHosts []string `json:"asynccallbackhosts,omitempty"`
+	}
+
+	// TrafficMirror sends a copy of requests to a function in background, e.g. to
+	// compare a new version of function against production traffic.
+	TrafficMirror struct {
+		// FunctionName is the name of mirror function in the namespace of trigger.
+		FunctionName string `json:"functionname"`
+
+		// Percent of requests to mirror, from 1 to 100.
+		Percent int `json:"percent"`
+	}
+
+	// StreamingConfig is the streaming mode of an HTTP trigger.
+	StreamingConfig struct {
+		// (Optional) IdleTimeout is the number of seconds a stream is kept open
+		// without any data transferred in either direction. Defaults to 60.
+		IdleTimeout int `json:"idletimeout,omitempty"`
 	}
 
 	// IngressConfig is for router to set up Ingress.
@@ -597,6 +851,177 @@ type (
 		TLS string `json:"tls"`
 	}
 
+	// HTTPTriggerProtocol is the protocol of the requests of an HTTP trigger.
+	HTTPTriggerProtocol string
+
+	// TriggerAuthType is the authentication method of an HTTP trigger.
+	TriggerAuthType string
+
+	// TriggerAuth is for router to authenticate requests with the credentials
+	// stored in a Secret. Requests failed to authenticate are rejected with
+	// 401 Unauthorized. The subject of an authenticated request is forwarded
+	// to the function in the "X-Fission-Auth-Subject" request header.
+	TriggerAuth struct {
+		// Type is the authentication method. Available value:
+		// - apikey: the API key is sent in Header. Each data entry of the
+		//   Secret is a key, the entry name is used as subject.
+		// - hmac: the hex encoded HMAC-SHA256 of "<timestamp>.<body>" is
+		//   sent in Header, optionally prefixed with "sha256=", where
+		//   timestamp is the unix time sent in "X-Signature-Timestamp".
+		//   Requests signed more than 5 minutes away from the router clock
+		//   are rejected. The shared key is the "key" data entry of the Secret.
+		// - basic: HTTP basic authentication. Each data entry of the
+		//   Secret is a username and its password.
+		// - jwt: a JWT is sent as bearer token in Authorization header, and
+		//   verified with the JWKS stored in the "jwks.json" data entry of
+		//   the Secret.
+		Type TriggerAuthType `json:"type"`
+
+		// SecretName is the name of the Secret in the namespace of the
+		// trigger that contains the credentials.
+		SecretName string `json:"secretname"`
+
+		// (Optional) Header is the request header that carries the API
+		// key or HMAC signature. Defaults to "X-Api-Key" for apikey and
+		// "X-Signature" for hmac.
+		Header string `json:"header,omitempty"`
+
+		// (Optional) Issuer is the expected "iss" claim of JWTs.
+		Issuer string `json:"issuer,omitempty"`
+
+		// (Optional) Audience is the expected "aud" claim of JWTs.
+		Audience string `json:"audience,omitempty"`
+
+		// (Optional) AllowNoExpiry accepts JWTs without "exp" claim, which
+		// are rejected by default since they are valid forever.
+		AllowNoExpiry bool `json:"allownoexpiry,omitempty"`
+
+		// (Optional) ForwardClaims is the list of JWT claims forwarded to
+		// the function in the "X-Fission-Auth-Claim-<claim>" request headers.
+		ForwardClaims []string `json:"forwardclaims,omitempty"`
+	}
+
+	// ResponseCache is for router to cache the responses of GET requests.
+	// Only 200 OK responses are cached. A function can control the caching
+	// with the Cache-Control response header: "no-store", "no-cache" and
+	// "private" disable caching, and "max-age"/"s-maxage" override TTL.
+	// Responses to requests with Authorization or Cookie header are only
+	// cached if they are marked "public" or have "s-maxage".
+	ResponseCache struct {
+		// TTL is the number of seconds a response is cached for.
+		TTL int `json:"ttl"`
+
+		// (Optional) VaryByHeaders is the list of request headers whose
+		// values are part of the cache key, e.g. "Accept-Language".
+		VaryByHeaders []string `json:"varybyheaders,omitempty"`
+
+		// (Optional) MaxEntries is the maximum number of responses cached for
+		// the trigger, the least recently used ones are evicted first.
+		// Defaults to 1000.
+		MaxEntries int `json:"maxentries,omitempty"`
+	}
+
+	// RateLimitKeyType decides how router groups requests into token buckets.
+	RateLimitKeyType string
+
+	// RateLimit is for router to reject requests with 429 Too Many Requests
+	// before they reach the executor and function pods.
+	RateLimit struct {
+		// RequestsPerSecond is the sustained rate of requests allowed for
+		// each rate limit key. 0 means no rate limit.
+		RequestsPerSecond int `json:"requestspersecond,omitempty"`
+
+		// Burst is the maximum number of requests allowed at once for
+		// each rate limit key. (Optional) defaults to RequestsPerSecond.
+		Burst int `json:"burst,omitempty"`
+
+		// MaxInFlight is the maximum number of requests router proxies
+		// concurrently for the trigger. 0 means no limit.
+		MaxInFlight int `json:"maxinflight,omitempty"`
+
+		// KeyType decides how requests are grouped into token buckets.
+		// Available value:
+		// - trigger (default): one bucket for the whole trigger
+		// - client-ip: one bucket per client address
+		// - header: one bucket per value of KeyHeader
+		KeyType RateLimitKeyType `json:"keytype,omitempty"`
+
+		// KeyHeader is the name of the request header used as rate limit
+		// key when KeyType is "header". For example, "X-Forwarded-For"
+		// for clients behind a proxy or "X-Api-Key" for per-tenant limits.
+		KeyHeader string `json:"keyheader,omitempty"`
+	}
+
+	// HTTPTransform is a declarative policy for router to modify the
+	// requests and responses of an HTTP trigger.
+	HTTPTransform struct {
+		// RequestHeaders are applied to the request headers.
+		RequestHeaders *HeaderTransform `json:"requestheaders,omitempty"`
+
+		// ResponseHeaders are applied to the response headers.
+		ResponseHeaders *HeaderTransform `json:"responseheaders,omitempty"`
+
+		// PathPrefix rewrites the prefix of request path. Functions see
+		// the rewritten URL in the "X-Fission-Full-Url" request header.
+		PathPrefix *PathPrefixRewrite `json:"pathprefix,omitempty"`
+
+		// Query parameters added to the request, a parameter sent by
+		// client with the same name is overwritten.
+		Query map[string]string `json:"query,omitempty"`
+	}
+
+	// HeaderTransform modifies HTTP headers. Headers are renamed first,
+	// then removed and set last.
+	HeaderTransform struct {
+		// Set sets the headers to the given values.
+		Set map[string]string `json:"set,omitempty"`
+
+		// Remove removes the headers.
+		Remove []string `json:"remove,omitempty"`
+
+		// Rename renames headers from the key to the value.
+		Rename map[string]string `json:"rename,omitempty"`
+	}
+
+	// CORSPolicy is the Cross-Origin Resource Sharing policy of an HTTP trigger.
+	// Router answers OPTIONS preflight requests of the trigger URL without
+	// invoking the function. Triggers with the same host and URL share the
+	// preflight, which allows the methods of all of them.
+	CORSPolicy struct {
+		// AllowedOrigins is the list of origins allowed to access the trigger,
+		// e.g. "https://example.com". "*" allows any origin, and a subdomain
+		// wildcard like "https://*.example.com" is supported.
+		AllowedOrigins []string `json:"allowedorigins"`
+
+		// (Optional) AllowedMethods is the list of methods allowed in
+		// cross-origin requests. Defaults to the methods of the trigger.
+		AllowedMethods []string `json:"allowedmethods,omitempty"`
+
+		// (Optional) AllowedHeaders is the list of request headers allowed
+		// in cross-origin requests. "*" allows any header.
+		AllowedHeaders []string `json:"allowedheaders,omitempty"`
+
+		// (Optional) ExposedHeaders is the list of response headers
+		// browsers are allowed to expose to client scripts.
+		ExposedHeaders []string `json:"exposedheaders,omitempty"`
+
+		// (Optional) AllowCredentials indicates whether requests can include
+		// credentials like cookies. When set, router replies with the request
+		// origin instead of "*", and AllowedOrigins can't contain "*".
+		AllowCredentials bool `json:"allowcredentials,omitempty"`
+
+		// (Optional) MaxAge is the number of seconds browsers can cache
+		// the preflight response. 0 means the header is not sent.
+		MaxAge int `json:"maxage,omitempty"`
+	}
+
+	// PathPrefixRewrite replaces the Prefix of request path with Replacement,
+	// e.g. Prefix "/api/v1" and Replacement "/" turn "/api/v1/users" into "/users".
+	PathPrefixRewrite struct {
+		Prefix      string `json:"prefix"`
+		Replacement string `json:"replacement"`
+	}
+
 	// KubernetesWatchTriggerSpec
 	KubernetesWatchTriggerSpec struct {
 		Namespace string `json:"namespace"`
@@ -680,6 +1105,17 @@ type (
 		Status string `json:"status"`
 	}
 
+	// FunctionAliasSpec is the function an alias points to.
+	FunctionAliasSpec struct {
+		// Name of the function the alias points to.
+		FunctionName string `json:"functionname,omitempty"`
+
+		// (Optional) Function weights split the traffic of alias between
+		// functions, function name as key and its weight as the value.
+		// FunctionName is ignored if it's set.
+		FunctionWeights map[string]int `json:"functionweights,omitempty"`
+	}
+
 	// MetadataAccessor lets you work with object metadata and type metadata
 	// from any of the versioned or internal API objects.
 	MetadataAccessor interface {
@@ -691,3 +1127,27 @@ type (
 func (a Archive) IsEmpty() bool {
 	return len(a.Literal) == 0 && len(a.URL) == 0
 }
+
+// GetMethods returns the HTTP methods of trigger. For backward compatibility,
+// Method is used if Methods is empty.
+func (spec HTTPTriggerSpec) GetMethods() []string {
+	if len(spec.Methods) > 0 {
+		return spec.Methods
+	}
+	return []string{spec.Method}
+}
+
+// IsGRPC returns true if the trigger proxies gRPC calls.
+func (spec HTTPTriggerSpec) IsGRPC() bool {
+	return spec.Protocol == HTTPTriggerProtocolGRPC
+}
+
+// HasMethod returns true if the trigger accepts the given HTTP method.
+func (spec HTTPTriggerSpec) HasMethod(method string) bool {
+	for _, m := range spec.GetMethods() {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
@@ -20,11 +20,15 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"regexp/syntax"
 	"strings"
+	"unicode"
 
 	"github.com/hashicorp/go-multierror"
 	nsUtil "github.com/nats-io/nats-streaming-server/util"
 	"github.com/robfig/cron"
+	"golang.org/x/net/http/httpguts"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
@@ -41,6 +45,9 @@ var (
 	validAzureQueueName = regexp.MustCompile(`^[a-z0-9][a-z0-9\\-]*[a-z0-9]$`)
 	// Need to use raw string to support escape sequence for - & . chars
 	validKafkaTopicName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9\-\._]*[a-zA-Z0-9]$`)
+	// Path parameters are forwarded to functions as X-Fission-Params-<name>
+	// headers, so the name must be a valid header field name as well.
+	validRouteParamName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
 )
 
 type (
@@ -123,9 +130,12 @@ func ValidateKubeLabel(field string, labels map[string]string) error {
 		// Example: XXX -> YYY
 		// KubernetesWatchTriggerSpec.LabelSelector.Key: Invalid value: XXX
 		// KubernetesWatchTriggerSpec.LabelSelector.Value: Invalid value: YYY
-		result = multierror.Append(result,
-			MakeValidationErr(ErrorInvalidValue, fmt.Sprintf("%v.Key", field), k, validation.IsQualifiedName(k)...),
-			MakeValidationErr(ErrorInvalidValue, fmt.Sprintf("%v.Value", field), v, validation.IsValidLabelValue(v)...))
+		if e := validation.IsQualifiedName(k); len(e) > 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, fmt.Sprintf("%v.Key", field), k, e...))
+		}
+		if e := validation.IsValidLabelValue(v); len(e) > 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, fmt.Sprintf("%v.Value", field), v, e...))
+		}
 	}
 
 	return result.ErrorOrNil()
@@ -197,6 +207,249 @@ func IsValidCronSpec(spec string) error {
 	return err
 }
 
+// routeBraceIndices returns the first and last index of each {...} block in
+// a route template. Nested braces are allowed in the regex of a parameter,
+// e.g. "/{id:[0-9]{3}}".
+func routeBraceIndices(tpl string) ([]int, error) {
+	var level, idx int
+	var idxs []int
+	for i := 0; i < len(tpl); i++ {
+		switch tpl[i] {
+		case '{':
+			if level++; level == 1 {
+				idx = i
+			}
+		case '}':
+			if level--; level == 0 {
+				idxs = append(idxs, idx, i+1)
+			} else if level < 0 {
+				return nil, fmt.Errorf("unbalanced braces in %q", tpl)
+			}
+		}
+	}
+	if level != 0 {
+		return nil, fmt.Errorf("unbalanced braces in %q", tpl)
+	}
+	return idxs, nil
+}
+
+// splitRouteParam splits a route parameter block like "{id:[0-9]+}" into
+// its name and pattern. The pattern defaults to "[^/]+", the same as gorilla/mux.
+func splitRouteParam(block string) (name string, pattern string) {
+	parts := strings.SplitN(block[1:len(block)-1], ":", 2)
+	name, pattern = parts[0], "[^/]+"
+	if len(parts) == 2 {
+		pattern = parts[1]
+	}
+	return name, pattern
+}
+
+// ParseRouteTemplate parses the RelativeURL of an HTTP trigger. RelativeURL is a
+// gorilla/mux route template where path parameters are written as "{name}" or
+// "{name:regex}", for example "/users/{id:[0-9]+}". It returns an anchored
+// regular expression that matches the same request paths and the names of
+// the path parameters in order.
+func ParseRouteTemplate(tpl string) (*regexp.Regexp, []string, error) {
+	if !strings.HasPrefix(tpl, "/") {
+		return nil, nil, fmt.Errorf("must be an absolute path")
+	}
+
+	idxs, err := routeBraceIndices(tpl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var names []string
+	var end int
+	pattern := "^"
+	for i := 0; i < len(idxs); i += 2 {
+		name, patt := splitRouteParam(tpl[idxs[i]:idxs[i+1]])
+		if !validRouteParamName.MatchString(name) {
+			return nil, nil, fmt.Errorf("invalid path parameter name %q, must match %v", name, validRouteParamName.String())
+		}
+		for _, n := range names {
+			if strings.EqualFold(n, name) {
+				return nil, nil, fmt.Errorf("duplicate path parameter name %q", name)
+			}
+		}
+		if len(patt) == 0 {
+			return nil, nil, fmt.Errorf("empty regex for path parameter %q", name)
+		}
+		re, err := regexp.Compile(patt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid regex for path parameter %q: %v", name, err)
+		}
+		// gorilla/mux panics when a route contains capturing groups
+		if re.NumSubexp() > 0 {
+			return nil, nil, fmt.Errorf("regex for path parameter %q contains capturing groups, use (?:pattern) instead of (pattern)", name)
+		}
+		pattern += regexp.QuoteMeta(tpl[end:idxs[i]]) + "(?:" + patt + ")"
+		names = append(names, name)
+		end = idxs[i+1]
+	}
+	pattern += regexp.QuoteMeta(tpl[end:]) + "$"
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return re, names, nil
+}
+
+// routeInstRanges returns the rune ranges matched by a rune instruction of
+// a compiled regex, as pairs of lo, hi.
+func routeInstRanges(inst *syntax.Inst) []rune {
+	switch inst.Op {
+	case syntax.InstRune1:
+		return []rune{inst.Rune[0], inst.Rune[0]}
+	case syntax.InstRuneAny:
+		return []rune{0, unicode.MaxRune}
+	case syntax.InstRuneAnyNotNL:
+		return []rune{0, '\n' - 1, '\n' + 1, unicode.MaxRune}
+	}
+	if len(inst.Rune) == 1 {
+		// a single case folded rune, e.g. "(?i)a"
+		r0 := inst.Rune[0]
+		ranges := []rune{r0, r0}
+		if syntax.Flags(inst.Arg)&syntax.FoldCase != 0 {
+			for r := unicode.SimpleFold(r0); r != r0; r = unicode.SimpleFold(r) {
+				ranges = append(ranges, r, r)
+			}
+		}
+		return ranges
+	}
+	return inst.Rune
+}
+
+// routeInstsIntersect returns true if some rune is matched by both rune instructions.
+func routeInstsIntersect(a, b *syntax.Inst) bool {
+	ra, rb := routeInstRanges(a), routeInstRanges(b)
+	for i := 0; i < len(ra); i += 2 {
+		for j := 0; j < len(rb); j += 2 {
+			if ra[i] <= rb[j+1] && rb[j] <= ra[i+1] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// routeProgClosure returns the rune and match instructions reachable from pc
+// without consuming input. Empty width assertions are treated as always
+// satisfied, which is exact for the ^ and $ around a route template regex.
+func routeProgClosure(prog *syntax.Prog, pc uint32) []uint32 {
+	var pcs []uint32
+	seen := make(map[uint32]bool)
+	stack := []uint32{pc}
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[pc] {
+			continue
+		}
+		seen[pc] = true
+
+		inst := &prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, inst.Out, inst.Arg)
+		case syntax.InstCapture, syntax.InstNop, syntax.InstEmptyWidth:
+			stack = append(stack, inst.Out)
+		case syntax.InstFail:
+		default:
+			pcs = append(pcs, pc)
+		}
+	}
+	return pcs
+}
+
+func compileRouteRegex(re *regexp.Regexp) (*syntax.Prog, error) {
+	r, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	return syntax.Compile(r.Simplify())
+}
+
+// regexesIntersect returns true if some string is matched by both regexes.
+// It walks the product of the two regex programs, so unlike matching sample
+// strings it finds every overlap, e.g. "[a-z]{5}" and "[a-z]+".
+func regexesIntersect(a, b *regexp.Regexp) bool {
+	progA, errA := compileRouteRegex(a)
+	progB, errB := compileRouteRegex(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	type state struct{ a, b uint32 }
+	seen := make(map[state]bool)
+	var queue []state
+	push := func(pcA, pcB uint32) {
+		for _, a := range routeProgClosure(progA, pcA) {
+			for _, b := range routeProgClosure(progB, pcB) {
+				if s := (state{a, b}); !seen[s] {
+					seen[s] = true
+					queue = append(queue, s)
+				}
+			}
+		}
+	}
+
+	push(uint32(progA.Start), uint32(progB.Start))
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+
+		instA, instB := &progA.Inst[s.a], &progB.Inst[s.b]
+		matchA, matchB := instA.Op == syntax.InstMatch, instB.Op == syntax.InstMatch
+		if matchA && matchB {
+			return true
+		}
+		if !matchA && !matchB && routeInstsIntersect(instA, instB) {
+			push(instA.Out, instB.Out)
+		}
+	}
+
+	return false
+}
+
+// RouteTemplatesOverlap returns true if some request path is matched by both
+// route templates, for example "/users/{id}" and "/users/{name}", or
+// "/users/{id}" and "/users/admin". Router registers triggers in no particular
+// order, so a request matching overlapping routes may go to either trigger.
+func RouteTemplatesOverlap(a, b string) bool {
+	return RoutesOverlap(a, false, b, false)
+}
+
+// RoutesOverlap is RouteTemplatesOverlap for routes that may be prefix
+// routes, which match the paths starting with the template, as the routes
+// of gRPC triggers do. For example the prefix routes "/pkg." and
+// "/pkg.Greeter/" both match "/pkg.Greeter/SayHello".
+func RoutesOverlap(a string, aPrefix bool, b string, bPrefix bool) bool {
+	if a == b {
+		return true
+	}
+
+	reA, errA := parseRoute(a, aPrefix)
+	reB, errB := parseRoute(b, bPrefix)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return regexesIntersect(reA, reB)
+}
+
+// parseRoute returns the regex of route template, which matches the
+// paths starting with the template if prefix is true.
+func parseRoute(tpl string, prefix bool) (*regexp.Regexp, error) {
+	re, _, err := ParseRouteTemplate(tpl)
+	if err != nil || !prefix {
+		return re, err
+	}
+	return regexp.Compile(strings.TrimSuffix(re.String(), "$") + "(?s:.*)$")
+}
+
 /* Resource validation function */
 
 func (checksum Checksum) Validate() error {
@@ -307,6 +560,31 @@ func (spec FunctionSpec) Validate() error {
 		result = multierror.Append(result, spec.InvokeStrategy.Validate())
 	}
 
+	switch executorType := spec.InvokeStrategy.ExecutionStrategy.ExecutorType; executorType {
+	case ExecutorTypeContainer, ExecutorTypeJob:
+		if spec.Container == nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionSpec.Container", spec.Container, fmt.Sprintf("container is required by the %v executor", executorType)))
+		} else {
+			result = multierror.Append(result, spec.Container.Validate())
+			if executorType == ExecutorTypeContainer && spec.Container.Port == 0 {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionContainer.Port", spec.Container.Port, "port is required by the container executor"))
+			}
+			if executorType == ExecutorTypeJob && len(spec.Container.Command) == 0 {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionContainer.Command", spec.Container.Command, "command is required by the job executor, which runs it to capture its stdout"))
+			}
+		}
+		if spec.Package != (FunctionPackageRef{}) || spec.Environment != (EnvironmentReference{}) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionSpec.Package", spec.Package.PackageRef.Name, fmt.Sprintf("the %v executor runs the container image without an environment or package", executorType)))
+		}
+		if len(spec.Secrets) > 0 || len(spec.ConfigMaps) > 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionSpec.Secrets", len(spec.Secrets)+len(spec.ConfigMaps), fmt.Sprintf("secrets and configmaps are loaded by the fetcher, which the %v executor doesn't run", executorType)))
+		}
+	default:
+		if spec.Container != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionSpec.Container", spec.Container.Image, "container is only supported by the container and job executors"))
+		}
+	}
+
 	// TODO Add below validation warning
 	/*if spec.FunctionTimeout <= 0 {
 		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionTimeout value", spec.FunctionTimeout, "not a valid value. Should always be more than 0"))
@@ -315,6 +593,21 @@ func (spec FunctionSpec) Validate() error {
 	return result.ErrorOrNil()
 }
 
+func (c FunctionContainer) Validate() error {
+	result := &multierror.Error{}
+
+	if len(c.Image) == 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionContainer.Image", c.Image, "image is required"))
+	}
+
+	// port is left zero by the job executor
+	if c.Port < 0 || c.Port > 65535 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionContainer.Port", c.Port, "port must be a value between 1 - 65535"))
+	}
+
+	return result.ErrorOrNil()
+}
+
 func (is InvokeStrategy) Validate() error {
 	result := &multierror.Error{}
 
@@ -333,12 +626,13 @@ func (es ExecutionStrategy) Validate() error {
 	result := &multierror.Error{}
 
 	switch es.ExecutorType {
-	case ExecutorTypeNewdeploy, ExecutorTypePoolmgr: // no op
+	case ExecutorTypeNewdeploy, ExecutorTypePoolmgr, ExecutorTypeContainer, ExecutorTypeJob: // no op
 	default:
 		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "ExecutionStrategy.ExecutorType", es.ExecutorType, "not a valid executor type"))
 	}
 
-	if es.ExecutorType == ExecutorTypeNewdeploy {
+	// the container executor scales deployments the way newdeploy does
+	if es.ExecutorType == ExecutorTypeNewdeploy || es.ExecutorType == ExecutorTypeContainer {
 		if es.MinScale < 0 {
 			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.MinScale", es.MinScale, "minimum scale must be greater than or equal to 0"))
 		}
@@ -359,6 +653,57 @@ func (es ExecutionStrategy) Validate() error {
 		//if es.SpecializationTimeout < 120 {
 		//	result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.SpecializationTimeout", es.SpecializationTimeout, "SpecializationTimeout must be a value equal to or greater than 120"))
 		//}
+
+		if es.ConcurrencyPerInstance != 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.ConcurrencyPerInstance", es.ConcurrencyPerInstance, "concurrency per instance is only supported by poolmgr, newdeploy scales with HPA"))
+		}
+
+		if es.NeverScaleToZero {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.NeverScaleToZero", es.NeverScaleToZero, "never scaling to zero is only supported by poolmgr, set minimum scale for newdeploy"))
+		}
+
+		if es.TargetConcurrency < 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.TargetConcurrency", es.TargetConcurrency, "target concurrency must be greater than or equal to 0"))
+		}
+
+		if es.TargetRequestsPerSecond < 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.TargetRequestsPerSecond", es.TargetRequestsPerSecond, "target requests per second must be greater than or equal to 0"))
+		}
+
+		if len(es.ScaleMetricName) > 0 {
+			switch es.ScaleMetricType {
+			case "", ScaleMetricTypePods, ScaleMetricTypeExternal: // no op
+			default:
+				result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "ExecutionStrategy.ScaleMetricType", es.ScaleMetricType, "not a valid scale metric type"))
+			}
+
+			if q, err := resource.ParseQuantity(es.ScaleMetricTarget); err != nil || q.Sign() <= 0 {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.ScaleMetricTarget", es.ScaleMetricTarget, "scale metric target must be a quantity greater than 0"))
+			}
+		} else if len(es.ScaleMetricType) > 0 || len(es.ScaleMetricTarget) > 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.ScaleMetricName", es.ScaleMetricName, "scale metric name is required for scale metric type and target"))
+		}
+	} else if es.TargetConcurrency != 0 || es.TargetRequestsPerSecond != 0 || len(es.ScaleMetricName) > 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.ExecutorType", es.ExecutorType, "scaling targets are only supported by newdeploy, set concurrency per instance for poolmgr"))
+	}
+
+	// the job executor runs a job for every invocation, nothing is kept to scale
+	if es.ExecutorType == ExecutorTypeJob {
+		if es.ConcurrencyPerInstance != 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.ConcurrencyPerInstance", es.ConcurrencyPerInstance, "concurrency per instance is not supported by job, every invocation runs a job"))
+		}
+
+		if es.NeverScaleToZero || es.MinScale > 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.MinScale", es.MinScale, "the job executor keeps no pods running between invocations"))
+		}
+	}
+
+	if es.IdleTimeout < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.IdleTimeout", es.IdleTimeout, "idle timeout must be greater than or equal to 0"))
+	}
+
+	if es.ConcurrencyPerInstance < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "ExecutionStrategy.ConcurrencyPerInstance", es.ConcurrencyPerInstance, "concurrency per instance must be greater than or equal to 0"))
 	}
 
 	return result.ErrorOrNil()
@@ -370,14 +715,108 @@ func (ref FunctionReference) Validate() error {
 	switch ref.Type {
 	case FunctionReferenceTypeFunctionName: // no op
 	case FunctionReferenceTypeFunctionWeights: // no op
+	case FunctionReferenceTypeFunctionAlias: // no op
+	case FunctionReferenceTypeFunctionSelector: // no op
 	default:
 		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "FunctionReference.Type", ref.Type, "not a valid function reference type"))
 	}
 
-	if ref.Type == FunctionReferenceTypeFunctionName {
+	if ref.Type == FunctionReferenceTypeFunctionName || ref.Type == FunctionReferenceTypeFunctionAlias {
 		result = multierror.Append(result, ValidateKubeName("FunctionReference.Name", ref.Name))
 	}
 
+	if ref.Type != FunctionReferenceTypeFunctionWeights {
+		if len(ref.CanaryRules) > 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "FunctionReference.CanaryRules", ref.Type, "canary rules are only supported by function reference type "+FunctionReferenceTypeFunctionWeights))
+		}
+		if ref.StickySession != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "FunctionReference.StickySession", ref.Type, "sticky session is only supported by function reference type "+FunctionReferenceTypeFunctionWeights))
+		}
+	}
+
+	if ref.Type == FunctionReferenceTypeFunctionSelector {
+		if len(ref.Selector) == 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionReference.Selector", ref.Selector, "selector must not be empty"))
+		}
+		result = multierror.Append(result, ValidateKubeLabel("FunctionReference.Selector", ref.Selector))
+
+		switch ref.LoadBalancing {
+		case "", LoadBalancingRoundRobin, LoadBalancingLeastInflight: // no op
+		default:
+			result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "FunctionReference.LoadBalancing", ref.LoadBalancing, "not a supported load balancing strategy"))
+		}
+	} else if len(ref.Selector) > 0 || len(ref.LoadBalancing) > 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "FunctionReference.Selector", ref.Type, "selector is only supported by function reference type "+FunctionReferenceTypeFunctionSelector))
+	}
+
+	for _, rule := range ref.CanaryRules {
+		if _, ok := ref.FunctionWeights[rule.Function]; !ok {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "CanaryRule.Function", rule.Function, "function not found in function weights"))
+		}
+		result = multierror.Append(result, rule.Validate())
+	}
+
+	if ref.StickySession != nil {
+		result = multierror.Append(result, ref.StickySession.Validate())
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (rule CanaryRule) Validate() error {
+	result := &multierror.Error{}
+
+	if rule.Header == nil && rule.Cookie == nil && rule.HashBucket == nil {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "CanaryRule", rule.Function, "at least one of header, cookie and hash bucket is required"))
+	}
+
+	if rule.Header != nil && !httpguts.ValidHeaderFieldName(rule.Header.Name) {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "CanaryRule.Header.Name", rule.Header.Name, "not a valid header name"))
+	}
+
+	if rule.Cookie != nil && !httpguts.ValidHeaderFieldName(rule.Cookie.Name) {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "CanaryRule.Cookie.Name", rule.Cookie.Name, "not a valid cookie name"))
+	}
+
+	if b := rule.HashBucket; b != nil {
+		if (len(b.Header) > 0) == (len(b.Cookie) > 0) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "CanaryRule.HashBucket", rule.Function, "exactly one of header and cookie is required"))
+		} else if len(b.Header) > 0 && !httpguts.ValidHeaderFieldName(b.Header) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "CanaryRule.HashBucket.Header", b.Header, "not a valid header name"))
+		} else if len(b.Cookie) > 0 && !httpguts.ValidHeaderFieldName(b.Cookie) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "CanaryRule.HashBucket.Cookie", b.Cookie, "not a valid cookie name"))
+		}
+		if b.Percent < 0 || b.Percent > 100 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "CanaryRule.HashBucket.Percent", b.Percent, "must be between 0 and 100"))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (mirror TrafficMirror) Validate() error {
+	result := &multierror.Error{}
+
+	result = multierror.Append(result, ValidateKubeName("TrafficMirror.FunctionName", mirror.FunctionName))
+
+	if mirror.Percent < 1 || mirror.Percent > 100 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "TrafficMirror.Percent", mirror.Percent, "must be between 1 and 100"))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (sticky StickySession) Validate() error {
+	result := &multierror.Error{}
+
+	if len(sticky.CookieName) > 0 && !httpguts.ValidHeaderFieldName(sticky.CookieName) {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "StickySession.CookieName", sticky.CookieName, "not a valid cookie name"))
+	}
+
+	if sticky.MaxAge < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "StickySession.MaxAge", sticky.MaxAge, "must be greater than or equal to 0"))
+	}
+
 	return result.ErrorOrNil()
 }
 
@@ -432,14 +871,39 @@ func (spec EnvironmentSpec) Validate() error {
 	return result.ErrorOrNil()
 }
 
+func isValidHTTPMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
 func (spec HTTPTriggerSpec) Validate() error {
 	result := &multierror.Error{}
 
-	switch spec.Method {
-	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
-		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace: // no op
-	default:
-		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "HTTPTriggerSpec.Method", spec.Method, "not a valid HTTP method"))
+	if len(spec.Methods) == 0 {
+		if !isValidHTTPMethod(spec.Method) {
+			result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "HTTPTriggerSpec.Method", spec.Method, "not a valid HTTP method"))
+		}
+	} else {
+		methods := make(map[string]struct{}, len(spec.Methods))
+		for _, m := range spec.Methods {
+			if !isValidHTTPMethod(m) {
+				result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "HTTPTriggerSpec.Methods", m, "not a valid HTTP method"))
+			}
+			if _, ok := methods[m]; ok {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.Methods", m, "duplicate HTTP method"))
+			}
+			methods[m] = struct{}{}
+		}
+	}
+
+	_, _, err := ParseRouteTemplate(spec.RelativeURL)
+	if err != nil {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.RelativeURL", spec.RelativeURL, err.Error()))
 	}
 
 	result = multierror.Append(result, spec.FunctionReference.Validate())
@@ -453,6 +917,108 @@ func (spec HTTPTriggerSpec) Validate() error {
 
 	result = multierror.Append(result, spec.IngressConfig.Validate())
 
+	if spec.RateLimit != nil {
+		result = multierror.Append(result, spec.RateLimit.Validate())
+	}
+
+	if spec.Transform != nil {
+		result = multierror.Append(result, spec.Transform.Validate())
+	}
+
+	if spec.CORS != nil {
+		result = multierror.Append(result, spec.CORS.Validate())
+	}
+
+	if spec.Auth != nil {
+		result = multierror.Append(result, spec.Auth.Validate())
+	}
+
+	if spec.ResponseCache != nil {
+		if !spec.HasMethod(http.MethodGet) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "HTTPTriggerSpec.ResponseCache", spec.GetMethods(), "response cache requires trigger to accept GET requests"))
+		}
+		result = multierror.Append(result, spec.ResponseCache.Validate())
+	}
+
+	if spec.MaxRequestBodyBytes < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.MaxRequestBodyBytes", spec.MaxRequestBodyBytes, "must be greater than or equal to 0"))
+	}
+
+	if spec.RequestTimeout < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.RequestTimeout", spec.RequestTimeout, "must be greater than or equal to 0"))
+	}
+
+	if spec.Mirror != nil {
+		result = multierror.Append(result, spec.Mirror.Validate())
+	}
+
+	if spec.Streaming != nil {
+		if spec.Streaming.IdleTimeout < 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.Streaming.IdleTimeout", spec.Streaming.IdleTimeout, "must be greater than or equal to 0"))
+		}
+		if spec.ResponseCache != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "HTTPTriggerSpec.ResponseCache", spec.ResponseCache, "streamed responses can't be cached"))
+		}
+	}
+
+	for _, h := range spec.AsyncCallbackHosts {
+		if e := validation.IsDNS1123Subdomain(strings.TrimPrefix(h, "*.")); len(e) > 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.AsyncCallbackHosts", h, e...))
+		}
+	}
+
+	switch spec.Protocol {
+	case "", HTTPTriggerProtocolHTTP: // no op
+	case HTTPTriggerProtocolGRPC:
+		// router serves the gRPC calls under RelativeURL on POST only
+		if methods := spec.GetMethods(); len(methods) != 1 || methods[0] != http.MethodPost {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.Method", methods, "gRPC triggers only accept POST"))
+		}
+		if spec.ResponseCache != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "HTTPTriggerSpec.ResponseCache", spec.ResponseCache, "gRPC responses can't be cached"))
+		}
+		if spec.CORS != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "HTTPTriggerSpec.CORS", spec.CORS, "not supported by gRPC triggers"))
+		}
+		if spec.Mirror != nil {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "HTTPTriggerSpec.Mirror", spec.Mirror, "not supported by gRPC triggers"))
+		}
+	default:
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "HTTPTriggerSpec.Protocol", spec.Protocol, "not a supported protocol"))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (rl RateLimit) Validate() error {
+	result := &multierror.Error{}
+
+	if rl.RequestsPerSecond < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.RateLimit.RequestsPerSecond", rl.RequestsPerSecond, "must be greater than or equal to 0"))
+	}
+
+	if rl.Burst < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.RateLimit.Burst", rl.Burst, "must be greater than or equal to 0"))
+	}
+
+	if rl.MaxInFlight < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.RateLimit.MaxInFlight", rl.MaxInFlight, "must be greater than or equal to 0"))
+	}
+
+	if rl.RequestsPerSecond == 0 && rl.MaxInFlight == 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidObject, "HTTPTriggerSpec.RateLimit", rl, "either RequestsPerSecond or MaxInFlight must be set"))
+	}
+
+	switch rl.KeyType {
+	case "", RateLimitKeyTypeTrigger, RateLimitKeyTypeClientIP: // no op
+	case RateLimitKeyTypeHeader:
+		if len(rl.KeyHeader) == 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.RateLimit.KeyHeader", rl.KeyHeader, "header name is required when key type is header"))
+		}
+	default:
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "HTTPTriggerSpec.RateLimit.KeyType", rl.KeyType, "not a valid rate limit key type"))
+	}
+
 	return result.ErrorOrNil()
 }
 
@@ -518,7 +1084,7 @@ func (spec KubernetesWatchTriggerSpec) Validate() error {
 	result = multierror.Append(result,
 		ValidateKubeName("KubernetesWatchTriggerSpec.Namespace", spec.Namespace),
 		ValidateKubeLabel("KubernetesWatchTriggerSpec.LabelSelector", spec.LabelSelector),
-		spec.FunctionReference.Validate())
+		validateNonHTTPFunctionReference("KubernetesWatchTriggerSpec.FunctionReference", spec.FunctionReference))
 
 	return result.ErrorOrNil()
 }
@@ -526,7 +1092,7 @@ func (spec KubernetesWatchTriggerSpec) Validate() error {
 func (spec MessageQueueTriggerSpec) Validate() error {
 	result := &multierror.Error{}
 
-	result = multierror.Append(result, spec.FunctionReference.Validate())
+	result = multierror.Append(result, validateNonHTTPFunctionReference("MessageQueueTriggerSpec.FunctionReference", spec.FunctionReference))
 
 	switch spec.MessageQueueType {
 	case MessageQueueTypeNats, MessageQueueTypeASQ, MessageQueueTypeKafka: // no op
@@ -553,7 +1119,46 @@ func (spec TimeTriggerSpec) Validate() error {
 		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "TimeTriggerSpec.Cron", spec.Cron, "not a valid cron spec"))
 	}
 
-	result = multierror.Append(result, spec.FunctionReference.Validate())
+	result = multierror.Append(result, validateNonHTTPFunctionReference("TimeTriggerSpec.FunctionReference", spec.FunctionReference))
+
+	return result.ErrorOrNil()
+}
+
+// validateNonHTTPFunctionReference checks the function reference of a trigger
+// invoking functions through their router path, which has a single function
+// or function alias behind.
+func validateNonHTTPFunctionReference(field string, ref FunctionReference) error {
+	result := &multierror.Error{}
+
+	switch ref.Type {
+	case FunctionReferenceTypeFunctionName, FunctionReferenceTypeFunctionAlias:
+	default:
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, field+".Type", ref.Type, "only function name and function alias are supported by the trigger"))
+	}
+	result = multierror.Append(result, ref.Validate())
+
+	return result.ErrorOrNil()
+}
+
+func (spec FunctionAliasSpec) Validate() error {
+	result := &multierror.Error{}
+
+	if len(spec.FunctionWeights) == 0 {
+		result = multierror.Append(result, ValidateKubeName("FunctionAliasSpec.FunctionName", spec.FunctionName))
+		return result.ErrorOrNil()
+	}
+
+	total := 0
+	for name, weight := range spec.FunctionWeights {
+		result = multierror.Append(result, ValidateKubeName("FunctionAliasSpec.FunctionWeights", name))
+		if weight < 0 || weight > 100 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionAliasSpec.FunctionWeights", weight, "weight must be a value between 0 - 100"))
+		}
+		total += weight
+	}
+	if total != 100 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "FunctionAliasSpec.FunctionWeights", total, "sum of weights must be 100"))
+	}
 
 	return result.ErrorOrNil()
 }
@@ -689,3 +1294,168 @@ func (ml *MessageQueueTriggerList) Validate() error {
 	}
 	return result.ErrorOrNil()
 }
+
+func (t HTTPTransform) Validate() error {
+	result := &multierror.Error{}
+
+	if t.RequestHeaders != nil {
+		result = multierror.Append(result, t.RequestHeaders.validate("HTTPTriggerSpec.Transform.RequestHeaders"))
+	}
+
+	if t.ResponseHeaders != nil {
+		result = multierror.Append(result, t.ResponseHeaders.validate("HTTPTriggerSpec.Transform.ResponseHeaders"))
+	}
+
+	if t.PathPrefix != nil {
+		if !strings.HasPrefix(t.PathPrefix.Prefix, "/") {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.Transform.PathPrefix.Prefix", t.PathPrefix.Prefix, "must start with /"))
+		}
+		if !strings.HasPrefix(t.PathPrefix.Replacement, "/") {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.Transform.PathPrefix.Replacement", t.PathPrefix.Replacement, "must start with /"))
+		}
+	}
+
+	for k := range t.Query {
+		if len(k) == 0 {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.Transform.Query", k, "query parameter name cannot be empty"))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (t HeaderTransform) validate(field string) error {
+	result := &multierror.Error{}
+
+	checkName := func(subField, name string) {
+		if !httpguts.ValidHeaderFieldName(name) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, field+subField, name, "not a valid header name"))
+		}
+	}
+
+	for k, v := range t.Set {
+		checkName(".Set", k)
+		if !httpguts.ValidHeaderFieldValue(v) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, field+".Set", v, "not a valid header value"))
+		}
+	}
+	for _, k := range t.Remove {
+		checkName(".Remove", k)
+	}
+	for k, v := range t.Rename {
+		checkName(".Rename", k)
+		checkName(".Rename", v)
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (c CORSPolicy) Validate() error {
+	result := &multierror.Error{}
+
+	if len(c.AllowedOrigins) == 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.CORS.AllowedOrigins", c.AllowedOrigins, "at least one origin is required"))
+	}
+	for _, o := range c.AllowedOrigins {
+		if o == "*" {
+			// browsers refuse credentials from any origin, and reflecting
+			// the request origin instead would trust every site with them.
+			if c.AllowCredentials {
+				result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.CORS.AllowedOrigins", o, "\"*\" is not allowed with AllowCredentials, list the allowed origins instead"))
+			}
+			continue
+		}
+		if !strings.HasPrefix(o, "http://") && !strings.HasPrefix(o, "https://") || strings.HasSuffix(o, "/") {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.CORS.AllowedOrigins", o, "origin must be \"*\" or in the form of scheme://host[:port]"))
+		}
+	}
+
+	for _, m := range c.AllowedMethods {
+		if !isValidHTTPMethod(m) {
+			result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "HTTPTriggerSpec.CORS.AllowedMethods", m, "not a valid HTTP method"))
+		}
+	}
+
+	for _, h := range c.AllowedHeaders {
+		if h != "*" && !httpguts.ValidHeaderFieldName(h) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.CORS.AllowedHeaders", h, "not a valid header name"))
+		}
+	}
+
+	for _, h := range c.ExposedHeaders {
+		if !httpguts.ValidHeaderFieldName(h) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.CORS.ExposedHeaders", h, "not a valid header name"))
+		}
+	}
+
+	if c.MaxAge < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.CORS.MaxAge", c.MaxAge, "must be greater than or equal to 0"))
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (a TriggerAuth) Validate() error {
+	result := &multierror.Error{}
+
+	switch a.Type {
+	case TriggerAuthTypeAPIKey, TriggerAuthTypeHMAC, TriggerAuthTypeBasic, TriggerAuthTypeJWT: // no op
+	default:
+		result = multierror.Append(result, MakeValidationErr(ErrorUnsupportedType, "HTTPTriggerSpec.Auth.Type", a.Type, "not a valid auth type"))
+	}
+
+	e := validation.IsDNS1123Subdomain(a.SecretName)
+	if len(e) > 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.Auth.SecretName", a.SecretName, e...))
+	}
+
+	if len(a.Header) > 0 && !httpguts.ValidHeaderFieldName(a.Header) {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.Auth.Header", a.Header, "not a valid header name"))
+	}
+
+	for _, c := range a.ForwardClaims {
+		if !httpguts.ValidHeaderFieldName(c) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.Auth.ForwardClaims", c, "claim name must be usable in a header name"))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (rc ResponseCache) Validate() error {
+	result := &multierror.Error{}
+
+	if rc.TTL <= 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.ResponseCache.TTL", rc.TTL, "must be greater than 0"))
+	}
+
+	if rc.MaxEntries < 0 {
+		result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.ResponseCache.MaxEntries", rc.MaxEntries, "must be greater than or equal to 0"))
+	}
+
+	for _, h := range rc.VaryByHeaders {
+		if !httpguts.ValidHeaderFieldName(h) {
+			result = multierror.Append(result, MakeValidationErr(ErrorInvalidValue, "HTTPTriggerSpec.ResponseCache.VaryByHeaders", h, "not a valid header name"))
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (a *FunctionAlias) Validate() error {
+	result := &multierror.Error{}
+
+	result = multierror.Append(result,
+		validateMetadata("FunctionAlias", a.ObjectMeta),
+		a.Spec.Validate())
+
+	return result.ErrorOrNil()
+}
+
+func (al *FunctionAliasList) Validate() error {
+	result := &multierror.Error{}
+	for _, a := range al.Items {
+		result = multierror.Append(result, a.Validate())
+	}
+	return result.ErrorOrNil()
+}
@@ -0,0 +1,541 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+)
+
+func TestParseRouteTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tpl     string
+		params  []string
+		match   []string
+		noMatch []string
+		wantErr bool
+	}{
+		{
+			name:    "plain path",
+			tpl:     "/foo/bar",
+			match:   []string{"/foo/bar"},
+			noMatch: []string{"/foo", "/foo/bar/baz"},
+		},
+		{
+			name:    "path parameter",
+			tpl:     "/users/{id}",
+			params:  []string{"id"},
+			match:   []string{"/users/1", "/users/abc"},
+			noMatch: []string{"/users/", "/users/1/2"},
+		},
+		{
+			name:    "regex path parameters",
+			tpl:     "/users/{id:[0-9]+}/orders/{order:[a-z]{3}}",
+			params:  []string{"id", "order"},
+			match:   []string{"/users/12/orders/abc"},
+			noMatch: []string{"/users/ab/orders/abc", "/users/12/orders/abcd"},
+		},
+		{
+			name:    "not absolute",
+			tpl:     "users/{id}",
+			wantErr: true,
+		},
+		{
+			name:    "unbalanced braces",
+			tpl:     "/users/{id",
+			wantErr: true,
+		},
+		{
+			name:    "invalid name",
+			tpl:     "/users/{user id}",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate name",
+			tpl:     "/users/{id}/{ID}",
+			wantErr: true,
+		},
+		{
+			name:    "invalid regex",
+			tpl:     "/users/{id:[0-9}",
+			wantErr: true,
+		},
+		{
+			name:    "capturing group",
+			tpl:     "/users/{id:([0-9]+)}",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, params, err := ParseRouteTemplate(tt.tpl)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRouteTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(params) != len(tt.params) {
+				t.Fatalf("ParseRouteTemplate() params = %v, want %v", params, tt.params)
+			}
+			for i := range params {
+				if params[i] != tt.params[i] {
+					t.Errorf("ParseRouteTemplate() params = %v, want %v", params, tt.params)
+				}
+			}
+			for _, p := range tt.match {
+				if !re.MatchString(p) {
+					t.Errorf("%v should match %v", tt.tpl, p)
+				}
+			}
+			for _, p := range tt.noMatch {
+				if re.MatchString(p) {
+					t.Errorf("%v should not match %v", tt.tpl, p)
+				}
+			}
+		})
+	}
+}
+
+func TestRouteTemplatesOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"/foo", "/foo", true},
+		{"/foo", "/bar", false},
+		{"/users/{id}", "/users/{name}", true},
+		{"/users/{id}", "/users/admin", true},
+		{"/users/{id:[0-9]+}", "/users/admin", false},
+		{"/users/{id:[0-9]+}", "/users/{name:[a-z]+}", false},
+		{"/users/{id}", "/users/{id}/orders", false},
+		{"/files/{path:.+}", "/files/a/b", true},
+		{"/a/{x:[a-z]{5}}", "/a/{y:[a-z]+}", true},
+		{"/a/{x:[a-z]{5}}", "/a/{y:[a-z]{1,4}}", false},
+		{"/a/{x:(?i)abc}", "/a/ABC", true},
+		{"/a/{x:[0-9]+}-{y}", "/a/{z:[a-z]+}-b", false},
+		{"/a/{x:[0-9]+}-{y}", "/a/1-{z:[a-z]+}", true},
+	}
+
+	for _, tt := range tests {
+		if got := RouteTemplatesOverlap(tt.a, tt.b); got != tt.want {
+			t.Errorf("RouteTemplatesOverlap(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := RouteTemplatesOverlap(tt.b, tt.a); got != tt.want {
+			t.Errorf("RouteTemplatesOverlap(%v, %v) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestRoutesOverlap(t *testing.T) {
+	tests := []struct {
+		a       string
+		aPrefix bool
+		b       string
+		bPrefix bool
+		want    bool
+	}{
+		{"/pkg.", true, "/pkg.Greeter/", true, true},
+		{"/pkg.Greeter/", true, "/pkg.Other/", true, false},
+		{"/pkg.Greeter/", true, "/pkg.Greeter/SayHello", false, true},
+		{"/pkg.Greeter/", true, "/pkg.Greeter", false, false},
+		{"/pkg.Greeter/", true, "/{svc}/SayHello", false, true},
+		{"/pkg.Greeter/", true, "/other/{name}", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := RoutesOverlap(tt.a, tt.aPrefix, tt.b, tt.bPrefix); got != tt.want {
+			t.Errorf("RoutesOverlap(%v, %v, %v, %v) = %v, want %v", tt.a, tt.aPrefix, tt.b, tt.bPrefix, got, tt.want)
+		}
+		if got := RoutesOverlap(tt.b, tt.bPrefix, tt.a, tt.aPrefix); got != tt.want {
+			t.Errorf("RoutesOverlap(%v, %v, %v, %v) = %v, want %v", tt.b, tt.bPrefix, tt.a, tt.aPrefix, got, tt.want)
+		}
+	}
+}
+
+func TestCORSPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  CORSPolicy
+		wantErr bool
+	}{
+		{name: "any origin", policy: CORSPolicy{AllowedOrigins: []string{"*"}}},
+		{name: "origins with credentials", policy: CORSPolicy{AllowedOrigins: []string{"https://example.com", "https://*.example.com"}, AllowCredentials: true}},
+		{name: "any origin with credentials", policy: CORSPolicy{AllowedOrigins: []string{"*"}, AllowCredentials: true}, wantErr: true},
+		{name: "no origin", policy: CORSPolicy{}, wantErr: true},
+		{name: "origin with path", policy: CORSPolicy{AllowedOrigins: []string{"https://example.com/"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPTriggerSpecValidateRelativeURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "/users/{id:[0-9]+}"},
+		{url: "users", wantErr: true},
+		{url: "/users/{id", wantErr: true},
+		{url: "/users/{id:(a|b)}", wantErr: true},
+		{url: "/users/{id}/{ID}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		spec := HTTPTriggerSpec{
+			RelativeURL: tt.url,
+			Method:      "GET",
+			FunctionReference: FunctionReference{
+				Type: FunctionReferenceTypeFunctionName,
+				Name: "foo",
+			},
+		}
+		if err := spec.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate() of %v error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
+
+func TestHTTPTriggerSpecValidateAsyncCallbackHosts(t *testing.T) {
+	tests := []struct {
+		name    string
+		hosts   []string
+		wantErr bool
+	}{
+		{name: "no hosts"},
+		{name: "hosts", hosts: []string{"hooks.example.com", "*.example.org"}},
+		{name: "url instead of host", hosts: []string{"https://hooks.example.com"}, wantErr: true},
+		{name: "wildcard in the middle", hosts: []string{"hooks.*.example.com"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := HTTPTriggerSpec{
+				RelativeURL:        "/foo",
+				Method:             "GET",
+				AsyncCallbackHosts: tt.hosts,
+				FunctionReference: FunctionReference{
+					Type: FunctionReferenceTypeFunctionName,
+					Name: "foo",
+				},
+			}
+			err := spec.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTPTriggerSpecValidateMethods(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		methods  []string
+		protocol HTTPTriggerProtocol
+		wantErr  bool
+	}{
+		{name: "single method", method: "GET"},
+		{name: "invalid method", method: "FOO", wantErr: true},
+		{name: "multiple methods", methods: []string{"GET", "POST", "DELETE"}},
+		{name: "methods take precedence over method", method: "FOO", methods: []string{"GET"}},
+		{name: "invalid method in methods", methods: []string{"GET", "get"}, wantErr: true},
+		{name: "duplicate methods", methods: []string{"GET", "GET"}, wantErr: true},
+		{name: "grpc post", method: "POST", protocol: HTTPTriggerProtocolGRPC},
+		{name: "grpc get", method: "GET", protocol: HTTPTriggerProtocolGRPC, wantErr: true},
+		{name: "grpc methods", methods: []string{"POST", "GET"}, protocol: HTTPTriggerProtocolGRPC, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := HTTPTriggerSpec{
+				RelativeURL: "/foo",
+				Method:      tt.method,
+				Methods:     tt.methods,
+				Protocol:    tt.protocol,
+				FunctionReference: FunctionReference{
+					Type: FunctionReferenceTypeFunctionName,
+					Name: "foo",
+				},
+			}
+			err := spec.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFunctionAliasSpecValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    FunctionAliasSpec
+		wantErr bool
+	}{
+		{name: "function name", spec: FunctionAliasSpec{FunctionName: "foo-v7"}},
+		{name: "no function", spec: FunctionAliasSpec{}, wantErr: true},
+		{name: "function weights", spec: FunctionAliasSpec{FunctionWeights: map[string]int{"foo-v7": 90, "foo-v8": 10}}},
+		{name: "weights not add up to 100", spec: FunctionAliasSpec{FunctionWeights: map[string]int{"foo-v7": 90, "foo-v8": 20}}, wantErr: true},
+		{name: "negative weight", spec: FunctionAliasSpec{FunctionWeights: map[string]int{"foo-v7": 110, "foo-v8": -10}}, wantErr: true},
+		{name: "invalid function name", spec: FunctionAliasSpec{FunctionWeights: map[string]int{"Foo": 100}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFunctionReferenceValidateSelector(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     FunctionReference
+		wantErr bool
+	}{
+		{name: "selector", ref: FunctionReference{Type: FunctionReferenceTypeFunctionSelector, Selector: map[string]string{"app": "foo"}}},
+		{name: "least inflight", ref: FunctionReference{Type: FunctionReferenceTypeFunctionSelector, Selector: map[string]string{"app": "foo"}, LoadBalancing: LoadBalancingLeastInflight}},
+		{name: "empty selector", ref: FunctionReference{Type: FunctionReferenceTypeFunctionSelector}, wantErr: true},
+		{name: "invalid label", ref: FunctionReference{Type: FunctionReferenceTypeFunctionSelector, Selector: map[string]string{"app": "foo bar"}}, wantErr: true},
+		{name: "unsupported load balancing", ref: FunctionReference{Type: FunctionReferenceTypeFunctionSelector, Selector: map[string]string{"app": "foo"}, LoadBalancing: "random"}, wantErr: true},
+		{name: "selector of other type", ref: FunctionReference{Type: FunctionReferenceTypeFunctionName, Name: "foo", Selector: map[string]string{"app": "foo"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ref.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// time, message queue and kubewatch triggers reach a single function by its router path
+	spec := TimeTriggerSpec{
+		Cron:              "@every 1m",
+		FunctionReference: tests[0].ref,
+	}
+	if err := spec.Validate(); err == nil {
+		t.Error("Validate() of time trigger with function selector succeeded")
+	}
+}
+
+func TestExecutionStrategyValidateConcurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		es      ExecutionStrategy
+		wantErr bool
+	}{
+		{name: "poolmgr", es: ExecutionStrategy{ExecutorType: ExecutorTypePoolmgr, ConcurrencyPerInstance: 10}},
+		{name: "poolmgr without limit", es: ExecutionStrategy{ExecutorType: ExecutorTypePoolmgr}},
+		{name: "negative", es: ExecutionStrategy{ExecutorType: ExecutorTypePoolmgr, ConcurrencyPerInstance: -1}, wantErr: true},
+		{name: "newdeploy", es: ExecutionStrategy{ExecutorType: ExecutorTypeNewdeploy, MaxScale: 1, TargetCPUPercent: 80, ConcurrencyPerInstance: 10}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.es.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecutionStrategyValidateIdleTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		es      ExecutionStrategy
+		wantErr bool
+	}{
+		{name: "poolmgr", es: ExecutionStrategy{ExecutorType: ExecutorTypePoolmgr, IdleTimeout: 600, NeverScaleToZero: true}},
+		{name: "newdeploy", es: ExecutionStrategy{ExecutorType: ExecutorTypeNewdeploy, MaxScale: 1, TargetCPUPercent: 80, IdleTimeout: 30}},
+		{name: "negative", es: ExecutionStrategy{ExecutorType: ExecutorTypePoolmgr, IdleTimeout: -1}, wantErr: true},
+		{name: "newdeploy never scale to zero", es: ExecutionStrategy{ExecutorType: ExecutorTypeNewdeploy, MaxScale: 1, TargetCPUPercent: 80, NeverScaleToZero: true}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.es.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecutionStrategyValidateScalingTargets(t *testing.T) {
+	newdeploy := func(es ExecutionStrategy) ExecutionStrategy {
+		es.ExecutorType, es.MaxScale, es.TargetCPUPercent = ExecutorTypeNewdeploy, 3, 80
+		return es
+	}
+	tests := []struct {
+		name    string
+		es      ExecutionStrategy
+		wantErr bool
+	}{
+		{name: "concurrency and rps", es: newdeploy(ExecutionStrategy{TargetConcurrency: 10, TargetRequestsPerSecond: 50})},
+		{name: "pods metric", es: newdeploy(ExecutionStrategy{ScaleMetricName: "queue_depth", ScaleMetricTarget: "500m"})},
+		{name: "external metric", es: newdeploy(ExecutionStrategy{ScaleMetricName: "queue_depth", ScaleMetricType: ScaleMetricTypeExternal, ScaleMetricTarget: "30"})},
+		{name: "negative concurrency", es: newdeploy(ExecutionStrategy{TargetConcurrency: -1}), wantErr: true},
+		{name: "unknown metric type", es: newdeploy(ExecutionStrategy{ScaleMetricName: "queue_depth", ScaleMetricType: "object", ScaleMetricTarget: "30"}), wantErr: true},
+		{name: "invalid metric target", es: newdeploy(ExecutionStrategy{ScaleMetricName: "queue_depth", ScaleMetricTarget: "lots"}), wantErr: true},
+		{name: "metric target without name", es: newdeploy(ExecutionStrategy{ScaleMetricTarget: "30"}), wantErr: true},
+		{name: "poolmgr", es: ExecutionStrategy{ExecutorType: ExecutorTypePoolmgr, TargetConcurrency: 10}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.es.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFunctionSpecValidateContainer(t *testing.T) {
+	strategy := InvokeStrategy{
+		StrategyType: StrategyTypeExecution,
+		ExecutionStrategy: ExecutionStrategy{
+			ExecutorType:     ExecutorTypeContainer,
+			MaxScale:         3,
+			TargetCPUPercent: 80,
+		},
+	}
+	container := &FunctionContainer{Image: "nginx:1.17", Port: 8080}
+	tests := []struct {
+		name    string
+		spec    FunctionSpec
+		wantErr bool
+	}{
+		{name: "container", spec: FunctionSpec{InvokeStrategy: strategy, Container: container}},
+		{name: "no container", spec: FunctionSpec{InvokeStrategy: strategy}, wantErr: true},
+		{name: "no image", spec: FunctionSpec{InvokeStrategy: strategy, Container: &FunctionContainer{Port: 8080}}, wantErr: true},
+		{name: "invalid port", spec: FunctionSpec{InvokeStrategy: strategy, Container: &FunctionContainer{Image: "nginx:1.17", Port: 70000}}, wantErr: true},
+		{name: "no port", spec: FunctionSpec{InvokeStrategy: strategy, Container: &FunctionContainer{Image: "nginx:1.17"}}, wantErr: true},
+		{
+			name: "container with environment",
+			spec: FunctionSpec{
+				InvokeStrategy: strategy,
+				Container:      container,
+				Environment:    EnvironmentReference{Name: "nodejs", Namespace: "default"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "container with secret",
+			spec: FunctionSpec{
+				InvokeStrategy: strategy,
+				Container:      container,
+				Secrets:        []SecretReference{{Name: "creds", Namespace: "default"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "newdeploy with container",
+			spec: FunctionSpec{
+				InvokeStrategy: InvokeStrategy{
+					StrategyType:      StrategyTypeExecution,
+					ExecutionStrategy: ExecutionStrategy{ExecutorType: ExecutorTypeNewdeploy, MaxScale: 1, TargetCPUPercent: 80},
+				},
+				Container: container,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFunctionSpecValidateJob(t *testing.T) {
+	strategy := InvokeStrategy{
+		StrategyType:      StrategyTypeExecution,
+		ExecutionStrategy: ExecutionStrategy{ExecutorType: ExecutorTypeJob},
+	}
+	container := &FunctionContainer{Image: "busybox:1.31", Command: []string{"sh", "-c", "cat $FISSION_REQUEST_BODY"}}
+	tests := []struct {
+		name    string
+		spec    FunctionSpec
+		wantErr bool
+	}{
+		{name: "job", spec: FunctionSpec{InvokeStrategy: strategy, Container: container, FunctionTimeout: 600}},
+		{name: "no container", spec: FunctionSpec{InvokeStrategy: strategy}, wantErr: true},
+		{
+			name:    "no command",
+			spec:    FunctionSpec{InvokeStrategy: strategy, Container: &FunctionContainer{Image: "busybox:1.31"}},
+			wantErr: true,
+		},
+		{
+			name: "job with package",
+			spec: FunctionSpec{
+				InvokeStrategy: strategy,
+				Container:      container,
+				Package:        FunctionPackageRef{PackageRef: PackageRef{Name: "pkg", Namespace: "default"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "job with concurrency per instance",
+			spec: FunctionSpec{
+				InvokeStrategy: InvokeStrategy{
+					StrategyType:      StrategyTypeExecution,
+					ExecutionStrategy: ExecutionStrategy{ExecutorType: ExecutorTypeJob, ConcurrencyPerInstance: 2},
+				},
+				Container: container,
+			},
+			wantErr: true,
+		},
+		{
+			name: "job with minimum scale",
+			spec: FunctionSpec{
+				InvokeStrategy: InvokeStrategy{
+					StrategyType:      StrategyTypeExecution,
+					ExecutionStrategy: ExecutionStrategy{ExecutorType: ExecutorTypeJob, MinScale: 1},
+				},
+				Container: container,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
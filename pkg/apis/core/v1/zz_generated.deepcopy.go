@@ -73,6 +73,42 @@ func (in *Builder) DeepCopy() *Builder {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CORSPolicy) DeepCopyInto(out *CORSPolicy) {
+	*out = *in
+	if in.AllowedOrigins != nil {
+		in, out := &in.AllowedOrigins, &out.AllowedOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedMethods != nil {
+		in, out := &in.AllowedMethods, &out.AllowedMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedHeaders != nil {
+		in, out := &in.AllowedHeaders, &out.AllowedHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposedHeaders != nil {
+		in, out := &in.ExposedHeaders, &out.ExposedHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CORSPolicy.
+func (in *CORSPolicy) DeepCopy() *CORSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CORSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CanaryConfig) DeepCopyInto(out *CanaryConfig) {
 	*out = *in
@@ -166,6 +202,69 @@ func (in *CanaryConfigStatus) DeepCopy() *CanaryConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryHashBucket) DeepCopyInto(out *CanaryHashBucket) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryHashBucket.
+func (in *CanaryHashBucket) DeepCopy() *CanaryHashBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryHashBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryMatch) DeepCopyInto(out *CanaryMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryMatch.
+func (in *CanaryMatch) DeepCopy() *CanaryMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryRule) DeepCopyInto(out *CanaryRule) {
+	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(CanaryMatch)
+		**out = **in
+	}
+	if in.Cookie != nil {
+		in, out := &in.Cookie, &out.Cookie
+		*out = new(CanaryMatch)
+		**out = **in
+	}
+	if in.HashBucket != nil {
+		in, out := &in.HashBucket, &out.HashBucket
+		*out = new(CanaryHashBucket)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryRule.
+func (in *CanaryRule) DeepCopy() *CanaryRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Checksum) DeepCopyInto(out *Checksum) {
 	*out = *in
@@ -336,6 +435,115 @@ func (in *Function) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionAlias) DeepCopyInto(out *FunctionAlias) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionAlias.
+func (in *FunctionAlias) DeepCopy() *FunctionAlias {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionAlias)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FunctionAlias) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionAliasList) DeepCopyInto(out *FunctionAliasList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FunctionAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionAliasList.
+func (in *FunctionAliasList) DeepCopy() *FunctionAliasList {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionAliasList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FunctionAliasList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionAliasSpec) DeepCopyInto(out *FunctionAliasSpec) {
+	*out = *in
+	if in.FunctionWeights != nil {
+		in, out := &in.FunctionWeights, &out.FunctionWeights
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionAliasSpec.
+func (in *FunctionAliasSpec) DeepCopy() *FunctionAliasSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionAliasSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionContainer) DeepCopyInto(out *FunctionContainer) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionContainer.
+func (in *FunctionContainer) DeepCopy() *FunctionContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FunctionList) DeepCopyInto(out *FunctionList) {
 	*out = *in
@@ -396,6 +604,25 @@ func (in *FunctionReference) DeepCopyInto(out *FunctionReference) {
 			(*out)[key] = val
 		}
 	}
+	if in.CanaryRules != nil {
+		in, out := &in.CanaryRules, &out.CanaryRules
+		*out = make([]CanaryRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StickySession != nil {
+		in, out := &in.StickySession, &out.StickySession
+		*out = new(StickySession)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -426,6 +653,11 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	out.InvokeStrategy = in.InvokeStrategy
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(FunctionContainer)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -439,6 +671,44 @@ func (in *FunctionSpec) DeepCopy() *FunctionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPTransform) DeepCopyInto(out *HTTPTransform) {
+	*out = *in
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = new(HeaderTransform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = new(HeaderTransform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PathPrefix != nil {
+		in, out := &in.PathPrefix, &out.PathPrefix
+		*out = new(PathPrefixRewrite)
+		**out = **in
+	}
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPTransform.
+func (in *HTTPTransform) DeepCopy() *HTTPTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPTrigger) DeepCopyInto(out *HTTPTrigger) {
 	*out = *in
@@ -502,8 +772,53 @@ func (in *HTTPTriggerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPTriggerSpec) DeepCopyInto(out *HTTPTriggerSpec) {
 	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.FunctionReference.DeepCopyInto(&out.FunctionReference)
 	in.IngressConfig.DeepCopyInto(&out.IngressConfig)
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		**out = **in
+	}
+	if in.Transform != nil {
+		in, out := &in.Transform, &out.Transform
+		*out = new(HTTPTransform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(CORSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(TriggerAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseCache != nil {
+		in, out := &in.ResponseCache, &out.ResponseCache
+		*out = new(ResponseCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(TrafficMirror)
+		**out = **in
+	}
+	if in.Streaming != nil {
+		in, out := &in.Streaming, &out.Streaming
+		*out = new(StreamingConfig)
+		**out = **in
+	}
+	if in.AsyncCallbackHosts != nil {
+		in, out := &in.AsyncCallbackHosts, &out.AsyncCallbackHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -517,6 +832,41 @@ func (in *HTTPTriggerSpec) DeepCopy() *HTTPTriggerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderTransform) DeepCopyInto(out *HeaderTransform) {
+	*out = *in
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rename != nil {
+		in, out := &in.Rename, &out.Rename
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderTransform.
+func (in *HeaderTransform) DeepCopy() *HeaderTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IngressConfig) DeepCopyInto(out *IngressConfig) {
 	*out = *in
@@ -831,6 +1181,59 @@ func (in *PackageStatus) DeepCopy() *PackageStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PathPrefixRewrite) DeepCopyInto(out *PathPrefixRewrite) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PathPrefixRewrite.
+func (in *PathPrefixRewrite) DeepCopy() *PathPrefixRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(PathPrefixRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimit) DeepCopyInto(out *RateLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimit.
+func (in *RateLimit) DeepCopy() *RateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseCache) DeepCopyInto(out *ResponseCache) {
+	*out = *in
+	if in.VaryByHeaders != nil {
+		in, out := &in.VaryByHeaders, &out.VaryByHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseCache.
+func (in *ResponseCache) DeepCopy() *ResponseCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Runtime) DeepCopyInto(out *Runtime) {
 	*out = *in
@@ -873,6 +1276,38 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StickySession) DeepCopyInto(out *StickySession) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StickySession.
+func (in *StickySession) DeepCopy() *StickySession {
+	if in == nil {
+		return nil
+	}
+	out := new(StickySession)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamingConfig) DeepCopyInto(out *StreamingConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamingConfig.
+func (in *StreamingConfig) DeepCopy() *StreamingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TimeTrigger) DeepCopyInto(out *TimeTrigger) {
 	*out = *in
@@ -950,6 +1385,43 @@ func (in *TimeTriggerSpec) DeepCopy() *TimeTriggerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficMirror) DeepCopyInto(out *TrafficMirror) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficMirror.
+func (in *TrafficMirror) DeepCopy() *TrafficMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerAuth) DeepCopyInto(out *TriggerAuth) {
+	*out = *in
+	if in.ForwardClaims != nil {
+		in, out := &in.ForwardClaims, &out.ForwardClaims
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerAuth.
+func (in *TriggerAuth) DeepCopy() *TriggerAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValidationError) DeepCopyInto(out *ValidationError) {
 	*out = *in
@@ -29,6 +29,7 @@ type CoreV1Interface interface {
 	CanaryConfigsGetter
 	EnvironmentsGetter
 	FunctionsGetter
+	FunctionAliasesGetter
 	HTTPTriggersGetter
 	KubernetesWatchTriggersGetter
 	MessageQueueTriggersGetter
@@ -53,6 +54,10 @@ func (c *CoreV1Client) Functions(namespace string) FunctionInterface {
 	return newFunctions(c, namespace)
 }
 
+func (c *CoreV1Client) FunctionAliases(namespace string) FunctionAliasInterface {
+	return newFunctionAliases(c, namespace)
+}
+
 func (c *CoreV1Client) HTTPTriggers(namespace string) HTTPTriggerInterface {
 	return newHTTPTriggers(c, namespace)
 }
@@ -40,6 +40,10 @@ func (c *FakeCoreV1) Functions(namespace string) v1.FunctionInterface {
 	return &FakeFunctions{c, namespace}
 }
 
+func (c *FakeCoreV1) FunctionAliases(namespace string) v1.FunctionAliasInterface {
+	return &FakeFunctionAliases{c, namespace}
+}
+
 func (c *FakeCoreV1) HTTPTriggers(namespace string) v1.HTTPTriggerInterface {
 	return &FakeHTTPTriggers{c, namespace}
 }
@@ -24,6 +24,8 @@ type EnvironmentExpansion interface{}
 
 type FunctionExpansion interface{}
 
+type FunctionAliasExpansion interface{}
+
 type HTTPTriggerExpansion interface{}
 
 type KubernetesWatchTriggerExpansion interface{}
@@ -30,6 +30,8 @@ type Interface interface {
 	Environments() EnvironmentInformer
 	// Functions returns a FunctionInformer.
 	Functions() FunctionInformer
+	// FunctionAliases returns a FunctionAliasInformer.
+	FunctionAliases() FunctionAliasInformer
 	// HTTPTriggers returns a HTTPTriggerInformer.
 	HTTPTriggers() HTTPTriggerInformer
 	// KubernetesWatchTriggers returns a KubernetesWatchTriggerInformer.
@@ -68,6 +70,11 @@ func (v *version) Functions() FunctionInformer {
 	return &_functionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// FunctionAliases returns a FunctionAliasInformer.
+func (v *version) FunctionAliases() FunctionAliasInformer {
+	return &_functionAliasInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // HTTPTriggers returns a HTTPTriggerInformer.
 func (v *version) HTTPTriggers() HTTPTriggerInformer {
 	return &_hTTPTriggerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -42,6 +42,14 @@ type FunctionListerExpansion interface{}
 // FunctionNamespaceLister.
 type FunctionNamespaceListerExpansion interface{}
 
+// FunctionAliasListerExpansion allows custom methods to be added to
+// FunctionAliasLister.
+type FunctionAliasListerExpansion interface{}
+
+// FunctionAliasNamespaceListerExpansion allows custom methods to be added to
+// FunctionAliasNamespaceLister.
+type FunctionAliasNamespaceListerExpansion interface{}
+
 // HTTPTriggerListerExpansion allows custom methods to be added to
 // HTTPTriggerLister.
 type HTTPTriggerListerExpansion interface{}
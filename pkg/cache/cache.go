@@ -17,6 +17,7 @@ limitations under the License.
 package cache
 
 import (
+	"container/list"
 	"fmt"
 	"time"
 
@@ -37,19 +38,29 @@ type (
 	Value struct {
 		ctime time.Time
 		atime time.Time
-		value interface{}
+		// expiry is the deadline of the value, zero means no deadline.
+		expiry time.Time
+		value  interface{}
+		// elem is the element of key in the LRU list
+		elem *list.Element
 	}
 	Cache struct {
-		cache          map[interface{}]*Value
+		cache map[interface{}]*Value
+		// lru orders the keys from the most to the least recently
+		// accessed, it's only kept when maxEntries is set.
+		lru            *list.List
 		ctimeExpiry    time.Duration
 		atimeExpiry    time.Duration
+		maxEntries     int
 		requestChannel chan *request
+		done           chan struct{}
 	}
 
 	request struct {
 		requestType
 		key             interface{}
 		value           interface{}
+		ttl             time.Duration
 		responseChannel chan *response
 	}
 	response struct {
@@ -69,26 +80,70 @@ func (c *Cache) IsOld(v *Value) bool {
 		return true
 	}
 
+	if !v.expiry.IsZero() && time.Now().After(v.expiry) {
+		return true
+	}
+
 	return false
 }
 
 func MakeCache(ctimeExpiry, atimeExpiry time.Duration) *Cache {
+	return MakeLRUCache(ctimeExpiry, atimeExpiry, 0)
+}
+
+// MakeLRUCache makes a cache that holds at most maxEntries values, the least
+// recently accessed value is evicted to make room for a new one. 0 means no limit.
+func MakeLRUCache(ctimeExpiry, atimeExpiry time.Duration, maxEntries int) *Cache {
 	c := &Cache{
 		cache:          make(map[interface{}]*Value),
 		ctimeExpiry:    ctimeExpiry,
 		atimeExpiry:    atimeExpiry,
+		maxEntries:     maxEntries,
 		requestChannel: make(chan *request),
+		done:           make(chan struct{}),
+	}
+	if maxEntries > 0 {
+		c.lru = list.New()
 	}
 	go c.service()
-	if ctimeExpiry != time.Duration(0) || atimeExpiry != time.Duration(0) {
+	if ctimeExpiry != time.Duration(0) || atimeExpiry != time.Duration(0) || maxEntries > 0 {
 		go c.expiryService()
 	}
 	return c
 }
 
+// remove removes the value of key from the cache.
+func (c *Cache) remove(key interface{}) {
+	if v, ok := c.cache[key]; ok && v.elem != nil {
+		c.lru.Remove(v.elem)
+	}
+	delete(c.cache, key)
+}
+
+// touch marks the value as the most recently accessed one.
+func (c *Cache) touch(v *Value) {
+	v.atime = time.Now()
+	if v.elem != nil {
+		c.lru.MoveToFront(v.elem)
+	}
+}
+
+// evict removes the least recently accessed values until
+// there is room for a new one.
+func (c *Cache) evict() {
+	for len(c.cache) >= c.maxEntries && c.lru.Len() > 0 {
+		c.remove(c.lru.Back().Value)
+	}
+}
+
 func (c *Cache) service() {
 	for {
-		req := <-c.requestChannel
+		var req *request
+		select {
+		case req = <-c.requestChannel:
+		case <-c.done:
+			return
+		}
 		resp := &response{}
 		switch req.requestType {
 		case GET:
@@ -99,36 +154,43 @@ func (c *Cache) service() {
 			} else if c.IsOld(val) {
 				resp.error = ferror.MakeError(ferror.ErrorNotFound,
 					fmt.Sprintf("key '%v' expired (atime %v)", req.key, val.atime))
-				delete(c.cache, req.key)
+				c.remove(req.key)
 			} else {
-				// update atime
-				val.atime = time.Now()
-				c.cache[req.key] = val
+				c.touch(val)
 				resp.value = val.value
 			}
 			req.responseChannel <- resp
 		case SET:
 			now := time.Now()
-			if _, ok := c.cache[req.key]; ok {
-				val := c.cache[req.key]
-				val.atime = time.Now()
+			if val, ok := c.cache[req.key]; ok {
+				c.touch(val)
 				resp.existingValue = val.value
 				resp.error = ferror.MakeError(ferror.ErrorNameExists, "key already exists")
 			} else {
-				c.cache[req.key] = &Value{
+				if c.maxEntries > 0 && len(c.cache) >= c.maxEntries {
+					c.evict()
+				}
+				val := &Value{
 					value: req.value,
 					ctime: now,
 					atime: now,
 				}
+				if req.ttl > 0 {
+					val.expiry = now.Add(req.ttl)
+				}
+				if c.lru != nil {
+					val.elem = c.lru.PushFront(req.key)
+				}
+				c.cache[req.key] = val
 			}
 			req.responseChannel <- resp
 		case DELETE:
-			delete(c.cache, req.key)
+			c.remove(req.key)
 			req.responseChannel <- resp
 		case EXPIRE:
 			for k, v := range c.cache {
 				if c.IsOld(v) {
-					delete(c.cache, k)
+					c.remove(k)
 				}
 			}
 			// no response
@@ -146,57 +208,79 @@ func (c *Cache) service() {
 	}
 }
 
-func (c *Cache) Get(key interface{}) (interface{}, error) {
-	respChannel := make(chan *response)
-	c.requestChannel <- &request{
-		requestType:     GET,
-		key:             key,
-		responseChannel: respChannel,
+// send sends the request to the cache service and waits for the response.
+func (c *Cache) send(req *request) *response {
+	req.responseChannel = make(chan *response)
+	select {
+	case c.requestChannel <- req:
+		return <-req.responseChannel
+	case <-c.done:
+		return &response{
+			error: ferror.MakeError(ferror.ErrorNotFound, "cache is stopped"),
+		}
 	}
-	resp := <-respChannel
+}
+
+func (c *Cache) Get(key interface{}) (interface{}, error) {
+	resp := c.send(&request{
+		requestType: GET,
+		key:         key,
+	})
 	return resp.value, resp.error
 }
 
 // if key exists in the cache, the new value is NOT set; instead an
 // error and the old value are returned
 func (c *Cache) Set(key interface{}, value interface{}) (interface{}, error) {
-	respChannel := make(chan *response)
-	c.requestChannel <- &request{
-		requestType:     SET,
-		key:             key,
-		value:           value,
-		responseChannel: respChannel,
-	}
-	resp := <-respChannel
+	return c.SetWithTTL(key, value, 0)
+}
+
+// SetWithTTL is like Set, but the value expires after ttl in addition to
+// the expiry of cache. 0 means the value only expires with the cache expiry.
+func (c *Cache) SetWithTTL(key interface{}, value interface{}, ttl time.Duration) (interface{}, error) {
+	resp := c.send(&request{
+		requestType: SET,
+		key:         key,
+		value:       value,
+		ttl:         ttl,
+	})
 	return resp.existingValue, resp.error
 }
 
 func (c *Cache) Delete(key interface{}) error {
-	respChannel := make(chan *response)
-	c.requestChannel <- &request{
-		requestType:     DELETE,
-		key:             key,
-		responseChannel: respChannel,
-	}
-	resp := <-respChannel
+	resp := c.send(&request{
+		requestType: DELETE,
+		key:         key,
+	})
 	return resp.error
 }
 
 func (c *Cache) Copy() map[interface{}]interface{} {
-	respChannel := make(chan *response)
-	c.requestChannel <- &request{
-		requestType:     COPY,
-		responseChannel: respChannel,
-	}
-	resp := <-respChannel
+	resp := c.send(&request{
+		requestType: COPY,
+	})
 	return resp.mapCopy
 }
 
+// Stop stops the cache goroutines. Requests made after Stop
+// return ErrorNotFound error instead of blocking forever.
+func (c *Cache) Stop() {
+	close(c.done)
+}
+
 func (c *Cache) expiryService() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
 	for {
-		time.Sleep(time.Minute)
-		c.requestChannel <- &request{
-			requestType: EXPIRE,
+		select {
+		case <-ticker.C:
+		case <-c.done:
+			return
+		}
+		select {
+		case c.requestChannel <- &request{requestType: EXPIRE}:
+		case <-c.done:
+			return
 		}
 	}
 }
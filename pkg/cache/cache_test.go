@@ -63,3 +63,53 @@ func TestCache(t *testing.T) {
 		log.Panicf("found expired element")
 	}
 }
+
+func TestLRUCache(t *testing.T) {
+	c := MakeLRUCache(0, 0, 2)
+	defer c.Stop()
+
+	_, err := c.Set("a", 1)
+	checkErr(err)
+	time.Sleep(time.Millisecond)
+	_, err = c.Set("b", 2)
+	checkErr(err)
+	time.Sleep(time.Millisecond)
+
+	// access "a" so that "b" is the least recently used
+	_, err = c.Get("a")
+	checkErr(err)
+
+	_, err = c.Set("c", 3)
+	checkErr(err)
+	if _, err = c.Get("b"); err == nil {
+		log.Panicf("least recently used element not evicted")
+	}
+	if _, err = c.Get("a"); err != nil {
+		log.Panicf("recently used element evicted")
+	}
+
+	// deleted elements make room without evicting others
+	checkErr(c.Delete("c"))
+	_, err = c.Set("d", 4)
+	checkErr(err)
+	if _, err = c.Get("a"); err != nil {
+		log.Panicf("element evicted while cache isn't full")
+	}
+
+	_, err = c.SetWithTTL("ttl", 4, 50*time.Millisecond)
+	checkErr(err)
+	time.Sleep(100 * time.Millisecond)
+	if _, err = c.Get("ttl"); err == nil {
+		log.Panicf("found expired element")
+	}
+}
+
+func TestCacheStop(t *testing.T) {
+	c := MakeCache(0, 0)
+	c.Stop()
+
+	// requests to a stopped cache must not block
+	if _, err := c.Get("a"); err == nil {
+		log.Panicf("expected error from stopped cache")
+	}
+}
@@ -208,6 +208,7 @@ func (api *API) GetHandler() http.Handler {
 	r.HandleFunc("/v2/functions/{function}", api.FunctionApiGet).Methods("GET")
 	r.HandleFunc("/v2/functions/{function}", api.FunctionApiUpdate).Methods("PUT")
 	r.HandleFunc("/v2/functions/{function}", api.FunctionApiDelete).Methods("DELETE")
+	r.HandleFunc("/v2/functions/{function}/jobs", api.FunctionJobsApiList).Methods("GET")
 
 	r.HandleFunc("/v2/triggers/http", api.HTTPTriggerApiList).Methods("GET")
 	r.HandleFunc("/v2/triggers/http", api.HTTPTriggerApiCreate).Methods("POST")
@@ -248,6 +249,12 @@ func (api *API) GetHandler() http.Handler {
 	r.HandleFunc("/v2/canaryconfigs/{canaryConfig}", api.CanaryConfigApiDelete).Methods("DELETE")
 	r.HandleFunc("/v2/canaryconfigs", api.CanaryConfigApiList).Methods("GET")
 
+	r.HandleFunc("/v2/functionaliases", api.FunctionAliasApiCreate).Methods("POST")
+	r.HandleFunc("/v2/functionaliases/{functionAlias}", api.FunctionAliasApiGet).Methods("GET")
+	r.HandleFunc("/v2/functionaliases/{functionAlias}", api.FunctionAliasApiUpdate).Methods("PUT")
+	r.HandleFunc("/v2/functionaliases/{functionAlias}", api.FunctionAliasApiDelete).Methods("DELETE")
+	r.HandleFunc("/v2/functionaliases", api.FunctionAliasApiList).Methods("GET")
+
 	r.HandleFunc("/proxy/{dbType}", api.FunctionLogsApiPost).Methods("POST")
 	r.HandleFunc("/proxy/storage/v1/archive", api.StorageServiceProxy)
 	r.HandleFunc("/proxy/logs/{function}", api.FunctionPodLogs).Methods("POST")
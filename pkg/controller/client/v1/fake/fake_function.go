@@ -17,6 +17,7 @@ limitations under the License.
 package fake
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
@@ -54,3 +55,7 @@ func (c *FakeFunction) Delete(m *metav1.ObjectMeta) error {
 func (c *FakeFunction) List(functionNamespace string) ([]fv1.Function, error) {
 	return nil, nil
 }
+
+func (c *FakeFunction) ListJobs(m *metav1.ObjectMeta) ([]batchv1.Job, error) {
+	return nil, nil
+}
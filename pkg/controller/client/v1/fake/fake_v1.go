@@ -45,6 +45,10 @@ func (c *FakeV1) Function() v1.FunctionInterface {
 	return newFunctionClient(nil)
 }
 
+func (c *FakeV1) FunctionAlias() v1.FunctionAliasInterface {
+	return newFunctionAliasClient(nil)
+}
+
 func (c *FakeV1) HTTPTrigger() v1.HTTPTriggerInterface {
 	return newHTTPTriggerClient(nil)
 }
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
@@ -38,6 +39,7 @@ type (
 		Update(f *fv1.Function) (*metav1.ObjectMeta, error)
 		Delete(m *metav1.ObjectMeta) error
 		List(functionNamespace string) ([]fv1.Function, error)
+		ListJobs(m *metav1.ObjectMeta) ([]batchv1.Job, error)
 	}
 
 	Function struct {
@@ -176,3 +178,28 @@ func (c *Function) List(functionNamespace string) ([]fv1.Function, error) {
 
 	return funcs, nil
 }
+
+// ListJobs returns the job history of a function run by the job executor, the latest first.
+func (c *Function) ListJobs(m *metav1.ObjectMeta) ([]batchv1.Job, error) {
+	relativeUrl := fmt.Sprintf("functions/%v/jobs", m.Name)
+	relativeUrl += fmt.Sprintf("?namespace=%v", m.Namespace)
+
+	resp, err := c.client.Get(relativeUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]batchv1.Job, 0)
+	err = json.Unmarshal(body, &jobs)
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/fission/fission/pkg/controller/client/rest"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+type (
+	FunctionAliasGetter interface {
+		FunctionAlias() FunctionAliasInterface
+	}
+
+	FunctionAliasInterface interface {
+		Create(alias *fv1.FunctionAlias) (*metav1.ObjectMeta, error)
+		Get(m *metav1.ObjectMeta) (*fv1.FunctionAlias, error)
+		Update(alias *fv1.FunctionAlias) (*metav1.ObjectMeta, error)
+		Delete(m *metav1.ObjectMeta) error
+		List(ns string) ([]fv1.FunctionAlias, error)
+	}
+
+	FunctionAlias struct {
+		client rest.Interface
+	}
+)
+
+func newFunctionAliasClient(c *V1) FunctionAliasInterface {
+	return &FunctionAlias{client: c.restClient}
+}
+
+func (c *FunctionAlias) Create(alias *fv1.FunctionAlias) (*metav1.ObjectMeta, error) {
+	reqbody, err := json.Marshal(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Create("functionaliases", "application/json", reqbody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := handleCreateResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var m metav1.ObjectMeta
+	err = json.Unmarshal(body, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (c *FunctionAlias) Get(m *metav1.ObjectMeta) (*fv1.FunctionAlias, error) {
+	relativeUrl := fmt.Sprintf("functionaliases/%v", m.Name)
+	relativeUrl += fmt.Sprintf("?namespace=%v", m.Namespace)
+
+	resp, err := c.client.Get(relativeUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var alias fv1.FunctionAlias
+	err = json.Unmarshal(body, &alias)
+	if err != nil {
+		return nil, err
+	}
+
+	return &alias, nil
+}
+
+func (c *FunctionAlias) Update(alias *fv1.FunctionAlias) (*metav1.ObjectMeta, error) {
+	reqbody, err := json.Marshal(alias)
+	if err != nil {
+		return nil, err
+	}
+	relativeUrl := fmt.Sprintf("functionaliases/%v", alias.ObjectMeta.Name)
+
+	resp, err := c.client.Put(relativeUrl, "application/json", reqbody)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var m metav1.ObjectMeta
+	err = json.Unmarshal(body, &m)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (c *FunctionAlias) Delete(m *metav1.ObjectMeta) error {
+	relativeUrl := fmt.Sprintf("functionaliases/%v", m.Name)
+	relativeUrl += fmt.Sprintf("?namespace=%v", m.Namespace)
+	return c.client.Delete(relativeUrl)
+}
+
+func (c *FunctionAlias) List(ns string) ([]fv1.FunctionAlias, error) {
+	relativeUrl := fmt.Sprintf("functionaliases?namespace=%v", ns)
+	resp, err := c.client.Get(relativeUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := handleResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := make([]fv1.FunctionAlias, 0)
+	err = json.Unmarshal(body, &aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
@@ -31,6 +31,7 @@ type (
 		CanaryConfigGetter
 		EnvironmentGetter
 		FunctionGetter
+		FunctionAliasGetter
 		HTTPTriggerGetter
 		KubeWatcherGetter
 		MessageQueueTriggerGetter
@@ -63,6 +64,10 @@ func (c *V1) Function() FunctionInterface {
 	return newFunctionClient(c)
 }
 
+func (c *V1) FunctionAlias() FunctionAliasInterface {
+	return newFunctionAliasClient(c)
+}
+
 func (c *V1) HTTPTrigger() HTTPTriggerInterface {
 	return newHTTPTriggerClient(c)
 }
@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/emicklei/go-restful"
+	restfulspec "github.com/emicklei/go-restful-openapi"
+	"github.com/go-openapi/spec"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func RegisterFunctionAliasRoute(ws *restful.WebService) {
+	tags := []string{"FunctionAlias"}
+	specTag = append(specTag, spec.Tag{TagProps: spec.TagProps{Name: "FunctionAlias", Description: "FunctionAlias Operation"}})
+
+	ws.Route(
+		ws.GET("/v2/functionaliases").
+			Doc("List all function aliases").
+			Metadata(restfulspec.KeyOpenAPITags, tags).
+			To(func(req *restful.Request, resp *restful.Response) {
+				resp.ResponseWriter.WriteHeader(http.StatusOK)
+			}).
+			Param(ws.QueryParameter("namespace", "Namespace of functionAlias").DataType("string").DefaultValue(metav1.NamespaceAll).Required(false)).
+			Produces(restful.MIME_JSON).
+			Writes([]fv1.FunctionAlias{}).
+			Returns(http.StatusOK, "List of functionAliases", []fv1.FunctionAlias{}))
+
+	ws.Route(
+		ws.POST("/v2/functionaliases").
+			Doc("Create function alias").
+			Metadata(restfulspec.KeyOpenAPITags, tags).
+			To(func(req *restful.Request, resp *restful.Response) {
+				resp.ResponseWriter.WriteHeader(http.StatusOK)
+			}).
+			Produces(restful.MIME_JSON).
+			Reads(fv1.FunctionAlias{}).
+			Writes(metav1.ObjectMeta{}).
+			Returns(http.StatusCreated, "ObjectMeta of created functionAlias", metav1.ObjectMeta{}))
+
+	ws.Route(
+		ws.GET("/v2/functionaliases/{functionAlias}").
+			Doc("Get detail of function alias").
+			Metadata(restfulspec.KeyOpenAPITags, tags).
+			To(func(req *restful.Request, resp *restful.Response) {
+				resp.ResponseWriter.WriteHeader(http.StatusOK)
+			}).
+			Param(ws.PathParameter("functionAlias", "FunctionAlias name").DataType("string").DefaultValue("").Required(true)).
+			Param(ws.QueryParameter("namespace", "Namespace of functionAlias").DataType("string").DefaultValue(metav1.NamespaceAll).Required(false)).
+			Produces(restful.MIME_JSON).
+			Writes(fv1.FunctionAlias{}). // on the response
+			Returns(http.StatusOK, "A functionAlias", fv1.FunctionAlias{}))
+
+	ws.Route(
+		ws.PUT("/v2/functionaliases/{functionAlias}").
+			Doc("Update function alias").
+			Metadata(restfulspec.KeyOpenAPITags, tags).
+			To(func(req *restful.Request, resp *restful.Response) {
+				resp.ResponseWriter.WriteHeader(http.StatusOK)
+			}).
+			Param(ws.PathParameter("functionAlias", "FunctionAlias name").DataType("string").DefaultValue("").Required(true)).
+			Produces(restful.MIME_JSON).
+			Reads(fv1.FunctionAlias{}).
+			Writes(metav1.ObjectMeta{}). // on the response
+			Returns(http.StatusOK, "ObjectMeta of updated functionAlias", metav1.ObjectMeta{}))
+
+	ws.Route(
+		ws.DELETE("/v2/functionaliases/{functionAlias}").
+			Doc("Delete function alias").
+			Metadata(restfulspec.KeyOpenAPITags, tags).
+			To(func(req *restful.Request, resp *restful.Response) {
+				resp.ResponseWriter.WriteHeader(http.StatusOK)
+			}).
+			Param(ws.PathParameter("functionAlias", "FunctionAlias name").DataType("string").DefaultValue("").Required(true)).
+			Param(ws.QueryParameter("namespace", "Namespace of functionAlias").DataType("string").DefaultValue(metav1.NamespaceAll).Required(false)).
+			Produces(restful.MIME_JSON).
+			Returns(http.StatusOK, "Only HTTP status returned", nil))
+}
+
+func (a *API) FunctionAliasApiCreate(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	var alias fv1.FunctionAlias
+	err = json.Unmarshal(body, &alias)
+	if err != nil {
+		a.logger.Error("failed to unmarshal request body", zap.Error(err), zap.Binary("body", body))
+		a.respondWithError(w, err)
+		return
+	}
+
+	aliasNew, err := a.fissionClient.CoreV1().FunctionAliases(alias.ObjectMeta.Namespace).Create(&alias)
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	resp, err := json.Marshal(aliasNew.ObjectMeta)
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	a.respondWithSuccess(w, resp)
+}
+
+func (a *API) FunctionAliasApiGet(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["functionAlias"]
+
+	ns := a.extractQueryParamFromRequest(r, "namespace")
+	if len(ns) == 0 {
+		ns = metav1.NamespaceDefault
+	}
+
+	alias, err := a.fissionClient.CoreV1().FunctionAliases(ns).Get(name, metav1.GetOptions{})
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	resp, err := json.Marshal(alias)
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	a.respondWithSuccess(w, resp)
+}
+
+func (a *API) FunctionAliasApiList(w http.ResponseWriter, r *http.Request) {
+	ns := a.extractQueryParamFromRequest(r, "namespace")
+	if len(ns) == 0 {
+		ns = metav1.NamespaceDefault
+	}
+
+	aliases, err := a.fissionClient.CoreV1().FunctionAliases(ns).List(metav1.ListOptions{})
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	resp, err := json.Marshal(aliases.Items)
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	a.respondWithSuccess(w, resp)
+}
+
+func (a *API) FunctionAliasApiUpdate(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	var alias fv1.FunctionAlias
+	err = json.Unmarshal(body, &alias)
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	aliasNew, err := a.fissionClient.CoreV1().FunctionAliases(alias.ObjectMeta.Namespace).Update(&alias)
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	resp, err := json.Marshal(aliasNew.ObjectMeta)
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	a.respondWithSuccess(w, resp)
+}
+
+func (a *API) FunctionAliasApiDelete(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["functionAlias"]
+	ns := a.extractQueryParamFromRequest(r, "namespace")
+	if len(ns) == 0 {
+		ns = metav1.NamespaceDefault
+	}
+
+	err := a.fissionClient.CoreV1().FunctionAliases(ns).Delete(name, &metav1.DeleteOptions{})
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	a.respondWithSuccess(w, []byte(""))
+}
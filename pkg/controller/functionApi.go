@@ -34,6 +34,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -108,6 +109,19 @@ func RegisterFunctionRoute(ws *restful.WebService) {
 			Param(ws.QueryParameter("namespace", "Namespace of function").DataType("string").DefaultValue(metav1.NamespaceAll).Required(false)).
 			Produces(restful.MIME_JSON).
 			Returns(http.StatusOK, "Only HTTP status returned", nil))
+
+	ws.Route(
+		ws.GET("/v2/functions/{function}/jobs").
+			Doc("List the job history of a function run by the job executor").
+			Metadata(restfulspec.KeyOpenAPITags, tags).
+			To(func(req *restful.Request, resp *restful.Response) {
+				resp.ResponseWriter.WriteHeader(http.StatusOK)
+			}).
+			Param(ws.PathParameter("function", "Function name").DataType("string").DefaultValue("").Required(true)).
+			Param(ws.QueryParameter("namespace", "Namespace of function").DataType("string").DefaultValue(metav1.NamespaceAll).Required(false)).
+			Produces(restful.MIME_JSON).
+			Writes([]batchv1.Job{}). // on the response
+			Returns(http.StatusOK, "List of jobs of function, the latest first", []batchv1.Job{}))
 }
 
 func (a *API) FunctionApiList(w http.ResponseWriter, r *http.Request) {
@@ -190,6 +204,53 @@ func (a *API) FunctionApiGet(w http.ResponseWriter, r *http.Request) {
 	a.respondWithSuccess(w, resp)
 }
 
+// FunctionJobsApiList lists the jobs the job executor ran for the function,
+// the latest first. The exit code and failure reason of a finished job are
+// in its annotations.
+func (a *API) FunctionJobsApiList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["function"]
+	ns := a.extractQueryParamFromRequest(r, "namespace")
+	if len(ns) == 0 {
+		ns = metav1.NamespaceDefault
+	}
+
+	f, err := a.fissionClient.CoreV1().Functions(ns).Get(name, metav1.GetOptions{})
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	// the jobs of a function in the default namespace run in the function namespace
+	jobNs := ns
+	if ns == metav1.NamespaceDefault {
+		jobNs = a.functionNamespace
+	}
+
+	jobList, err := a.kubernetesClient.BatchV1().Jobs(jobNs).List(metav1.ListOptions{
+		LabelSelector: labels.Set(map[string]string{
+			fv1.EXECUTOR_TYPE: string(fv1.ExecutorTypeJob),
+			fv1.FUNCTION_UID:  string(f.ObjectMeta.UID),
+		}).AsSelector().String(),
+	})
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+
+	jobs := jobList.Items
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[j].ObjectMeta.CreationTimestamp.Before(&jobs[i].ObjectMeta.CreationTimestamp)
+	})
+
+	resp, err := json.Marshal(jobs)
+	if err != nil {
+		a.respondWithError(w, err)
+		return
+	}
+	a.respondWithSuccess(w, resp)
+}
+
 func (a *API) FunctionApiUpdate(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	name := vars["function"]
@@ -121,6 +121,9 @@ func (a *API) HTTPTriggerApiList(w http.ResponseWriter, r *http.Request) {
 }
 
 // checkHTTPTriggerDuplicates checks whether the tuple (Method, Host, URL) is duplicate or not.
+// Triggers with the same Host and a common method whose URLs may match the same
+// request path, like "/users/{id}" and "/users/admin", are considered duplicate too.
+// The URL of a gRPC trigger matches all the paths under it, on POST only.
 func (a *API) checkHTTPTriggerDuplicates(t *fv1.HTTPTrigger) error {
 	triggers, err := a.fissionClient.CoreV1().HTTPTriggers(metav1.NamespaceAll).List(metav1.ListOptions{})
 	if err != nil {
@@ -131,15 +134,33 @@ func (a *API) checkHTTPTriggerDuplicates(t *fv1.HTTPTrigger) error {
 			// Same resource. No need to check.
 			continue
 		}
-		if ht.Spec.RelativeURL == t.Spec.RelativeURL && ht.Spec.Method == t.Spec.Method && ht.Spec.Host == t.Spec.Host {
+		if ht.Spec.Host != t.Spec.Host || !hasCommonMethod(ht.Spec, t.Spec) {
+			continue
+		}
+		if ht.Spec.RelativeURL == t.Spec.RelativeURL {
 			return ferror.MakeError(ferror.ErrorNameExists,
 				fmt.Sprintf("HTTPTrigger with same Host, URL & method already exists (%v)",
 					ht.ObjectMeta.Name))
 		}
+		if fv1.RoutesOverlap(ht.Spec.RelativeURL, ht.Spec.IsGRPC(), t.Spec.RelativeURL, t.Spec.IsGRPC()) {
+			return ferror.MakeError(ferror.ErrorNameExists,
+				fmt.Sprintf("HTTPTrigger URL '%v' overlaps with the URL '%v' of HTTPTrigger %v with same Host & method",
+					t.Spec.RelativeURL, ht.Spec.RelativeURL, ht.ObjectMeta.Name))
+		}
 	}
 	return nil
 }
 
+// hasCommonMethod returns true if both triggers accept at least one same HTTP method.
+func hasCommonMethod(a, b fv1.HTTPTriggerSpec) bool {
+	for _, m := range a.GetMethods() {
+		if b.HasMethod(m) {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *API) HTTPTriggerApiCreate(w http.ResponseWriter, r *http.Request) {
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
@@ -51,6 +51,7 @@ func openAPIWebService() *restful.WebService {
 	RegisterWatchRoute(ws)
 	RegisterTimeTriggerRoute(ws)
 	RegisterCanaryConfigRoute(ws)
+	RegisterFunctionAliasRoute(ws)
 
 	// proxy
 	RegisterStorageServiceProxyRoute(ws)
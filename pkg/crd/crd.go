@@ -189,6 +189,22 @@ func EnsureFissionCRDs(logger *zap.Logger, clientset *apiextensionsclient.Client
 				},
 			},
 		},
+		// FunctionAlias: named pointer to functions, referenced by triggers
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "functionaliases.fission.io",
+			},
+			Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+				Group:   crdGroupName,
+				Version: crdVersion,
+				Scope:   apiextensionsv1beta1.NamespaceScoped,
+				Names: apiextensionsv1beta1.CustomResourceDefinitionNames{
+					Kind:     "FunctionAlias",
+					Plural:   "functionaliases",
+					Singular: "functionalias",
+				},
+			},
+		},
 	}
 	for _, crd := range crds {
 		err := ensureCRD(logger, clientset, &crd)
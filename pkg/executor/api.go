@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/hashicorp/go-multierror"
@@ -34,6 +35,8 @@ import (
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	ferror "github.com/fission/fission/pkg/error"
 	"github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/executor/executortype"
+	"github.com/fission/fission/pkg/executor/fscache"
 )
 
 func (executor *Executor) getServiceForFunctionApi(w http.ResponseWriter, r *http.Request) {
@@ -61,7 +64,9 @@ func (executor *Executor) getServiceForFunctionApi(w http.ResponseWriter, r *htt
 		return
 	}
 
-	serviceName, err := executor.getServiceForFunction(fn)
+	executor.prewarmer.observe(&fn.ObjectMeta, time.Now())
+
+	serviceName, times, err := executor.getServiceForFunction(fn)
 	if err != nil {
 		code, msg := ferror.GetHTTPError(err)
 		executor.logger.Error("error getting service for function",
@@ -72,7 +77,117 @@ func (executor *Executor) getServiceForFunctionApi(w http.ResponseWriter, r *htt
 		return
 	}
 
-	w.Write([]byte(serviceName))
+	writeFunctionService(w, serviceName, times)
+}
+
+// getServiceInstanceForFunctionApi returns another function service for a
+// function whose function services in use by router are saturated.
+func (executor *Executor) getServiceInstanceForFunctionApi(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusInternalServerError)
+		return
+	}
+
+	req := client.ServiceInstanceRequest{}
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		http.Error(w, "Failed to parse request", http.StatusBadRequest)
+		return
+	}
+
+	m := req.FnMetadata
+	fn, err := executor.fissionClient.CoreV1().Functions(m.Namespace).Get(m.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			http.Error(w, "Failed to find function", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get function", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	fsvc, err := executor.createServiceInstanceForFunction(fn, req.Exclude)
+	if err != nil {
+		code, msg := ferror.GetHTTPError(err)
+		executor.logger.Error("error getting service instance for function",
+			zap.Error(err),
+			zap.String("function", m.Name),
+			zap.String("fission_http_error", msg))
+		http.Error(w, msg, code)
+		return
+	}
+
+	writeFunctionService(w, fsvc.Address, fsvc.Specialization)
+}
+
+// runJobApi runs a job of function for an invocation and replies with the
+// output of job once it finishes, the result of job is in the headers. A
+// detached invocation is replied with 202 and the name of job at once.
+func (executor *Executor) runJobApi(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusInternalServerError)
+		return
+	}
+
+	req := client.JobRunRequest{}
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		http.Error(w, "Failed to parse request", http.StatusBadRequest)
+		return
+	}
+
+	m := req.FnMetadata
+	fn, err := executor.fissionClient.CoreV1().Functions(m.Namespace).Get(m.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			http.Error(w, "Failed to find function", http.StatusNotFound)
+		} else {
+			http.Error(w, "Failed to get function", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	result, err := executor.runJob(r.Context(), fn, &executortype.JobRequest{
+		Method: req.Method,
+		Body:   req.Body,
+		Detach: req.Detach,
+	})
+	if err != nil {
+		// the job manager reports a request it can't run as a fission error
+		code, _ := ferror.GetHTTPError(errors.Cause(err))
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	if req.Detach {
+		w.Header().Set(client.HEADER_FISSION_JOB_NAME, result.Name)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	client.SetJobResultHeaders(w.Header(), client.JobResult{
+		Name:      result.Name,
+		Succeeded: result.Succeeded,
+		ExitCode:  result.ExitCode,
+		Reason:    result.Reason,
+	})
+	w.Write(result.Output)
+}
+
+// writeFunctionService writes the address of function service, and tells
+// router whether the function was specialized for the request.
+func writeFunctionService(w http.ResponseWriter, address string, times *fscache.SpecializationTimes) {
+	if times != nil {
+		client.SetSpecializationHeaders(w.Header(), client.SpecializationTimings{
+			PodSelection: times.PodSelection,
+			Fetch:        times.Fetch,
+			Load:         times.Load,
+			Total:        times.Total,
+		})
+	}
+	w.Write([]byte(address))
 }
 
 // getServiceForFunction first checks if this function's service is cached, if yes, it validates the address.
@@ -84,7 +199,9 @@ func (executor *Executor) getServiceForFunctionApi(w http.ResponseWriter, r *htt
 // stale addresses are not returned to the router.
 // To make it optimal, plan is to add an eager cache invalidator function that watches for pod deletion events and
 // invalidates the cache entry if the pod address was cached.
-func (executor *Executor) getServiceForFunction(fn *fv1.Function) (string, error) {
+//
+// The specialization times are returned if a new function service was created for the request.
+func (executor *Executor) getServiceForFunction(fn *fv1.Function) (string, *fscache.SpecializationTimes, error) {
 	// Check function -> svc cache
 	executor.logger.Debug("checking for cached function service",
 		zap.String("function_name", fn.ObjectMeta.Name),
@@ -93,20 +210,27 @@ func (executor *Executor) getServiceForFunction(fn *fv1.Function) (string, error
 	t := fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType
 	et, exists := executor.executorTypes[t]
 	if !exists {
-		return "", errors.Errorf("Unknown executor type '%v'", t)
+		return "", nil, errors.Errorf("Unknown executor type '%v'", t)
 	}
 
 	fsvc, err := et.GetFuncSvcFromCache(fn)
 	if err == nil {
 		if et.IsValid(fsvc) {
 			// Cached, return svc address
-			return fsvc.Address, nil
+			return fsvc.Address, nil, nil
 		} else {
 			executor.logger.Debug("deleting cache entry for invalid address",
 				zap.String("function_name", fn.ObjectMeta.Name),
 				zap.String("function_namespace", fn.ObjectMeta.Namespace),
 				zap.String("address", fsvc.Address))
 			et.DeleteFuncSvcFromCache(fsvc)
+
+			// another function service of a function served by
+			// more than one may have taken over the deleted one.
+			fsvc, err = et.GetFuncSvcFromCache(fn)
+			if err == nil && et.IsValid(fsvc) {
+				return fsvc.Address, nil, nil
+			}
 		}
 	}
 
@@ -117,9 +241,11 @@ func (executor *Executor) getServiceForFunction(fn *fv1.Function) (string, error
 	}
 	resp := <-respChan
 	if resp.err != nil {
-		return "", resp.err
+		return "", nil, resp.err
 	}
-	return resp.funcSvc.Address, resp.err
+	// the times are nil if no pod was specialized or started for the request,
+	// e.g. the service was created by a concurrent request
+	return resp.funcSvc.Address, resp.funcSvc.Specialization, resp.err
 }
 
 // find funcSvc and update its atime
@@ -149,7 +275,11 @@ func (executor *Executor) tapServices(w http.ResponseWriter, r *http.Request) {
 	}
 
 	errs := &multierror.Error{}
+	resp := client.TapServicesResponse{}
+	now := time.Now()
 	for _, req := range tapSvcReqs {
+		executor.prewarmer.observe(&req.FnMetadata, now)
+
 		svcHost := strings.TrimPrefix(req.ServiceUrl, "http://")
 
 		et, exists := executor.executorTypes[req.FnExecutorType]
@@ -161,6 +291,11 @@ func (executor *Executor) tapServices(w http.ResponseWriter, r *http.Request) {
 		}
 
 		err = et.TapService(svcHost)
+		if fscache.IsNotFoundError(errors.Cause(err)) {
+			// tell router to stop sending requests to the released pod
+			resp.GoneServiceUrls = append(resp.GoneServiceUrls, req.ServiceUrl)
+			continue
+		}
 		if err != nil {
 			errs = multierror.Append(errs,
 				errors.Wrapf(err, "'%v' failed to tap function '%v' in '%v' with service url '%v'",
@@ -174,7 +309,8 @@ func (executor *Executor) tapServices(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
 }
 
 func (executor *Executor) healthHandler(w http.ResponseWriter, r *http.Request) {
@@ -184,6 +320,8 @@ func (executor *Executor) healthHandler(w http.ResponseWriter, r *http.Request)
 func (executor *Executor) GetHandler() http.Handler {
 	r := mux.NewRouter()
 	r.HandleFunc("/v2/getServiceForFunction", executor.getServiceForFunctionApi).Methods("POST")
+	r.HandleFunc("/v2/getServiceInstanceForFunction", executor.getServiceInstanceForFunctionApi).Methods("POST")
+	r.HandleFunc("/v2/runJob", executor.runJobApi).Methods("POST")
 	r.HandleFunc("/v2/tapService", executor.tapService).Methods("POST") // for backward compatibility
 	r.HandleFunc("/v2/tapServices", executor.tapServices).Methods("POST")
 	r.HandleFunc("/healthz", executor.healthHandler).Methods("GET")
@@ -20,9 +20,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,12 +46,96 @@ type (
 		tappedByUrl map[string]TapServiceRequest
 		requestChan chan TapServiceRequest
 		httpClient  *http.Client
+		// serviceGone is called with the tapped services executor doesn't know anymore
+		serviceGone func(TapServiceRequest)
 	}
 	TapServiceRequest struct {
 		FnMetadata     metav1.ObjectMeta
 		FnExecutorType fv1.ExecutorType
 		ServiceUrl     string
 	}
+
+	// TapServicesResponse is the reply of executor to tapped services.
+	TapServicesResponse struct {
+		// GoneServiceUrls are the tapped services executor doesn't know
+		// anymore, e.g. their pods were released as idle.
+		GoneServiceUrls []string `json:"goneServiceUrls,omitempty"`
+	}
+
+	// ServiceInstanceRequest asks executor for a function service of a function
+	// other than the ones at the excluded addresses, which are saturated.
+	ServiceInstanceRequest struct {
+		FnMetadata metav1.ObjectMeta
+		Exclude    []string
+	}
+
+	// FunctionService is the service of a function returned by executor.
+	FunctionService struct {
+		Address string
+		// ColdStart is true if executor specialized a new function
+		// service for the request, instead of returning a cached one.
+		ColdStart bool
+		// Timings of the specialization, the steps not taken by
+		// the executor type of function are left zero.
+		Timings SpecializationTimings
+	}
+
+	SpecializationTimings struct {
+		PodSelection time.Duration
+		Fetch        time.Duration
+		Load         time.Duration
+		Total        time.Duration
+	}
+
+	// JobRunRequest asks executor to run a job of function for an invocation.
+	JobRunRequest struct {
+		FnMetadata metav1.ObjectMeta
+		Method     string
+		Body       []byte
+		// Detach asks executor to reply once the job is started.
+		Detach bool
+	}
+
+	// JobResult is the result of a finished job of function.
+	JobResult struct {
+		Name      string
+		Succeeded bool
+		// ExitCode is nil if the function container
+		// never ran, or was gone once the job finished.
+		ExitCode *int32
+		// Reason is the reason of job failure, e.g. DeadlineExceeded.
+		Reason string
+		Output []byte
+	}
+)
+
+const (
+	// HEADER_FISSION_COLD_START is set by executor to the response of
+	// getServiceForFunction if the function service was newly specialized,
+	// with the timings of specialization in the Server-Timing header.
+	HEADER_FISSION_COLD_START = "X-Fission-Cold-Start"
+
+	serverTimingHeader = "Server-Timing"
+
+	// TapBatchInterval is how often the tapped services are sent to
+	// executor in batch, a tap reaches executor up to this late.
+	TapBatchInterval = 5 * time.Second
+
+	// headers of the result of a job returned by runJob, the
+	// output of job is the response body.
+	HEADER_FISSION_JOB_NAME      = "X-Fission-Job-Name"
+	HEADER_FISSION_JOB_STATUS    = "X-Fission-Job-Status"
+	HEADER_FISSION_JOB_EXIT_CODE = "X-Fission-Job-Exit-Code"
+	HEADER_FISSION_JOB_REASON    = "X-Fission-Job-Reason"
+
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+
+	// metric names of specialization steps in Server-Timing
+	TimingPodSelection = "podselection"
+	TimingFetch        = "fetch"
+	TimingLoad         = "load"
+	TimingSpecialize   = "specialize"
 )
 
 func MakeClient(logger *zap.Logger, executorUrl string) *Client {
@@ -65,34 +152,198 @@ func MakeClient(logger *zap.Logger, executorUrl string) *Client {
 	return c
 }
 
-func (c *Client) GetServiceForFunction(ctx context.Context, metadata *metav1.ObjectMeta) (string, error) {
+// GetServiceForFunction returns the service of function, and whether
+// executor specialized a new one for the request.
+func (c *Client) GetServiceForFunction(ctx context.Context, metadata *metav1.ObjectMeta) (*FunctionService, error) {
 	executorUrl := c.executorUrl + "/v2/getServiceForFunction"
 
 	body, err := json.Marshal(metadata)
 	if err != nil {
-		return "", errors.Wrap(err, "could not marshal request body for getting service for function")
+		return nil, errors.Wrap(err, "could not marshal request body for getting service for function")
 	}
 
+	return c.postFunctionServiceRequest(ctx, executorUrl, body)
+}
+
+// GetServiceInstanceForFunction returns another service of function whose
+// services at the excluded addresses are saturated, executor specializes a
+// new one if there isn't one.
+func (c *Client) GetServiceInstanceForFunction(ctx context.Context, metadata *metav1.ObjectMeta, exclude []string) (*FunctionService, error) {
+	executorUrl := c.executorUrl + "/v2/getServiceInstanceForFunction"
+
+	body, err := json.Marshal(ServiceInstanceRequest{
+		FnMetadata: metav1.ObjectMeta{
+			Name:            metadata.Name,
+			Namespace:       metadata.Namespace,
+			ResourceVersion: metadata.ResourceVersion,
+			UID:             metadata.UID,
+		},
+		Exclude: exclude,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal request body for getting service instance for function")
+	}
+
+	return c.postFunctionServiceRequest(ctx, executorUrl, body)
+}
+
+func (c *Client) postFunctionServiceRequest(ctx context.Context, executorUrl string, body []byte) (*FunctionService, error) {
 	resp, err := ctxhttp.Post(ctx, c.httpClient, executorUrl, "application/json", bytes.NewReader(body))
 	if err != nil {
-		return "", errors.Wrap(err, "error posting to getting service for function")
+		return nil, errors.Wrap(err, "error posting to getting service for function")
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return "", ferror.MakeErrorFromHTTP(resp)
+		return nil, ferror.MakeErrorFromHTTP(resp)
 	}
 
 	svcName, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return "", errors.Wrap(err, "error reading response body from getting service for function")
+		return nil, errors.Wrap(err, "error reading response body from getting service for function")
+	}
+
+	svc := &FunctionService{
+		Address: string(svcName),
+	}
+	if len(resp.Header.Get(HEADER_FISSION_COLD_START)) > 0 {
+		svc.ColdStart = true
+		svc.Timings = parseSpecializationTimings(resp.Header)
+	}
+	return svc, nil
+}
+
+// RunJob runs a job of function with the request body and waits for it to
+// finish. The job keeps running to its deadline if ctx is done first. If
+// detach is set, it returns a result with only the name of the started job.
+func (c *Client) RunJob(ctx context.Context, metadata *metav1.ObjectMeta, method string, reqBody []byte, detach bool) (*JobResult, error) {
+	executorUrl := c.executorUrl + "/v2/runJob"
+
+	body, err := json.Marshal(JobRunRequest{
+		FnMetadata: metav1.ObjectMeta{
+			Name:            metadata.Name,
+			Namespace:       metadata.Namespace,
+			ResourceVersion: metadata.ResourceVersion,
+			UID:             metadata.UID,
+		},
+		Method: method,
+		Body:   reqBody,
+		Detach: detach,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal request body for running job")
 	}
 
-	return string(svcName), nil
+	resp, err := ctxhttp.Post(ctx, c.httpClient, executorUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "error posting to running job")
+	}
+	defer resp.Body.Close()
+
+	if detach && resp.StatusCode == http.StatusAccepted {
+		return &JobResult{Name: resp.Header.Get(HEADER_FISSION_JOB_NAME)}, nil
+	}
+	if resp.StatusCode != 200 {
+		return nil, ferror.MakeErrorFromHTTP(resp)
+	}
+
+	output, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading response body from running job")
+	}
+
+	result := &JobResult{
+		Name:      resp.Header.Get(HEADER_FISSION_JOB_NAME),
+		Succeeded: resp.Header.Get(HEADER_FISSION_JOB_STATUS) == JobStatusSucceeded,
+		Reason:    resp.Header.Get(HEADER_FISSION_JOB_REASON),
+		Output:    output,
+	}
+	if code, err := strconv.ParseInt(resp.Header.Get(HEADER_FISSION_JOB_EXIT_CODE), 10, 32); err == nil {
+		exitCode := int32(code)
+		result.ExitCode = &exitCode
+	}
+	return result, nil
+}
+
+// SetJobResultHeaders adds the name, status, exit code and
+// failure reason of a finished job to the response of runJob.
+func SetJobResultHeaders(h http.Header, result JobResult) {
+	h.Set(HEADER_FISSION_JOB_NAME, result.Name)
+	if result.Succeeded {
+		h.Set(HEADER_FISSION_JOB_STATUS, JobStatusSucceeded)
+	} else {
+		h.Set(HEADER_FISSION_JOB_STATUS, JobStatusFailed)
+	}
+	if result.ExitCode != nil {
+		h.Set(HEADER_FISSION_JOB_EXIT_CODE, strconv.Itoa(int(*result.ExitCode)))
+	}
+	if len(result.Reason) > 0 {
+		h.Set(HEADER_FISSION_JOB_REASON, result.Reason)
+	}
+}
+
+// SetSpecializationHeaders marks the response of getServiceForFunction
+// as a cold start and adds the timings of specialization.
+func SetSpecializationHeaders(h http.Header, timings SpecializationTimings) {
+	h.Set(HEADER_FISSION_COLD_START, "true")
+	h.Set(serverTimingHeader, FormatServerTiming(map[string]time.Duration{
+		TimingPodSelection: timings.PodSelection,
+		TimingFetch:        timings.Fetch,
+		TimingLoad:         timings.Load,
+		TimingSpecialize:   timings.Total,
+	}))
+}
+
+// FormatServerTiming formats the durations as the metrics of a
+// Server-Timing header, e.g. "fetch;dur=12.5, load;dur=3", the
+// zero durations are left out.
+func FormatServerTiming(timings map[string]time.Duration) string {
+	names := make([]string, 0, len(timings))
+	for name, d := range timings {
+		if d > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	metrics := make([]string, 0, len(names))
+	for _, name := range names {
+		ms := float64(timings[name]) / float64(time.Millisecond)
+		metrics = append(metrics, name+";dur="+strconv.FormatFloat(ms, 'f', -1, 64))
+	}
+	return strings.Join(metrics, ", ")
+}
+
+func parseSpecializationTimings(h http.Header) SpecializationTimings {
+	var timings SpecializationTimings
+	for _, metric := range strings.Split(h.Get(serverTimingHeader), ",") {
+		params := strings.Split(metric, ";")
+		var d time.Duration
+		for _, p := range params[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "dur=") {
+				ms, err := strconv.ParseFloat(strings.TrimPrefix(p, "dur="), 64)
+				if err == nil {
+					d = time.Duration(ms * float64(time.Millisecond))
+				}
+			}
+		}
+		switch strings.TrimSpace(params[0]) {
+		case TimingPodSelection:
+			timings.PodSelection = d
+		case TimingFetch:
+			timings.Fetch = d
+		case TimingLoad:
+			timings.Load = d
+		case TimingSpecialize:
+			timings.Total = d
+		}
+	}
+	return timings
 }
 
 func (c *Client) service() {
-	ticker := time.NewTicker(time.Second * 5)
+	ticker := time.NewTicker(TapBatchInterval)
 	for {
 		select {
 		case svcReq := <-c.requestChan:
@@ -112,9 +363,18 @@ func (c *Client) service() {
 				}
 				c.logger.Debug("tapped services in batch", zap.Int("service_count", len(urls)))
 
-				err := c._tapService(svcReqs)
+				gone, err := c._tapService(svcReqs)
 				if err != nil {
 					c.logger.Error("error tapping function service address", zap.Error(err))
+					return
+				}
+				if c.serviceGone == nil {
+					return
+				}
+				for _, u := range gone {
+					if req, ok := urls[u]; ok {
+						c.serviceGone(req)
+					}
 				}
 			}()
 		}
@@ -136,23 +396,37 @@ func (c *Client) TapService(fnMeta metav1.ObjectMeta, executorType fv1.ExecutorT
 	}
 }
 
-func (c *Client) _tapService(tapSvcReqs []TapServiceRequest) error {
+// OnServiceGone sets the function called with the tapped services executor
+// doesn't know anymore, so that the caller stops sending requests to them.
+// It must be set before services are tapped.
+func (c *Client) OnServiceGone(f func(TapServiceRequest)) {
+	c.serviceGone = f
+}
+
+// _tapService taps the services, and returns the urls of the ones executor doesn't know anymore.
+func (c *Client) _tapService(tapSvcReqs []TapServiceRequest) ([]string, error) {
 	executorUrl := c.executorUrl + "/v2/tapServices"
 
 	body, err := json.Marshal(tapSvcReqs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	resp, err := http.Post(executorUrl, "application/json", bytes.NewReader(body))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return ferror.MakeErrorFromHTTP(resp)
+		return nil, ferror.MakeErrorFromHTTP(resp)
 	}
 
-	return nil
+	// executors of older versions reply without body
+	tapResp := TapServicesResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&tapResp)
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "error decoding tap services response")
+	}
+	return tapResp.GoneServiceUrls, nil
 }
@@ -33,8 +33,10 @@ import (
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/crd"
+	ferror "github.com/fission/fission/pkg/error"
 	"github.com/fission/fission/pkg/executor/cms"
 	"github.com/fission/fission/pkg/executor/executortype"
+	"github.com/fission/fission/pkg/executor/executortype/job"
 	"github.com/fission/fission/pkg/executor/executortype/newdeploy"
 	"github.com/fission/fission/pkg/executor/executortype/poolmgr"
 	"github.com/fission/fission/pkg/executor/fscache"
@@ -54,6 +56,9 @@ type (
 
 		requestChan chan *createFuncServiceRequest
 		fsCreateWg  map[string]*sync.WaitGroup
+
+		// prewarmer is nil unless pre-warming is enabled
+		prewarmer *prewarmer
 	}
 	createFuncServiceRequest struct {
 		function *fv1.Function
@@ -111,26 +116,7 @@ func (executor *Executor) serveCreateFuncServices() {
 			// launch a goroutine for each request, to parallelize
 			// the specialization of different functions
 			go func() {
-				// Control overall specialization time by setting function
-				// specialization time to context. The reason not to use
-				// context from router requests is because a request maybe
-				// canceled for unknown reasons and let executor keeps
-				// spawning pods that never finish specialization process.
-				// Also, even a request failed, a specialized function pod
-				// still can serve other subsequent requests.
-
-				buffer := 10 // add some buffer time for specialization
-				specializationTimeout := req.function.Spec.InvokeStrategy.ExecutionStrategy.SpecializationTimeout
-
-				// set minimum specialization timeout to avoid illegal input and
-				// compatibility problem when applying old spec file that doesn't
-				// have specialization timeout field.
-				if specializationTimeout < fv1.DefaultSpecializationTimeOut {
-					specializationTimeout = fv1.DefaultSpecializationTimeOut
-				}
-
-				fnSpecializationTimeoutContext, cancel := context.WithTimeout(context.Background(),
-					time.Duration(specializationTimeout+buffer)*time.Second)
+				fnSpecializationTimeoutContext, cancel := specializationContext(req.function)
 				defer cancel()
 
 				fsvc, err := executor.createServiceForFunction(fnSpecializationTimeoutContext, req.function)
@@ -165,6 +151,31 @@ func (executor *Executor) serveCreateFuncServices() {
 	}
 }
 
+// specializationContext returns the context controlling the overall
+// specialization time of function.
+func specializationContext(fn *fv1.Function) (context.Context, context.CancelFunc) {
+	// Control overall specialization time by setting function
+	// specialization time to context. The reason not to use
+	// context from router requests is because a request maybe
+	// canceled for unknown reasons and let executor keeps
+	// spawning pods that never finish specialization process.
+	// Also, even a request failed, a specialized function pod
+	// still can serve other subsequent requests.
+
+	buffer := 10 // add some buffer time for specialization
+	specializationTimeout := fn.Spec.InvokeStrategy.ExecutionStrategy.SpecializationTimeout
+
+	// set minimum specialization timeout to avoid illegal input and
+	// compatibility problem when applying old spec file that doesn't
+	// have specialization timeout field.
+	if specializationTimeout < fv1.DefaultSpecializationTimeOut {
+		specializationTimeout = fv1.DefaultSpecializationTimeOut
+	}
+
+	return context.WithTimeout(context.Background(),
+		time.Duration(specializationTimeout+buffer)*time.Second)
+}
+
 func (executor *Executor) createServiceForFunction(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
 	executor.logger.Debug("no cached function service found, creating one",
 		zap.String("function_name", fn.ObjectMeta.Name),
@@ -176,7 +187,13 @@ func (executor *Executor) createServiceForFunction(ctx context.Context, fn *fv1.
 		return nil, errors.Errorf("Unknown executor type '%v'", t)
 	}
 
+	start := time.Now()
 	fsvc, fsvcErr := e.GetFuncSvc(ctx, fn)
+	// a function service served by the pods already running has no
+	// specialization times, and the request isn't a cold start
+	if fsvcErr == nil && fsvc != nil && fsvc.Specialization != nil {
+		fsvc.Specialization.Total = time.Since(start)
+	}
 	if fsvcErr != nil {
 		e := "error creating service for function"
 		executor.logger.Error(e,
@@ -189,6 +206,66 @@ func (executor *Executor) createServiceForFunction(ctx context.Context, fn *fv1.
 	return fsvc, fsvcErr
 }
 
+// createServiceInstanceForFunction returns another function service for a
+// function whose function services in use are saturated.
+func (executor *Executor) createServiceInstanceForFunction(fn *fv1.Function, exclude []string) (*fscache.FuncSvc, error) {
+	t := fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType
+	e, ok := executor.executorTypes[t]
+	if !ok {
+		return nil, errors.Errorf("Unknown executor type '%v'", t)
+	}
+	scaler, ok := e.(executortype.InstanceScaler)
+	if !ok || fn.Spec.InvokeStrategy.ExecutionStrategy.ConcurrencyPerInstance <= 0 {
+		return nil, ferror.MakeError(ferror.ErrorInvalidArgument,
+			fmt.Sprintf("function %v is not served by more than one function service", fn.ObjectMeta.Name))
+	}
+
+	ctx, cancel := specializationContext(fn)
+	defer cancel()
+
+	start := time.Now()
+	fsvc, err := scaler.GetFuncSvcInstance(ctx, fn, exclude)
+	if err != nil {
+		e := "error creating service instance for function"
+		executor.logger.Error(e,
+			zap.Error(err),
+			zap.String("function_name", fn.ObjectMeta.Name),
+			zap.String("function_namespace", fn.ObjectMeta.Namespace))
+		return nil, errors.Wrap(err, fmt.Sprintf("[%s] %s", fn.ObjectMeta.Name, e))
+	}
+	// an existing function service has no specialization times
+	if fsvc.Specialization != nil {
+		fsvc.Specialization.Total = time.Since(start)
+	}
+	return fsvc, nil
+}
+
+// runJob runs a job of function for the request, for the
+// functions whose every invocation runs to completion as a job.
+func (executor *Executor) runJob(ctx context.Context, fn *fv1.Function, req *executortype.JobRequest) (*executortype.JobResult, error) {
+	t := fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType
+	e, ok := executor.executorTypes[t]
+	if !ok {
+		return nil, errors.Errorf("Unknown executor type '%v'", t)
+	}
+	runner, ok := e.(executortype.JobRunner)
+	if !ok {
+		return nil, ferror.MakeError(ferror.ErrorInvalidArgument,
+			fmt.Sprintf("function %v is not run as a job", fn.ObjectMeta.Name))
+	}
+
+	result, err := runner.RunJob(ctx, fn, req)
+	if err != nil {
+		e := "error running job for function"
+		executor.logger.Error(e,
+			zap.Error(err),
+			zap.String("function_name", fn.ObjectMeta.Name),
+			zap.String("function_namespace", fn.ObjectMeta.Namespace))
+		return nil, errors.Wrap(err, fmt.Sprintf("[%s] %s", fn.ObjectMeta.Name, e))
+	}
+	return result, nil
+}
+
 func (executor *Executor) getFunctionServiceFromCache(fn *fv1.Function) (*fscache.FuncSvc, error) {
 	t := fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType
 	e, ok := executor.executorTypes[t]
@@ -198,6 +275,12 @@ func (executor *Executor) getFunctionServiceFromCache(fn *fv1.Function) (*fscach
 	return e.GetFuncSvcFromCache(fn)
 }
 
+// prewarm specializes a function service for function ahead of its expected traffic.
+func (executor *Executor) prewarm(fn *fv1.Function) error {
+	_, _, err := executor.getServiceForFunction(fn)
+	return err
+}
+
 func serveMetric(logger *zap.Logger) {
 	// Expose the registered metrics via HTTP.
 	metricAddr := ":8080"
@@ -239,9 +322,21 @@ func StartExecutor(logger *zap.Logger, functionNamespace string, envBuilderNames
 		fissionClient, kubernetesClient, fissionClient.CoreV1().RESTClient(),
 		functionNamespace, fetcherConfig, executorInstanceID)
 
+	cnm := newdeploy.MakeContainer(
+		logger,
+		fissionClient, kubernetesClient, fissionClient.CoreV1().RESTClient(),
+		functionNamespace, executorInstanceID)
+
+	jbm := job.MakeJobManager(
+		logger,
+		fissionClient, kubernetesClient, fissionClient.CoreV1().RESTClient(),
+		functionNamespace, executorInstanceID)
+
 	executorTypes := make(map[fv1.ExecutorType]executortype.ExecutorType)
 	executorTypes[gpm.GetTypeName()] = gpm
 	executorTypes[ndm.GetTypeName()] = ndm
+	executorTypes[cnm.GetTypeName()] = cnm
+	executorTypes[jbm.GetTypeName()] = jbm
 
 	adoptExistingResources, _ := strconv.ParseBool(os.Getenv("ADOPT_EXISTING_RESOURCES"))
 
@@ -267,6 +362,16 @@ func StartExecutor(logger *zap.Logger, functionNamespace string, envBuilderNames
 		return err
 	}
 
+	enablePrewarm, _ := strconv.ParseBool(os.Getenv("ENABLE_PREWARM"))
+	if enablePrewarm {
+		leadTime, err := time.ParseDuration(os.Getenv("PREWARM_LEAD_TIME"))
+		if err != nil || leadTime <= 0 {
+			leadTime = defaultPrewarmLeadTime
+		}
+		api.prewarmer = makePrewarmer(logger, fissionClient, leadTime, api.prewarm)
+		go api.prewarmer.Run(context.Background())
+	}
+
 	go reaper.CleanupRoleBindings(logger, kubernetesClient, fissionClient, functionNamespace, envBuilderNamespace, time.Minute*30)
 	go api.Serve(port)
 	go serveMetric(logger)
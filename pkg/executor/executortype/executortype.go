@@ -57,3 +57,48 @@ type ExecutorType interface {
 	// CleanupOldExecutorObjects cleans up resources created by old executor instances
 	CleanupOldExecutorObjects()
 }
+
+// InstanceScaler is implemented by the executor types serving a function with
+// more than one function service once the ones in use are saturated.
+type InstanceScaler interface {
+	// GetFuncSvcInstance returns a function service of function other than the
+	// ones at the excluded addresses, specializing another pod if there isn't one.
+	GetFuncSvcInstance(ctx context.Context, fn *fv1.Function, exclude []string) (*fscache.FuncSvc, error)
+}
+
+// JobRunner is implemented by the executor types running every invocation
+// of function to completion as a Kubernetes job, instead of serving it with
+// a function service.
+type JobRunner interface {
+	// RunJob runs a job of function for the request and waits for it to
+	// finish, or returns only the name of job if the request is detached.
+	RunJob(ctx context.Context, fn *fv1.Function, req *JobRequest) (*JobResult, error)
+}
+
+// JobRequest is an invocation of a function run as a job.
+type JobRequest struct {
+	Method string
+	Body   []byte
+
+	// Detach returns the name of job once it's started, instead of
+	// waiting for it to finish, for the callers not reading the result.
+	Detach bool
+}
+
+// JobResult is the result of a finished job.
+type JobResult struct {
+	// Name is the name of job, to look it up in the job history of function.
+	Name string
+
+	Succeeded bool
+
+	// ExitCode is the exit code of function container, nil if the container
+	// never ran or was removed, e.g. by the deadline of job.
+	ExitCode *int32
+
+	// Reason is the reason of job failure reported by Kubernetes, e.g. DeadlineExceeded.
+	Reason string
+
+	// Output is the output of function container.
+	Output []byte
+}
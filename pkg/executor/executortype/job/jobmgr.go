@@ -0,0 +1,701 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dchest/uniuri"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/crd"
+	ferror "github.com/fission/fission/pkg/error"
+	"github.com/fission/fission/pkg/executor/executortype"
+	"github.com/fission/fission/pkg/executor/fscache"
+	"github.com/fission/fission/pkg/executor/util"
+	"github.com/fission/fission/pkg/fetcher"
+	"github.com/fission/fission/pkg/utils"
+)
+
+var (
+	_ executortype.ExecutorType = &JobManager{}
+	_ executortype.JobRunner    = &JobManager{}
+)
+
+const (
+	// EnvRequestBody is the environment variable of job container with
+	// the path of the file holding the request body.
+	EnvRequestBody = "FISSION_REQUEST_BODY"
+
+	// EnvRequestMethod is the environment variable of job container
+	// with the HTTP method of the request.
+	EnvRequestMethod = "FISSION_REQUEST_METHOD"
+
+	requestVolumeName = "fission-request"
+	requestMountPath  = "/fission/request"
+	requestBodyKey    = "body"
+
+	// the fetcher binary is copied to the job directory, and runs the
+	// function command there to keep its stdout apart from its stderr.
+	jobVolumeName       = "fission-job"
+	jobMountPath        = "/fission/job"
+	fetcherBinary       = "/fetcher"
+	fetcherInitName     = "fetcher"
+	outputContainerName = "output"
+
+	// maxRequestBodyBytes is the largest request body a job takes, the
+	// body is kept in a configmap, which holds at most 1 MiB.
+	maxRequestBodyBytes = 1000 * 1000
+
+	// maxOutputBytes is the most output of job container returned as the response.
+	maxOutputBytes int64 = 10 * 1024 * 1024
+
+	// jobNameLabel is the label Kubernetes sets on the pods of a job.
+	jobNameLabel = "job-name"
+
+	defaultJobHistoryLimit = 10
+	detachedJobWaitBuffer  = time.Minute
+)
+
+type (
+	// JobManager is the job executor type. It runs every invocation of
+	// function as a Kubernetes job of the container image of function,
+	// and returns the stdout of container once the job finishes.
+	JobManager struct {
+		logger *zap.Logger
+
+		fissionClient    *crd.FissionClient
+		kubernetesClient kubernetes.Interface
+		crdClient        rest.Interface
+		namespace        string
+		instanceID       string
+
+		fetcherImage           string
+		fetcherImagePullPolicy apiv1.PullPolicy
+
+		// historyLimit is the number of finished jobs kept per function.
+		historyLimit int
+
+		funcController k8sCache.Controller
+	}
+)
+
+// MakeJobManager returns the job executor type.
+func MakeJobManager(
+	logger *zap.Logger,
+	fissionClient *crd.FissionClient,
+	kubernetesClient *kubernetes.Clientset,
+	crdClient rest.Interface,
+	namespace string,
+	instanceID string,
+) executortype.ExecutorType {
+	return makeJobManager(logger.Named("job"), fissionClient, kubernetesClient, crdClient, namespace, instanceID)
+}
+
+func makeJobManager(
+	logger *zap.Logger,
+	fissionClient *crd.FissionClient,
+	kubernetesClient kubernetes.Interface,
+	crdClient rest.Interface,
+	namespace string,
+	instanceID string,
+) *JobManager {
+	historyLimit := defaultJobHistoryLimit
+	if len(os.Getenv("JOB_HISTORY_LIMIT")) > 0 {
+		limit, err := strconv.Atoi(os.Getenv("JOB_HISTORY_LIMIT"))
+		if err != nil || limit < 0 {
+			logger.Error("failed to parse 'JOB_HISTORY_LIMIT', use default value",
+				zap.Error(err), zap.Int("default", defaultJobHistoryLimit))
+		} else {
+			historyLimit = limit
+		}
+	}
+
+	fetcherImage := os.Getenv("FETCHER_IMAGE")
+	if len(fetcherImage) == 0 {
+		fetcherImage = "fission/fetcher"
+	}
+
+	jm := &JobManager{
+		logger:                 logger,
+		fissionClient:          fissionClient,
+		kubernetesClient:       kubernetesClient,
+		crdClient:              crdClient,
+		namespace:              namespace,
+		instanceID:             instanceID,
+		fetcherImage:           fetcherImage,
+		fetcherImagePullPolicy: utils.GetImagePullPolicy(os.Getenv("FETCHER_IMAGE_PULL_POLICY")),
+		historyLimit:           historyLimit,
+	}
+
+	if jm.crdClient != nil {
+		jm.funcController = jm.initFuncController()
+	}
+
+	return jm
+}
+
+// Run watches functions to delete the jobs of the deleted ones, the
+// job history of a function is pruned after each of its jobs finishes.
+func (jm *JobManager) Run(ctx context.Context) {
+	go jm.funcController.Run(ctx.Done())
+}
+
+func (jm *JobManager) initFuncController() k8sCache.Controller {
+	resyncPeriod := 30 * time.Second
+	listWatch := k8sCache.NewListWatchFromClient(jm.crdClient, "functions", metav1.NamespaceAll, fields.Everything())
+	_, controller := k8sCache.NewInformer(listWatch, &fv1.Function{}, resyncPeriod, k8sCache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			fn, ok := obj.(*fv1.Function)
+			if !ok {
+				tombstone, ok := obj.(k8sCache.DeletedFinalStateUnknown)
+				if !ok {
+					return
+				}
+				fn, ok = tombstone.Obj.(*fv1.Function)
+				if !ok {
+					return
+				}
+			}
+			if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeJob {
+				return
+			}
+			go jm.deleteFunctionJobs(fn)
+		},
+	})
+	return controller
+}
+
+// deleteFunctionJobs deletes the jobs of a deleted function, and the
+// configmaps of request body along with them. The jobs of a function
+// in default namespace run in the function namespace, so they can't
+// be owned by the function.
+func (jm *JobManager) deleteFunctionJobs(fn *fv1.Function) {
+	ns := jm.getJobNamespace(fn)
+	selector := labels.Set(map[string]string{
+		fv1.EXECUTOR_TYPE: string(fv1.ExecutorTypeJob),
+		fv1.FUNCTION_UID:  string(fn.ObjectMeta.UID),
+	}).AsSelector().String()
+	logger := jm.logger.With(zap.String("function", fn.ObjectMeta.Name), zap.String("namespace", ns))
+
+	jobList, err := jm.kubernetesClient.BatchV1().Jobs(ns).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		logger.Error("error listing jobs of deleted function", zap.Error(err))
+	} else {
+		propagation := metav1.DeletePropagationBackground
+		for _, job := range jobList.Items {
+			err := jm.kubernetesClient.BatchV1().Jobs(ns).Delete(job.ObjectMeta.Name,
+				&metav1.DeleteOptions{PropagationPolicy: &propagation})
+			if err != nil && !k8serrors.IsNotFound(err) {
+				logger.Error("error deleting job of deleted function", zap.Error(err), zap.String("job", job.ObjectMeta.Name))
+			}
+		}
+	}
+
+	// the configmap of a job that failed to be created, or
+	// to become its owner, is left without a job to go with
+	cmList, err := jm.kubernetesClient.CoreV1().ConfigMaps(ns).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		logger.Error("error listing configmaps of deleted function", zap.Error(err))
+		return
+	}
+	for _, cm := range cmList.Items {
+		err := jm.kubernetesClient.CoreV1().ConfigMaps(ns).Delete(cm.ObjectMeta.Name, nil)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			logger.Error("error deleting configmap of deleted function", zap.Error(err), zap.String("configmap", cm.ObjectMeta.Name))
+		}
+	}
+}
+
+func (jm *JobManager) GetTypeName() fv1.ExecutorType {
+	return fv1.ExecutorTypeJob
+}
+
+// GetFuncSvc returns an error, the functions run as jobs have no function service.
+func (jm *JobManager) GetFuncSvc(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
+	return nil, ferror.MakeError(ferror.ErrorInvalidArgument,
+		fmt.Sprintf("function %v runs a job for every invocation, it has no function service", fn.ObjectMeta.Name))
+}
+
+func (jm *JobManager) GetFuncSvcFromCache(fn *fv1.Function) (*fscache.FuncSvc, error) {
+	return nil, ferror.MakeError(ferror.ErrorNotFound,
+		fmt.Sprintf("function %v runs a job for every invocation, it has no function service", fn.ObjectMeta.Name))
+}
+
+func (jm *JobManager) DeleteFuncSvcFromCache(fsvc *fscache.FuncSvc) {}
+
+func (jm *JobManager) TapService(serviceUrl string) error {
+	return nil
+}
+
+func (jm *JobManager) IsValid(fsvc *fscache.FuncSvc) bool {
+	return false
+}
+
+// RefreshFuncPods does nothing, the next job of function runs with the latest function.
+func (jm *JobManager) RefreshFuncPods(logger *zap.Logger, f fv1.Function) error {
+	return nil
+}
+
+func (jm *JobManager) AdoptExistingResources() {}
+
+// CleanupOldExecutorObjects does nothing, the jobs started by old executor
+// instances run to their deadline and are kept in the job history.
+func (jm *JobManager) CleanupOldExecutorObjects() {}
+
+// RunJob runs a job of function with the request body mounted into
+// the container, waits for it to finish and returns its stdout. The
+// job is left running if ctx is done first. A detached request returns
+// once the job is started, and its result is only kept in the history.
+func (jm *JobManager) RunJob(ctx context.Context, fn *fv1.Function, req *executortype.JobRequest) (*executortype.JobResult, error) {
+	if len(req.Body) > maxRequestBodyBytes {
+		return nil, ferror.MakeError(ferror.ErrorInvalidArgument,
+			fmt.Sprintf("request body of %v bytes is larger than the %v bytes a job takes", len(req.Body), maxRequestBodyBytes))
+	}
+
+	ns := jm.getJobNamespace(fn)
+	name := jm.getJobName(fn)
+	logger := jm.logger.With(
+		zap.String("function", fn.ObjectMeta.Name),
+		zap.String("namespace", ns),
+		zap.String("job", name))
+
+	cm, err := jm.kubernetesClient.CoreV1().ConfigMaps(ns).Create(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: jm.getJobLabels(fn.ObjectMeta),
+		},
+		BinaryData: map[string][]byte{
+			requestBodyKey: req.Body,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating configmap of request body")
+	}
+
+	job, err := jm.kubernetesClient.BatchV1().Jobs(ns).Create(jm.getJobSpec(fn, name, req))
+	if err != nil {
+		delErr := jm.kubernetesClient.CoreV1().ConfigMaps(ns).Delete(name, nil)
+		if delErr != nil {
+			logger.Error("error deleting configmap of request body", zap.Error(delErr))
+		}
+		return nil, errors.Wrap(err, "error creating job")
+	}
+
+	// the configmap is removed along with the job once it's pruned from history
+	cm.ObjectMeta.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(job, batchv1.SchemeGroupVersion.WithKind("Job")),
+	}
+	_, err = jm.kubernetesClient.CoreV1().ConfigMaps(ns).Update(cm)
+	if err != nil {
+		logger.Error("error setting job as the owner of configmap of request body", zap.Error(err))
+	}
+
+	logger.Debug("started job for function")
+
+	if req.Detach {
+		go func() {
+			// the job is killed at its deadline, wait a bit
+			// longer for Kubernetes to report it failed
+			deadline := time.Duration(*job.Spec.ActiveDeadlineSeconds)*time.Second + detachedJobWaitBuffer
+			ctx, cancel := context.WithTimeout(context.Background(), deadline)
+			defer cancel()
+			_, err := jm.finishJob(ctx, fn, job)
+			if err != nil {
+				logger.Error("error waiting for detached job to finish", zap.Error(err))
+			}
+		}()
+		return &executortype.JobResult{Name: job.ObjectMeta.Name}, nil
+	}
+
+	return jm.finishJob(ctx, fn, job)
+}
+
+// finishJob waits for job to finish, records its result and prunes the job history of function.
+func (jm *JobManager) finishJob(ctx context.Context, fn *fv1.Function, job *batchv1.Job) (*executortype.JobResult, error) {
+	job, err := jm.waitForJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	result := jm.getJobResult(fn, job)
+	jm.recordJobResult(job, result)
+	go jm.pruneJobHistory(fn, job.ObjectMeta.Namespace)
+
+	return result, nil
+}
+
+// waitForJob watches job until it finishes.
+func (jm *JobManager) waitForJob(ctx context.Context, job *batchv1.Job) (*batchv1.Job, error) {
+	jobs := jm.kubernetesClient.BatchV1().Jobs(job.ObjectMeta.Namespace)
+	for finishedCondition(job) == nil {
+		w, err := jobs.Watch(metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", job.ObjectMeta.Name).String(),
+			ResourceVersion: job.ObjectMeta.ResourceVersion,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "error watching job %v", job.ObjectMeta.Name)
+		}
+		j, err := watchJob(ctx, w)
+		w.Stop()
+		if err != nil {
+			return nil, errors.Wrapf(err, "error waiting for job %v to finish", job.ObjectMeta.Name)
+		}
+		if j == nil {
+			// the watch was closed, or its resource version expired,
+			// get the job again to watch on from its latest version
+			j, err = jobs.Get(job.ObjectMeta.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, errors.Wrapf(err, "error getting job %v", job.ObjectMeta.Name)
+			}
+		}
+		job = j
+	}
+	return job, nil
+}
+
+// watchJob returns the job once w reports it finished, or nil
+// if w ends first and the job has to be watched again.
+func watchJob(ctx context.Context, w watch.Interface) (*batchv1.Job, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				return nil, nil
+			}
+			switch ev.Type {
+			case watch.Added, watch.Modified:
+				job, ok := ev.Object.(*batchv1.Job)
+				if ok && finishedCondition(job) != nil {
+					return job, nil
+				}
+			case watch.Deleted:
+				return nil, errors.New("job was deleted")
+			case watch.Error:
+				return nil, nil
+			}
+		}
+	}
+}
+
+// finishedCondition returns the condition job finished with, or nil if it's still running.
+func finishedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for i, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == apiv1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// getJobResult returns the result of a finished job, with the exit code and
+// stdout of function if its pod is still there. Both are reported by the
+// output container, which runs once the function exits.
+func (jm *JobManager) getJobResult(fn *fv1.Function, job *batchv1.Job) *executortype.JobResult {
+	result := &executortype.JobResult{
+		Name: job.ObjectMeta.Name,
+	}
+	if c := finishedCondition(job); c != nil {
+		result.Succeeded = c.Type == batchv1.JobComplete
+		if !result.Succeeded {
+			result.Reason = c.Reason
+		}
+	}
+
+	podList, err := jm.kubernetesClient.CoreV1().Pods(job.ObjectMeta.Namespace).List(metav1.ListOptions{
+		LabelSelector: labels.Set(map[string]string{jobNameLabel: job.ObjectMeta.Name}).AsSelector().String(),
+	})
+	if err != nil {
+		jm.logger.Error("error listing pods of job", zap.Error(err), zap.String("job", job.ObjectMeta.Name))
+		return result
+	}
+	if len(podList.Items) == 0 {
+		return result
+	}
+
+	// the job runs a single pod, take the last one in case it was rescheduled
+	pods := podList.Items
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[j].ObjectMeta.CreationTimestamp.Before(&pods[i].ObjectMeta.CreationTimestamp)
+	})
+	pod := pods[0]
+
+	var output *apiv1.ContainerStateTerminated
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == outputContainerName && cs.State.Terminated != nil {
+			output = cs.State.Terminated
+		}
+	}
+	if output == nil {
+		// the function didn't run to its end, e.g. it was killed at the
+		// deadline of job, report the exit code its container died with
+		for _, cs := range pod.Status.InitContainerStatuses {
+			if cs.Name == fn.ObjectMeta.Name && cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
+				exitCode := cs.State.Terminated.ExitCode
+				result.ExitCode = &exitCode
+			}
+		}
+		return result
+	}
+	exitCode := output.ExitCode
+	result.ExitCode = &exitCode
+
+	limitBytes := maxOutputBytes
+	stdout, err := jm.kubernetesClient.CoreV1().Pods(pod.ObjectMeta.Namespace).GetLogs(pod.ObjectMeta.Name, &apiv1.PodLogOptions{
+		Container:  outputContainerName,
+		LimitBytes: &limitBytes,
+	}).DoRaw()
+	if err != nil {
+		jm.logger.Error("error getting output of job", zap.Error(err), zap.String("job", job.ObjectMeta.Name))
+		return result
+	}
+	result.Output = stdout
+
+	return result
+}
+
+// recordJobResult annotates job with its exit code and failure reason,
+// which are gone with its pod otherwise.
+func (jm *JobManager) recordJobResult(job *batchv1.Job, result *executortype.JobResult) {
+	annotations := make(map[string]string)
+	if result.ExitCode != nil {
+		annotations[fv1.ANNOTATION_JOB_EXIT_CODE] = strconv.Itoa(int(*result.ExitCode))
+	}
+	if len(result.Reason) > 0 {
+		annotations[fv1.ANNOTATION_JOB_REASON] = result.Reason
+	}
+	if len(annotations) == 0 {
+		return
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		jm.logger.Error("error marshaling job result", zap.Error(err), zap.String("job", job.ObjectMeta.Name))
+		return
+	}
+
+	_, err = jm.kubernetesClient.BatchV1().Jobs(job.ObjectMeta.Namespace).Patch(job.ObjectMeta.Name, k8sTypes.MergePatchType, patch)
+	if err != nil {
+		jm.logger.Error("error recording job result", zap.Error(err), zap.String("job", job.ObjectMeta.Name))
+	}
+}
+
+// pruneJobHistory deletes the finished jobs of function
+// other than the latest ones kept in history.
+func (jm *JobManager) pruneJobHistory(fn *fv1.Function, ns string) {
+	jobList, err := jm.kubernetesClient.BatchV1().Jobs(ns).List(metav1.ListOptions{
+		LabelSelector: labels.Set(map[string]string{
+			fv1.EXECUTOR_TYPE: string(fv1.ExecutorTypeJob),
+			fv1.FUNCTION_UID:  string(fn.ObjectMeta.UID),
+		}).AsSelector().String(),
+	})
+	if err != nil {
+		jm.logger.Error("error listing jobs of function", zap.Error(err), zap.String("function", fn.ObjectMeta.Name))
+		return
+	}
+
+	for _, job := range jobsToPrune(jobList.Items, jm.historyLimit) {
+		propagation := metav1.DeletePropagationBackground
+		err := jm.kubernetesClient.BatchV1().Jobs(job.ObjectMeta.Namespace).Delete(job.ObjectMeta.Name,
+			&metav1.DeleteOptions{PropagationPolicy: &propagation})
+		if err != nil {
+			jm.logger.Error("error pruning job from history", zap.Error(err), zap.String("job", job.ObjectMeta.Name))
+		}
+	}
+}
+
+// jobsToPrune returns the finished jobs other than the latest limit ones.
+func jobsToPrune(jobs []batchv1.Job, limit int) []batchv1.Job {
+	finished := make([]batchv1.Job, 0, len(jobs))
+	for _, job := range jobs {
+		if finishedCondition(&job) != nil {
+			finished = append(finished, job)
+		}
+	}
+	if len(finished) <= limit {
+		return nil
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[j].ObjectMeta.CreationTimestamp.Before(&finished[i].ObjectMeta.CreationTimestamp)
+	})
+	return finished[limit:]
+}
+
+// getJobSpec returns the job running the container of function once for the
+// request. The function runs as an init container with its command wrapped by
+// the fetcher binary, and the output container prints its stdout once it exits.
+func (jm *JobManager) getJobSpec(fn *fv1.Function, name string, req *executortype.JobRequest) *batchv1.Job {
+	fc := fn.Spec.Container
+
+	// the deadline of job is the timeout of function,
+	// and a failed job is not retried.
+	deadline := int64(fn.Spec.FunctionTimeout)
+	if deadline <= 0 {
+		deadline = int64(fv1.DEFAULT_FUNCTION_TIMEOUT)
+	}
+	backoffLimit := int32(0)
+
+	jobMount := apiv1.VolumeMount{
+		Name:      jobVolumeName,
+		MountPath: jobMountPath,
+	}
+	wrapper := path.Join(jobMountPath, path.Base(fetcherBinary))
+
+	container := apiv1.Container{
+		Name:    fn.ObjectMeta.Name,
+		Image:   fc.Image,
+		Command: append([]string{wrapper, fetcher.JobRunCommand, jobMountPath}, fc.Command...),
+		Args:    fc.Args,
+		Env: []apiv1.EnvVar{
+			{Name: EnvRequestBody, Value: path.Join(requestMountPath, requestBodyKey)},
+			{Name: EnvRequestMethod, Value: req.Method},
+		},
+		VolumeMounts: []apiv1.VolumeMount{
+			{
+				Name:      requestVolumeName,
+				MountPath: requestMountPath,
+				ReadOnly:  true,
+			},
+			jobMount,
+		},
+		Resources: fn.Spec.Resources,
+	}
+
+	podSpec := apiv1.PodSpec{
+		InitContainers: []apiv1.Container{
+			{
+				Name:            fetcherInitName,
+				Image:           jm.fetcherImage,
+				ImagePullPolicy: jm.fetcherImagePullPolicy,
+				Command:         []string{"cp", fetcherBinary, wrapper},
+				VolumeMounts:    []apiv1.VolumeMount{jobMount},
+			},
+			container,
+		},
+		Containers: []apiv1.Container{
+			{
+				Name:            outputContainerName,
+				Image:           jm.fetcherImage,
+				ImagePullPolicy: jm.fetcherImagePullPolicy,
+				Command:         []string{fetcherBinary, fetcher.JobOutputCommand, jobMountPath},
+				VolumeMounts:    []apiv1.VolumeMount{jobMount},
+			},
+		},
+		RestartPolicy: apiv1.RestartPolicyNever,
+		Volumes: []apiv1.Volume{
+			{
+				Name: requestVolumeName,
+				VolumeSource: apiv1.VolumeSource{
+					ConfigMap: &apiv1.ConfigMapVolumeSource{
+						LocalObjectReference: apiv1.LocalObjectReference{Name: name},
+					},
+				},
+			},
+			{
+				Name: jobVolumeName,
+				VolumeSource: apiv1.VolumeSource{
+					EmptyDir: &apiv1.EmptyDirVolumeSource{},
+				},
+			},
+		},
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      jm.getJobLabels(fn.ObjectMeta),
+			Annotations: jm.getJobAnnotations(fn.ObjectMeta),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &deadline,
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: jm.getJobLabels(fn.ObjectMeta),
+					// a sidecar keeps running after the function
+					// container exits, and the job never finishes.
+					Annotations: map[string]string{
+						"sidecar.istio.io/inject": "false",
+					},
+				},
+				Spec: *(util.ApplyImagePullSecret(fc.ImagePullSecret, podSpec)),
+			},
+		},
+	}
+}
+
+// getJobNamespace returns the namespace the jobs of function run in.
+func (jm *JobManager) getJobNamespace(fn *fv1.Function) string {
+	if fn.ObjectMeta.Namespace != metav1.NamespaceDefault {
+		return fn.ObjectMeta.Namespace
+	}
+	return jm.namespace
+}
+
+// getJobName returns a unique name for a job of function, short
+// enough for the job name label Kubernetes sets on its pods.
+func (jm *JobManager) getJobName(fn *fv1.Function) string {
+	name := fn.ObjectMeta.Name
+	if len(name) > 50 {
+		name = name[:50]
+	}
+	return strings.ToLower(fmt.Sprintf("%v-%v", name, uniuri.NewLen(8)))
+}
+
+func (jm *JobManager) getJobLabels(fnMeta metav1.ObjectMeta) map[string]string {
+	return map[string]string{
+		fv1.EXECUTOR_TYPE:      string(fv1.ExecutorTypeJob),
+		fv1.FUNCTION_NAME:      fnMeta.Name,
+		fv1.FUNCTION_NAMESPACE: fnMeta.Namespace,
+		fv1.FUNCTION_UID:       string(fnMeta.UID),
+	}
+}
+
+func (jm *JobManager) getJobAnnotations(fnMeta metav1.ObjectMeta) map[string]string {
+	return map[string]string{
+		fv1.EXECUTOR_INSTANCEID_LABEL: jm.instanceID,
+		fv1.FUNCTION_RESOURCE_VERSION: fnMeta.ResourceVersion,
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/executortype"
+)
+
+func TestJobSpec(t *testing.T) {
+	jm := makeJobManager(zap.NewNop(), nil, nil, nil, "fission-function", "test")
+
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly-report", Namespace: "default", UID: "0123456789abcdef"},
+		Spec: fv1.FunctionSpec{
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{ExecutorType: fv1.ExecutorTypeJob},
+			},
+			FunctionTimeout: 600,
+			Container: &fv1.FunctionContainer{
+				Image:           "example/report:1.0",
+				Command:         []string{"/report"},
+				ImagePullSecret: "registry",
+			},
+		},
+	}
+
+	name := jm.getJobName(fn)
+	if !strings.HasPrefix(name, "nightly-report-") || len(name) != len("nightly-report-")+8 {
+		t.Fatalf("unexpected job name %v", name)
+	}
+	if ns := jm.getJobNamespace(fn); ns != "fission-function" {
+		t.Fatalf("expected job of function in default namespace to run in fission-function, got %v", ns)
+	}
+
+	job := jm.getJobSpec(fn, name, &executortype.JobRequest{Method: "POST", Body: []byte("{}")})
+	if job.ObjectMeta.Labels[fv1.FUNCTION_UID] != string(fn.ObjectMeta.UID) {
+		t.Fatalf("expected job to be labeled with function, got %v", job.ObjectMeta.Labels)
+	}
+	if *job.Spec.ActiveDeadlineSeconds != 600 || *job.Spec.BackoffLimit != 0 {
+		t.Fatalf("expected job deadline 600s without retry, got deadline %v backoff limit %v",
+			*job.Spec.ActiveDeadlineSeconds, *job.Spec.BackoffLimit)
+	}
+
+	podSpec := job.Spec.Template.Spec
+	if podSpec.RestartPolicy != apiv1.RestartPolicyNever {
+		t.Fatalf("expected job pod never to restart, got %v", podSpec.RestartPolicy)
+	}
+	if len(podSpec.Volumes) != 2 || podSpec.Volumes[0].ConfigMap == nil || podSpec.Volumes[0].ConfigMap.Name != name {
+		t.Fatalf("expected request body configmap %v to be mounted, got %v", name, podSpec.Volumes)
+	}
+	if len(podSpec.ImagePullSecrets) != 1 || podSpec.ImagePullSecrets[0].Name != "registry" {
+		t.Fatalf("expected image pull secret registry, got %v", podSpec.ImagePullSecrets)
+	}
+
+	// the function runs after the fetcher binary is copied, and before its stdout is printed
+	if len(podSpec.InitContainers) != 2 || podSpec.InitContainers[0].Image != jm.fetcherImage ||
+		len(podSpec.Containers) != 1 || podSpec.Containers[0].Name != outputContainerName {
+		t.Fatalf("expected the function to run between the fetcher containers, got %v %v", podSpec.InitContainers, podSpec.Containers)
+	}
+	container := podSpec.InitContainers[1]
+	if container.Image != fn.Spec.Container.Image ||
+		strings.Join(container.Command, " ") != "/fission/job/fetcher jobrun /fission/job /report" {
+		t.Fatalf("expected command of container image %v to run with fetcher, got %v", fn.Spec.Container.Image, container)
+	}
+	env := make(map[string]string)
+	for _, e := range container.Env {
+		env[e.Name] = e.Value
+	}
+	if env[EnvRequestBody] != "/fission/request/body" || env[EnvRequestMethod] != "POST" {
+		t.Fatalf("expected request body path and method in container env, got %v", container.Env)
+	}
+
+	fn.Spec.FunctionTimeout = 0
+	job = jm.getJobSpec(fn, name, &executortype.JobRequest{Method: "GET"})
+	if *job.Spec.ActiveDeadlineSeconds != int64(fv1.DEFAULT_FUNCTION_TIMEOUT) {
+		t.Fatalf("expected default function timeout as job deadline, got %v", *job.Spec.ActiveDeadlineSeconds)
+	}
+}
+
+func TestJobsToPrune(t *testing.T) {
+	now := time.Now()
+	makeJob := func(name string, age time.Duration, condition batchv1.JobConditionType) batchv1.Job {
+		job := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+		}
+		if len(condition) > 0 {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: condition, Status: apiv1.ConditionTrue}}
+		}
+		return job
+	}
+
+	jobs := []batchv1.Job{
+		makeJob("oldest", 4*time.Hour, batchv1.JobComplete),
+		makeJob("running", 3*time.Hour, ""),
+		makeJob("failed", 2*time.Hour, batchv1.JobFailed),
+		makeJob("latest", time.Hour, batchv1.JobComplete),
+	}
+
+	pruned := jobsToPrune(jobs, 2)
+	if len(pruned) != 1 || pruned[0].ObjectMeta.Name != "oldest" {
+		t.Fatalf("expected only the oldest finished job to be pruned, got %v", pruned)
+	}
+
+	if pruned := jobsToPrune(jobs, 3); len(pruned) != 0 {
+		t.Fatalf("expected no job to be pruned within history limit, got %v", pruned)
+	}
+}
+
+func TestWaitForJob(t *testing.T) {
+	kubernetesClient := fake.NewSimpleClientset()
+	jm := makeJobManager(zap.NewNop(), nil, kubernetesClient, nil, "fission-function", "test")
+
+	job, err := kubernetesClient.BatchV1().Jobs("fission-function").Create(&batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly-report-abcdefgh", Namespace: "fission-function"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	type waitResult struct {
+		job *batchv1.Job
+		err error
+	}
+	done := make(chan waitResult, 1)
+	go func() {
+		j, err := jm.waitForJob(ctx, job)
+		done <- waitResult{j, err}
+	}()
+
+	// keep finishing the job until the watch of waitForJob has seen it
+	finished := job.DeepCopy()
+	finished.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: apiv1.ConditionTrue}}
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case r := <-done:
+			if r.err != nil {
+				t.Fatalf("error waiting for job: %v", r.err)
+			}
+			if finishedCondition(r.job) == nil {
+				t.Fatalf("expected finished job, got %v", r.job.Status)
+			}
+			return
+		case <-ticker.C:
+			_, err := kubernetesClient.BatchV1().Jobs("fission-function").UpdateStatus(finished)
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for job to finish")
+		}
+	}
+}
+
+func TestDeleteFunctionJobs(t *testing.T) {
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly-report", Namespace: "default", UID: "0123456789abcdef"},
+	}
+	other := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "hourly-report", Namespace: "default", UID: "fedcba9876543210"},
+	}
+
+	kubernetesClient := fake.NewSimpleClientset()
+	jm := makeJobManager(zap.NewNop(), nil, kubernetesClient, nil, "fission-function", "test")
+
+	for _, f := range []*fv1.Function{fn, other} {
+		name := jm.getJobName(f)
+		_, err := kubernetesClient.BatchV1().Jobs("fission-function").Create(&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: jm.getJobLabels(f.ObjectMeta)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = kubernetesClient.CoreV1().ConfigMaps("fission-function").Create(&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: jm.getJobLabels(f.ObjectMeta)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	jm.deleteFunctionJobs(fn)
+
+	jobs, err := kubernetesClient.BatchV1().Jobs("fission-function").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs.Items) != 1 || jobs.Items[0].ObjectMeta.Labels[fv1.FUNCTION_UID] != string(other.ObjectMeta.UID) {
+		t.Fatalf("expected only the job of the other function to be left, got %v", jobs.Items)
+	}
+	cms, err := kubernetesClient.CoreV1().ConfigMaps("fission-function").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cms.Items) != 1 || cms.Items[0].ObjectMeta.Labels[fv1.FUNCTION_UID] != string(other.ObjectMeta.UID) {
+		t.Fatalf("expected only the configmap of the other function to be left, got %v", cms.Items)
+	}
+}
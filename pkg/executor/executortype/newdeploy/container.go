@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package newdeploy
+
+import (
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/crd"
+	"github.com/fission/fission/pkg/executor/executortype"
+	"github.com/fission/fission/pkg/executor/util"
+)
+
+// containerPortName is the name of the port function container listens on.
+// The service of function targets the port by name, so that it follows the
+// port of container when function is updated.
+const containerPortName = "http-env"
+
+// MakeContainer returns the container executor type. It runs the container
+// image of function as-is, listening on the port of function, with no fetcher
+// and no specialization. The deployment, service and HPA of function and
+// scaling it down when idle are the same as newdeploy.
+func MakeContainer(
+	logger *zap.Logger,
+	fissionClient *crd.FissionClient,
+	kubernetesClient *kubernetes.Clientset,
+	crdClient rest.Interface,
+	namespace string,
+	instanceID string,
+) executortype.ExecutorType {
+	return makeDeployManager(logger.Named("container"), fv1.ExecutorTypeContainer,
+		fissionClient, kubernetesClient, crdClient, namespace, nil, instanceID)
+}
+
+// getContainerPodSpec returns the pod template running the container of function.
+func (deploy *NewDeploy) getContainerPodSpec(fn *fv1.Function, deployLabels map[string]string) *apiv1.PodTemplateSpec {
+	podLabels := make(map[string]string, len(deployLabels))
+	for k, v := range deployLabels {
+		podLabels[k] = v
+	}
+
+	fc := fn.Spec.Container
+	container := apiv1.Container{
+		Name:    fn.ObjectMeta.Name,
+		Image:   fc.Image,
+		Command: fc.Command,
+		Args:    fc.Args,
+		// https://istio.io/docs/setup/kubernetes/additional-setup/requirements/
+		Ports: []apiv1.ContainerPort{
+			{
+				Name:          containerPortName,
+				ContainerPort: fc.Port,
+			},
+		},
+		// the deployment is available once the container accepts connections,
+		// there is no specialize request to wait for.
+		ReadinessProbe: &apiv1.Probe{
+			Handler: apiv1.Handler{
+				TCPSocket: &apiv1.TCPSocketAction{
+					Port: intstr.FromString(containerPortName),
+				},
+			},
+			PeriodSeconds: 1,
+		},
+		Resources: fn.Spec.Resources,
+	}
+
+	pod := apiv1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: podLabels,
+		},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{container},
+		},
+	}
+	pod.Spec = *(util.ApplyImagePullSecret(fc.ImagePullSecret, pod.Spec))
+
+	return &pod
+}
+
+// serviceTargetPort returns the port the service of function targets.
+func (deploy *NewDeploy) serviceTargetPort() intstr.IntOrString {
+	if deploy.executorType == fv1.ExecutorTypeContainer {
+		return intstr.FromString(containerPortName)
+	}
+	return intstr.FromInt(8888)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package newdeploy
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestContainerDeploymentSpec(t *testing.T) {
+	deploy := makeDeployManager(zap.NewNop(), fv1.ExecutorTypeContainer, nil, nil, nil, "fission-function", nil, "test")
+
+	fn := &fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default", UID: "0123456789abcdef0123456789"},
+		Spec: fv1.FunctionSpec{
+			InvokeStrategy: fv1.InvokeStrategy{
+				ExecutionStrategy: fv1.ExecutionStrategy{ExecutorType: fv1.ExecutorTypeContainer, MinScale: 1, MaxScale: 3},
+			},
+			Container: &fv1.FunctionContainer{
+				Image:           "example/hello:1.0",
+				Port:            8080,
+				Args:            []string{"--verbose"},
+				ImagePullSecret: "registry",
+			},
+		},
+	}
+
+	name := deploy.getObjName(fn)
+	if name != "container-hello-default-9abcdef0123456789" {
+		t.Fatalf("unexpected object name %v", name)
+	}
+
+	env, err := deploy.getFunctionEnv(fn)
+	if err != nil {
+		t.Fatalf("error getting environment of function: %v", err)
+	}
+	labels := deploy.getDeployLabels(fn.ObjectMeta, env.ObjectMeta)
+	if labels[fv1.EXECUTOR_TYPE] != string(fv1.ExecutorTypeContainer) {
+		t.Fatalf("expected executor type label %v, got %v", fv1.ExecutorTypeContainer, labels[fv1.EXECUTOR_TYPE])
+	}
+
+	depl, err := deploy.getDeploymentSpec(fn, env, nil, name, "default", labels, deploy.getDeployAnnotations(fn.ObjectMeta))
+	if err != nil {
+		t.Fatalf("error getting deployment spec: %v", err)
+	}
+
+	podSpec := depl.Spec.Template.Spec
+	if len(podSpec.Containers) != 1 {
+		t.Fatalf("expected only the container of function without fetcher, got %v containers", len(podSpec.Containers))
+	}
+	container := podSpec.Containers[0]
+	if container.Image != fn.Spec.Container.Image || container.Args[0] != "--verbose" {
+		t.Fatalf("expected container image %v to run as-is, got %v", fn.Spec.Container.Image, container)
+	}
+	if len(container.Ports) != 1 || container.Ports[0].ContainerPort != 8080 || container.Ports[0].Name != containerPortName {
+		t.Fatalf("expected container to declare port 8080, got %v", container.Ports)
+	}
+	if container.ReadinessProbe == nil || container.ReadinessProbe.TCPSocket == nil {
+		t.Fatal("expected container to be ready once it accepts connections")
+	}
+	if len(podSpec.ImagePullSecrets) != 1 || podSpec.ImagePullSecrets[0].Name != "registry" {
+		t.Fatalf("expected image pull secret registry, got %v", podSpec.ImagePullSecrets)
+	}
+	if target := deploy.serviceTargetPort(); target.StrVal != containerPortName {
+		t.Fatalf("expected service to target port %v, got %v", containerPortName, target.String())
+	}
+}
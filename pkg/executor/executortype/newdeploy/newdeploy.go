@@ -25,7 +25,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
-	asv1 "k8s.io/api/autoscaling/v1"
+	asv2 "k8s.io/api/autoscaling/v2beta2"
 	apiv1 "k8s.io/api/core/v1"
 	k8s_err "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -43,8 +43,11 @@ const (
 	DeploymentVersion = "apps/v1"
 )
 
+// createOrGetDeployment returns the deployment of function with at least one
+// available pod. started is true if the deployment had to create or wait for
+// pods, i.e. the request is a cold start.
 func (deploy *NewDeploy) createOrGetDeployment(fn *fv1.Function, env *fv1.Environment,
-	deployName string, deployLabels map[string]string, deployAnnotations map[string]string, deployNamespace string) (*appsv1.Deployment, error) {
+	deployName string, deployLabels map[string]string, deployAnnotations map[string]string, deployNamespace string) (depl *appsv1.Deployment, started bool, err error) {
 
 	specializationTimeout := int(fn.Spec.InvokeStrategy.ExecutionStrategy.SpecializationTimeout)
 	minScale := int32(fn.Spec.InvokeStrategy.ExecutionStrategy.MinScale)
@@ -58,7 +61,7 @@ func (deploy *NewDeploy) createOrGetDeployment(fn *fv1.Function, env *fv1.Enviro
 
 	deployment, err := deploy.getDeploymentSpec(fn, env, &minScale, deployName, deployNamespace, deployLabels, deployAnnotations)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	existingDepl, err := deploy.kubernetesClient.AppsV1().Deployments(deployNamespace).Get(deployName, metav1.GetOptions{})
@@ -77,31 +80,35 @@ func (deploy *NewDeploy) createOrGetDeployment(fn *fv1.Function, env *fv1.Enviro
 			if err != nil {
 				deploy.logger.Warn("error adopting deploy", zap.Error(err),
 					zap.String("deploy", deployName), zap.String("ns", deployNamespace))
-				return nil, err
+				return nil, false, err
 			}
 			// In this case, we just return without waiting for it for fast bootstraping.
-			return existingDepl, nil
+			return existingDepl, false, nil
 		}
 
 		if *existingDepl.Spec.Replicas < minScale {
 			err = deploy.scaleDeployment(existingDepl.Namespace, existingDepl.Name, minScale)
 			if err != nil {
 				deploy.logger.Error("error scaling up function deployment", zap.Error(err), zap.String("function", fn.ObjectMeta.Name))
-				return nil, err
+				return nil, false, err
 			}
 		}
 		if existingDepl.Status.AvailableReplicas < minScale {
+			started = true
 			existingDepl, err = deploy.waitForDeploy(existingDepl, minScale, specializationTimeout)
 		}
 
-		return existingDepl, err
+		return existingDepl, started, err
 	} else if k8s_err.IsNotFound(err) {
-		err := deploy.setupRBACObjs(deployNamespace, fn)
-		if err != nil {
-			return nil, err
+		// the fetcher isn't run for the functions of container executor
+		if deploy.executorType != fv1.ExecutorTypeContainer {
+			err := deploy.setupRBACObjs(deployNamespace, fn)
+			if err != nil {
+				return nil, false, err
+			}
 		}
 
-		depl, err := deploy.kubernetesClient.AppsV1().Deployments(deployNamespace).Create(deployment)
+		depl, err = deploy.kubernetesClient.AppsV1().Deployments(deployNamespace).Create(deployment)
 		if err != nil {
 			if k8s_err.IsAlreadyExists(err) {
 				depl, err = deploy.kubernetesClient.AppsV1().Deployments(deployNamespace).Get(deployName, metav1.GetOptions{})
@@ -112,15 +119,15 @@ func (deploy *NewDeploy) createOrGetDeployment(fn *fv1.Function, env *fv1.Enviro
 					zap.String("function", fn.ObjectMeta.Name),
 					zap.String("deployment_name", deployName),
 					zap.String("deployment_namespace", deployNamespace))
-				return nil, err
+				return nil, false, err
 			}
 		}
 		if minScale > 0 {
 			depl, err = deploy.waitForDeploy(depl, minScale, specializationTimeout)
 		}
-		return depl, err
+		return depl, true, err
 	}
-	return nil, err
+	return nil, false, err
 }
 
 func (deploy *NewDeploy) setupRBACObjs(deployNamespace string, fn *fv1.Function) error {
@@ -186,6 +193,63 @@ func (deploy *NewDeploy) getDeploymentSpec(fn *fv1.Function, env *fv1.Environmen
 		replicas = *targetReplicas
 	}
 
+	var pod *apiv1.PodTemplateSpec
+	var err error
+	if deploy.executorType == fv1.ExecutorTypeContainer {
+		pod = deploy.getContainerPodSpec(fn, deployLabels)
+	} else {
+		pod, err = deploy.getEnvPodSpec(fn, env, deployLabels)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Set maxUnavailable and maxSurge to 20% is because we want
+	// fission to rollout newer function version gradually without
+	// affecting any online service. For example, if you set maxSurge
+	// to 100%, the new ReplicaSet scales up immediately and may
+	// consume all remaining compute resources which might be an
+	// issue if a cluster's resource is on a budget.
+	// TODO: add to ExecutionStrategy so that the user
+	// can do more fine control over different functions.
+	maxUnavailable := intstr.FromString("20%")
+	maxSurge := intstr.FromString("20%")
+
+	// Newdeploy updates the environment variable "LastUpdateTimestamp" of deployment
+	// whenever a configmap/secret gets an update, but it also leaves multiple ReplicaSets for
+	// rollback purpose. Since fission always update a deployment instead of performing a
+	// rollback, set RevisionHistoryLimit to 0 to disable this feature.
+	revisionHistoryLimit := int32(0)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        deployName,
+			Labels:      deployLabels,
+			Annotations: deployAnnotations,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: deployLabels,
+			},
+			Template: *pod,
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: &maxUnavailable,
+					MaxSurge:       &maxSurge,
+				},
+			},
+			RevisionHistoryLimit: &revisionHistoryLimit,
+		},
+	}
+
+	return deployment, nil
+}
+
+// getEnvPodSpec returns the pod template running function with the runtime
+// image of its environment, specialized by the fetcher.
+func (deploy *NewDeploy) getEnvPodSpec(fn *fv1.Function, env *fv1.Environment, deployLabels map[string]string) (*apiv1.PodTemplateSpec, error) {
 	gracePeriodSeconds := int64(6 * 60)
 	if env.Spec.TerminationGracePeriod > 0 {
 		gracePeriodSeconds = env.Spec.TerminationGracePeriod
@@ -214,23 +278,6 @@ func (deploy *NewDeploy) getDeploymentSpec(fn *fv1.Function, env *fv1.Environmen
 
 	resources := deploy.getResources(env, fn)
 
-	// Set maxUnavailable and maxSurge to 20% is because we want
-	// fission to rollout newer function version gradually without
-	// affecting any online service. For example, if you set maxSurge
-	// to 100%, the new ReplicaSet scales up immediately and may
-	// consume all remaining compute resources which might be an
-	// issue if a cluster's resource is on a budget.
-	// TODO: add to ExecutionStrategy so that the user
-	// can do more fine control over different functions.
-	maxUnavailable := intstr.FromString("20%")
-	maxSurge := intstr.FromString("20%")
-
-	// Newdeploy updates the environment variable "LastUpdateTimestamp" of deployment
-	// whenever a configmap/secret gets an update, but it also leaves multiple ReplicaSets for
-	// rollback purpose. Since fission always update a deployment instead of performing a
-	// rollback, set RevisionHistoryLimit to 0 to disable this feature.
-	revisionHistoryLimit := int32(0)
-
 	rvCount, err := referencedResourcesRVSum(deploy.kubernetesClient, fn.ObjectMeta.Namespace, fn.Spec.Secrets, fn.Spec.ConfigMaps)
 	if err != nil {
 		return nil, err
@@ -284,32 +331,9 @@ func (deploy *NewDeploy) getDeploymentSpec(fn *fv1.Function, env *fv1.Environmen
 
 	pod.Spec = *(util.ApplyImagePullSecret(env.Spec.ImagePullSecret, pod.Spec))
 
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        deployName,
-			Labels:      deployLabels,
-			Annotations: deployAnnotations,
-		},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: deployLabels,
-			},
-			Template: pod,
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: &maxUnavailable,
-					MaxSurge:       &maxSurge,
-				},
-			},
-			RevisionHistoryLimit: &revisionHistoryLimit,
-		},
-	}
-
 	// Order of merging is important here - first fetcher, then containers and lastly pod spec
 	err = deploy.fetcherConfig.AddSpecializingFetcherToPodSpec(
-		&deployment.Spec.Template.Spec,
+		&pod.Spec,
 		fn.ObjectMeta.Name,
 		fn,
 		env,
@@ -319,14 +343,14 @@ func (deploy *NewDeploy) getDeploymentSpec(fn *fv1.Function, env *fv1.Environmen
 	}
 
 	if env.Spec.Runtime.PodSpec != nil {
-		newPodSpec, err := util.MergePodSpec(&deployment.Spec.Template.Spec, env.Spec.Runtime.PodSpec)
+		newPodSpec, err := util.MergePodSpec(&pod.Spec, env.Spec.Runtime.PodSpec)
 		if err != nil {
 			return nil, err
 		}
-		deployment.Spec.Template.Spec = *newPodSpec
+		pod.Spec = *newPodSpec
 	}
 
-	return deployment, nil
+	return &pod, nil
 }
 
 // getResources overrides only the resources which are overridden at function level otherwise
@@ -363,13 +387,19 @@ func (deploy *NewDeploy) getResources(env *fv1.Environment, fn *fv1.Function) ap
 	return resources
 }
 
-func (deploy *NewDeploy) createOrGetHpa(hpaName string, execStrategy *fv1.ExecutionStrategy,
-	depl *appsv1.Deployment, deployLabels map[string]string, deployAnnotations map[string]string) (*asv1.HorizontalPodAutoscaler, error) {
+func (deploy *NewDeploy) createOrGetHpa(hpaName string, fn *fv1.Function,
+	depl *appsv1.Deployment, deployLabels map[string]string, deployAnnotations map[string]string) (*asv2.HorizontalPodAutoscaler, error) {
 
 	if depl == nil {
 		return nil, errors.New("failed to create HPA, found empty deployment")
 	}
 
+	execStrategy := &fn.Spec.InvokeStrategy.ExecutionStrategy
+	metrics, err := hpaMetrics(fn)
+	if err != nil {
+		return nil, err
+	}
+
 	minRepl := int32(execStrategy.MinScale)
 	if minRepl == 0 {
 		minRepl = 1
@@ -378,34 +408,33 @@ func (deploy *NewDeploy) createOrGetHpa(hpaName string, execStrategy *fv1.Execut
 	if maxRepl == 0 {
 		maxRepl = minRepl
 	}
-	targetCPU := int32(execStrategy.TargetCPUPercent)
 
-	hpa := &asv1.HorizontalPodAutoscaler{
+	hpa := &asv2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        hpaName,
 			Labels:      deployLabels,
 			Annotations: deployAnnotations,
 		},
-		Spec: asv1.HorizontalPodAutoscalerSpec{
-			ScaleTargetRef: asv1.CrossVersionObjectReference{
+		Spec: asv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: asv2.CrossVersionObjectReference{
 				Kind:       DeploymentKind,
 				Name:       depl.ObjectMeta.Name,
 				APIVersion: DeploymentVersion,
 			},
-			MinReplicas:                    &minRepl,
-			MaxReplicas:                    maxRepl,
-			TargetCPUUtilizationPercentage: &targetCPU,
+			MinReplicas: &minRepl,
+			MaxReplicas: maxRepl,
+			Metrics:     metrics,
 		},
 	}
 
-	existingHpa, err := deploy.kubernetesClient.AutoscalingV1().HorizontalPodAutoscalers(depl.ObjectMeta.Namespace).Get(hpaName, metav1.GetOptions{})
+	existingHpa, err := deploy.kubernetesClient.AutoscalingV2beta2().HorizontalPodAutoscalers(depl.ObjectMeta.Namespace).Get(hpaName, metav1.GetOptions{})
 	if err == nil {
 		// to adopt orphan service
 		if existingHpa.Annotations[fv1.EXECUTOR_INSTANCEID_LABEL] != deploy.instanceID {
 			existingHpa.Annotations = hpa.Annotations
 			existingHpa.Labels = hpa.Labels
 			existingHpa.Spec = hpa.Spec
-			existingHpa, err = deploy.kubernetesClient.AutoscalingV1().HorizontalPodAutoscalers(depl.ObjectMeta.Namespace).Update(existingHpa)
+			existingHpa, err = deploy.kubernetesClient.AutoscalingV2beta2().HorizontalPodAutoscalers(depl.ObjectMeta.Namespace).Update(existingHpa)
 			if err != nil {
 				deploy.logger.Warn("error adopting HPA", zap.Error(err),
 					zap.String("HPA", hpaName), zap.String("ns", depl.ObjectMeta.Namespace))
@@ -414,10 +443,10 @@ func (deploy *NewDeploy) createOrGetHpa(hpaName string, execStrategy *fv1.Execut
 		}
 		return existingHpa, err
 	} else if k8s_err.IsNotFound(err) {
-		cHpa, err := deploy.kubernetesClient.AutoscalingV1().HorizontalPodAutoscalers(depl.ObjectMeta.Namespace).Create(hpa)
+		cHpa, err := deploy.kubernetesClient.AutoscalingV2beta2().HorizontalPodAutoscalers(depl.ObjectMeta.Namespace).Create(hpa)
 		if err != nil {
 			if k8s_err.IsAlreadyExists(err) {
-				cHpa, err = deploy.kubernetesClient.AutoscalingV1().HorizontalPodAutoscalers(depl.ObjectMeta.Namespace).Get(hpaName, metav1.GetOptions{})
+				cHpa, err = deploy.kubernetesClient.AutoscalingV2beta2().HorizontalPodAutoscalers(depl.ObjectMeta.Namespace).Get(hpaName, metav1.GetOptions{})
 			}
 			if err != nil {
 				return nil, err
@@ -428,17 +457,92 @@ func (deploy *NewDeploy) createOrGetHpa(hpaName string, execStrategy *fv1.Execut
 	return nil, err
 }
 
-func (deploy *NewDeploy) getHpa(ns, name string) (*asv1.HorizontalPodAutoscaler, error) {
-	return deploy.kubernetesClient.AutoscalingV1().HorizontalPodAutoscalers(ns).Get(name, metav1.GetOptions{})
+func (deploy *NewDeploy) getHpa(ns, name string) (*asv2.HorizontalPodAutoscaler, error) {
+	return deploy.kubernetesClient.AutoscalingV2beta2().HorizontalPodAutoscalers(ns).Get(name, metav1.GetOptions{})
 }
 
-func (deploy *NewDeploy) updateHpa(hpa *asv1.HorizontalPodAutoscaler) error {
-	_, err := deploy.kubernetesClient.AutoscalingV1().HorizontalPodAutoscalers(hpa.ObjectMeta.Namespace).Update(hpa)
+func (deploy *NewDeploy) updateHpa(hpa *asv2.HorizontalPodAutoscaler) error {
+	_, err := deploy.kubernetesClient.AutoscalingV2beta2().HorizontalPodAutoscalers(hpa.ObjectMeta.Namespace).Update(hpa)
 	return err
 }
 
 func (deploy *NewDeploy) deleteHpa(ns string, name string) error {
-	return deploy.kubernetesClient.AutoscalingV1().HorizontalPodAutoscalers(ns).Delete(name, &metav1.DeleteOptions{})
+	return deploy.kubernetesClient.AutoscalingV2beta2().HorizontalPodAutoscalers(ns).Delete(name, &metav1.DeleteOptions{})
+}
+
+// hpaMetrics returns the metrics HPA scales function on: the CPU utilization,
+// and the in-flight requests, requests per second and custom metric targets
+// if they are set. HPA scales on whichever needs the most pods, so that I/O
+// bound functions scale out with requests even though their CPU stays low.
+func hpaMetrics(fn *fv1.Function) ([]asv2.MetricSpec, error) {
+	execStrategy := fn.Spec.InvokeStrategy.ExecutionStrategy
+
+	targetCPU := int32(execStrategy.TargetCPUPercent)
+	metrics := []asv2.MetricSpec{
+		{
+			Type: asv2.ResourceMetricSourceType,
+			Resource: &asv2.ResourceMetricSource{
+				Name: apiv1.ResourceCPU,
+				Target: asv2.MetricTarget{
+					Type:               asv2.UtilizationMetricType,
+					AverageUtilization: &targetCPU,
+				},
+			},
+		},
+	}
+
+	// router metrics are labeled with the function
+	fnSelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"namespace": fn.ObjectMeta.Namespace,
+			"name":      fn.ObjectMeta.Name,
+		},
+	}
+	routerMetric := func(name string, target int) asv2.MetricSpec {
+		return asv2.MetricSpec{
+			Type: asv2.ExternalMetricSourceType,
+			External: &asv2.ExternalMetricSource{
+				Metric: asv2.MetricIdentifier{Name: name, Selector: fnSelector},
+				Target: asv2.MetricTarget{
+					Type:         asv2.AverageValueMetricType,
+					AverageValue: resource.NewQuantity(int64(target), resource.DecimalSI),
+				},
+			},
+		}
+	}
+	if execStrategy.TargetConcurrency > 0 {
+		metrics = append(metrics, routerMetric(fv1.FunctionInflightRequestsMetric, execStrategy.TargetConcurrency))
+	}
+	if execStrategy.TargetRequestsPerSecond > 0 {
+		metrics = append(metrics, routerMetric(fv1.FunctionRequestsPerSecondMetric, execStrategy.TargetRequestsPerSecond))
+	}
+
+	if len(execStrategy.ScaleMetricName) > 0 {
+		target, err := resource.ParseQuantity(execStrategy.ScaleMetricTarget)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing target of scale metric %v", execStrategy.ScaleMetricName)
+		}
+		metricTarget := asv2.MetricTarget{
+			Type:         asv2.AverageValueMetricType,
+			AverageValue: &target,
+		}
+		metricID := asv2.MetricIdentifier{Name: execStrategy.ScaleMetricName}
+
+		switch execStrategy.ScaleMetricType {
+		case fv1.ScaleMetricTypeExternal:
+			metrics = append(metrics, asv2.MetricSpec{
+				Type:     asv2.ExternalMetricSourceType,
+				External: &asv2.ExternalMetricSource{Metric: metricID, Target: metricTarget},
+			})
+		default:
+			metrics = append(metrics, asv2.MetricSpec{
+				Type: asv2.PodsMetricSourceType,
+				Pods: &asv2.PodsMetricSource{Metric: metricID, Target: metricTarget},
+			})
+		}
+	}
+
+	return metrics, nil
 }
 
 func (deploy *NewDeploy) createOrGetSvc(deployLabels map[string]string, deployAnnotations map[string]string, svcName string, svcNamespace string) (*apiv1.Service, error) {
@@ -453,7 +557,7 @@ func (deploy *NewDeploy) createOrGetSvc(deployLabels map[string]string, deployAn
 				{
 					Name:       "http-env",
 					Port:       int32(80),
-					TargetPort: intstr.FromInt(8888),
+					TargetPort: deploy.serviceTargetPort(),
 				},
 			},
 			Selector: deployLabels,
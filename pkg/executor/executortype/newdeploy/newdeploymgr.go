@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -44,6 +45,7 @@ import (
 	"github.com/fission/fission/pkg/executor/executortype"
 	"github.com/fission/fission/pkg/executor/fscache"
 	"github.com/fission/fission/pkg/executor/reaper"
+	"github.com/fission/fission/pkg/executor/util"
 	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
 	"github.com/fission/fission/pkg/throttler"
 	"github.com/fission/fission/pkg/utils"
@@ -51,10 +53,17 @@ import (
 
 var _ executortype.ExecutorType = &NewDeploy{}
 
+// idleObjectReaperPollInterval is the interval idleObjectReaper looks for idle function services.
+const idleObjectReaperPollInterval = 10 * time.Second
+
 type (
 	NewDeploy struct {
 		logger *zap.Logger
 
+		// executorType is newdeploy, or container for the functions running
+		// a user container image in place of an environment.
+		executorType fv1.ExecutorType
+
 		kubernetesClient *kubernetes.Clientset
 		fissionClient    *crd.FissionClient
 		crdClient        rest.Interface
@@ -87,6 +96,20 @@ func MakeNewDeploy(
 	fetcherConfig *fetcherConfig.Config,
 	instanceID string,
 ) executortype.ExecutorType {
+	return makeDeployManager(logger.Named("new_deploy"), fv1.ExecutorTypeNewdeploy,
+		fissionClient, kubernetesClient, crdClient, namespace, fetcherConfig, instanceID)
+}
+
+func makeDeployManager(
+	logger *zap.Logger,
+	executorType fv1.ExecutorType,
+	fissionClient *crd.FissionClient,
+	kubernetesClient *kubernetes.Clientset,
+	crdClient rest.Interface,
+	namespace string,
+	fetcherConfig *fetcherConfig.Config,
+	instanceID string,
+) *NewDeploy {
 	enableIstio := false
 	if len(os.Getenv("ENABLE_ISTIO")) > 0 {
 		istio, err := strconv.ParseBool(os.Getenv("ENABLE_ISTIO"))
@@ -97,7 +120,8 @@ func MakeNewDeploy(
 	}
 
 	nd := &NewDeploy{
-		logger: logger.Named("new_deploy"),
+		logger:       logger,
+		executorType: executorType,
 
 		fissionClient:    fissionClient,
 		kubernetesClient: kubernetesClient,
@@ -135,7 +159,7 @@ func (deploy *NewDeploy) Run(ctx context.Context) {
 }
 
 func (deploy *NewDeploy) GetTypeName() fv1.ExecutorType {
-	return fv1.ExecutorTypeNewdeploy
+	return deploy.executorType
 }
 
 func (deploy *NewDeploy) GetFuncSvc(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
@@ -204,7 +228,7 @@ func (deploy *NewDeploy) IsValid(fsvc *fscache.FuncSvc) bool {
 // RefreshFuncPods deleted pods related to the function so that new pods are replenished
 func (deploy *NewDeploy) RefreshFuncPods(logger *zap.Logger, f fv1.Function) error {
 
-	env, err := deploy.fissionClient.CoreV1().Environments(f.Spec.Environment.Namespace).Get(f.Spec.Environment.Name, metav1.GetOptions{})
+	env, err := deploy.getFunctionEnv(&f)
 	if err != nil {
 		return err
 	}
@@ -254,7 +278,7 @@ func (deploy *NewDeploy) AdoptExistingResources() {
 
 	for i := range fnList.Items {
 		fn := &fnList.Items[i]
-		if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeNewdeploy {
+		if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == deploy.executorType {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
@@ -277,7 +301,7 @@ func (deploy *NewDeploy) CleanupOldExecutorObjects() {
 
 	errs := &multierror.Error{}
 	listOpts := metav1.ListOptions{
-		LabelSelector: labels.Set(map[string]string{fv1.EXECUTOR_TYPE: string(fv1.ExecutorTypeNewdeploy)}).AsSelector().String(),
+		LabelSelector: labels.Set(map[string]string{fv1.EXECUTOR_TYPE: string(deploy.executorType)}).AsSelector().String(),
 	}
 
 	err := reaper.CleanupHpa(deploy.logger, deploy.kubernetesClient, deploy.instanceID, listOpts)
@@ -387,7 +411,8 @@ func (deploy *NewDeploy) getEnvFunctions(m *metav1.ObjectMeta) []fv1.Function {
 	}
 	relatedFunctions := make([]fv1.Function, 0)
 	for _, f := range funcList.Items {
-		if (f.Spec.Environment.Name == m.Name) && (f.Spec.Environment.Namespace == m.Namespace) {
+		if (f.Spec.Environment.Name == m.Name) && (f.Spec.Environment.Namespace == m.Namespace) &&
+			f.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == deploy.executorType {
 			relatedFunctions = append(relatedFunctions, f)
 		}
 	}
@@ -395,7 +420,7 @@ func (deploy *NewDeploy) getEnvFunctions(m *metav1.ObjectMeta) []fv1.Function {
 }
 
 func (deploy *NewDeploy) createFunction(fn *fv1.Function) (*fscache.FuncSvc, error) {
-	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeNewdeploy {
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != deploy.executorType {
 		return nil, nil
 	}
 
@@ -424,7 +449,7 @@ func (deploy *NewDeploy) createFunction(fn *fv1.Function) (*fscache.FuncSvc, err
 }
 
 func (deploy *NewDeploy) deleteFunction(fn *fv1.Function) error {
-	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeNewdeploy {
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != deploy.executorType {
 		return nil
 	}
 	err := deploy.fnDelete(fn)
@@ -435,9 +460,7 @@ func (deploy *NewDeploy) deleteFunction(fn *fv1.Function) error {
 }
 
 func (deploy *NewDeploy) fnCreate(fn *fv1.Function) (*fscache.FuncSvc, error) {
-	env, err := deploy.fissionClient.CoreV1().
-		Environments(fn.Spec.Environment.Namespace).
-		Get(fn.Spec.Environment.Name, metav1.GetOptions{})
+	env, err := deploy.getFunctionEnv(fn)
 	if err != nil {
 		return nil, err
 	}
@@ -466,14 +489,14 @@ func (deploy *NewDeploy) fnCreate(fn *fv1.Function) (*fscache.FuncSvc, error) {
 	}
 	svcAddress := fmt.Sprintf("%v.%v", svc.Name, svc.Namespace)
 
-	depl, err := deploy.createOrGetDeployment(fn, env, objName, deployLabels, deployAnnotations, ns)
+	depl, started, err := deploy.createOrGetDeployment(fn, env, objName, deployLabels, deployAnnotations, ns)
 	if err != nil {
 		deploy.logger.Error("error creating deployment", zap.Error(err), zap.String("deployment", objName))
 		go deploy.cleanupNewdeploy(ns, objName)
 		return nil, errors.Wrapf(err, "error creating deployment %v", objName)
 	}
 
-	hpa, err := deploy.createOrGetHpa(objName, &fn.Spec.InvokeStrategy.ExecutionStrategy, depl, deployLabels, deployAnnotations)
+	hpa, err := deploy.createOrGetHpa(objName, fn, depl, deployLabels, deployAnnotations)
 	if err != nil {
 		deploy.logger.Error("error creating HPA", zap.Error(err), zap.String("hpa", objName))
 		go deploy.cleanupNewdeploy(ns, objName)
@@ -514,7 +537,11 @@ func (deploy *NewDeploy) fnCreate(fn *fv1.Function) (*fscache.FuncSvc, error) {
 		Environment:       env,
 		Address:           svcAddress,
 		KubernetesObjects: kubeObjRefs,
-		Executor:          fv1.ExecutorTypeNewdeploy,
+		Executor:          deploy.executorType,
+	}
+	// a request served by the pods already running isn't a cold start
+	if started {
+		fsvc.Specialization = &fscache.SpecializationTimes{}
 	}
 
 	_, err = deploy.fsCache.Add(*fsvc)
@@ -523,7 +550,9 @@ func (deploy *NewDeploy) fnCreate(fn *fv1.Function) (*fscache.FuncSvc, error) {
 		return fsvc, err
 	}
 
-	deploy.fsCache.IncreaseColdStarts(fn.ObjectMeta.Name, string(fn.ObjectMeta.UID))
+	if started {
+		deploy.fsCache.IncreaseColdStarts(fn.ObjectMeta.Name, string(fn.ObjectMeta.UID))
+	}
 
 	return fsvc, nil
 }
@@ -535,14 +564,14 @@ func (deploy *NewDeploy) updateFunction(oldFn *fv1.Function, newFn *fv1.Function
 	}
 
 	// Ignoring updates to functions which are not of NewDeployment type
-	if newFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeNewdeploy &&
-		oldFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeNewdeploy {
+	if newFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != deploy.executorType &&
+		oldFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != deploy.executorType {
 		return nil
 	}
 
 	// Executor type is no longer New Deployment
-	if newFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeNewdeploy &&
-		oldFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeNewdeploy {
+	if newFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != deploy.executorType &&
+		oldFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == deploy.executorType {
 		deploy.logger.Info("function does not use new deployment executor anymore, deleting resources",
 			zap.Any("function", newFn))
 		// IMP - pass the oldFn, as the new/modified function is not in cache
@@ -550,14 +579,14 @@ func (deploy *NewDeploy) updateFunction(oldFn *fv1.Function, newFn *fv1.Function
 	}
 
 	// Executor type changed to New Deployment from something else
-	if oldFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != fv1.ExecutorTypeNewdeploy &&
-		newFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeNewdeploy {
+	if oldFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType != deploy.executorType &&
+		newFn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == deploy.executorType {
 		deploy.logger.Info("function type changed to new deployment, creating resources",
 			zap.Any("old_function", oldFn.ObjectMeta),
 			zap.Any("new_function", newFn.ObjectMeta))
 		_, err := deploy.createFunction(newFn)
 		if err != nil {
-			deploy.updateStatus(oldFn, err, "error changing the function's type to newdeploy")
+			deploy.updateStatus(oldFn, err, fmt.Sprintf("error changing the function's type to %v", deploy.executorType))
 		}
 		return err
 	}
@@ -598,9 +627,20 @@ func (deploy *NewDeploy) updateFunction(oldFn *fv1.Function, newFn *fv1.Function
 			hpaChanged = true
 		}
 
-		if newFn.Spec.InvokeStrategy.ExecutionStrategy.TargetCPUPercent != oldFn.Spec.InvokeStrategy.ExecutionStrategy.TargetCPUPercent {
-			targetCpupercent := int32(newFn.Spec.InvokeStrategy.ExecutionStrategy.TargetCPUPercent)
-			hpa.Spec.TargetCPUUtilizationPercentage = &targetCpupercent
+		newStrategy := newFn.Spec.InvokeStrategy.ExecutionStrategy
+		oldStrategy := oldFn.Spec.InvokeStrategy.ExecutionStrategy
+		if newStrategy.TargetCPUPercent != oldStrategy.TargetCPUPercent ||
+			newStrategy.TargetConcurrency != oldStrategy.TargetConcurrency ||
+			newStrategy.TargetRequestsPerSecond != oldStrategy.TargetRequestsPerSecond ||
+			newStrategy.ScaleMetricName != oldStrategy.ScaleMetricName ||
+			newStrategy.ScaleMetricType != oldStrategy.ScaleMetricType ||
+			newStrategy.ScaleMetricTarget != oldStrategy.ScaleMetricTarget {
+			metrics, err := hpaMetrics(newFn)
+			if err != nil {
+				deploy.updateStatus(oldFn, err, "error updating HPA metrics while updating function")
+				return err
+			}
+			hpa.Spec.Metrics = metrics
 			hpaChanged = true
 		}
 
@@ -615,7 +655,8 @@ func (deploy *NewDeploy) updateFunction(oldFn *fv1.Function, newFn *fv1.Function
 
 	if oldFn.Spec.Environment != newFn.Spec.Environment ||
 		oldFn.Spec.Package.PackageRef != newFn.Spec.Package.PackageRef ||
-		oldFn.Spec.Package.FunctionName != newFn.Spec.Package.FunctionName {
+		oldFn.Spec.Package.FunctionName != newFn.Spec.Package.FunctionName ||
+		!reflect.DeepEqual(oldFn.Spec.Container, newFn.Spec.Container) {
 		deployChanged = true
 	}
 
@@ -642,8 +683,7 @@ func (deploy *NewDeploy) updateFunction(oldFn *fv1.Function, newFn *fv1.Function
 	}
 
 	if deployChanged {
-		env, err := deploy.fissionClient.CoreV1().Environments(newFn.Spec.Environment.Namespace).
-			Get(newFn.Spec.Environment.Name, metav1.GetOptions{})
+		env, err := deploy.getFunctionEnv(newFn)
 		if err != nil {
 			deploy.updateStatus(oldFn, err, "failed to get environment while updating function")
 			return err
@@ -738,12 +778,22 @@ func (deploy *NewDeploy) fnDelete(fn *fv1.Function) error {
 func (deploy *NewDeploy) getObjName(fn *fv1.Function) string {
 	// use meta uuid of function, this ensure we always get the same name for the same function.
 	uid := fn.ObjectMeta.UID[len(fn.ObjectMeta.UID)-17:]
-	return strings.ToLower(fmt.Sprintf("newdeploy-%v-%v-%v", fn.ObjectMeta.Name, fn.ObjectMeta.Namespace, uid))
+	return strings.ToLower(fmt.Sprintf("%v-%v-%v-%v", deploy.executorType, fn.ObjectMeta.Name, fn.ObjectMeta.Namespace, uid))
+}
+
+// getFunctionEnv returns the environment of function. Functions run by the
+// container executor have none, an empty one is returned for them.
+func (deploy *NewDeploy) getFunctionEnv(fn *fv1.Function) (*fv1.Environment, error) {
+	if deploy.executorType == fv1.ExecutorTypeContainer {
+		return &fv1.Environment{}, nil
+	}
+	return deploy.fissionClient.CoreV1().Environments(fn.Spec.Environment.Namespace).
+		Get(fn.Spec.Environment.Name, metav1.GetOptions{})
 }
 
 func (deploy *NewDeploy) getDeployLabels(fnMeta metav1.ObjectMeta, envMeta metav1.ObjectMeta) map[string]string {
 	return map[string]string{
-		fv1.EXECUTOR_TYPE:         string(fv1.ExecutorTypeNewdeploy),
+		fv1.EXECUTOR_TYPE:         string(deploy.executorType),
 		fv1.ENVIRONMENT_NAME:      envMeta.Name,
 		fv1.ENVIRONMENT_NAMESPACE: envMeta.Namespace,
 		fv1.ENVIRONMENT_UID:       string(envMeta.UID),
@@ -766,50 +816,55 @@ func (deploy *NewDeploy) updateStatus(fn *fv1.Function, err error, message strin
 	deploy.logger.Error("function status update", zap.Error(err), zap.Any("function", fn), zap.String("message", message))
 }
 
+// idleTimeout returns the time the deployment of function is kept
+// at its current scale after the last request.
+func (deploy *NewDeploy) idleTimeout(fsvc *fscache.FuncSvc) time.Duration {
+	return util.IdleTimeout(util.GetFunction(deploy.funcStore, fsvc), deploy.idlePodReapTime)
+}
+
 // idleObjectReaper reaps objects after certain idle time
 func (deploy *NewDeploy) idleObjectReaper() {
 
-	pollSleep := time.Duration(deploy.idlePodReapTime)
+	// poll more often than the idle timeout, the one of function may be shorter.
+	pollSleep := idleObjectReaperPollInterval
 	for {
 		time.Sleep(pollSleep)
 
-		envs, err := deploy.fissionClient.CoreV1().Environments(metav1.NamespaceAll).List(metav1.ListOptions{})
-		if err != nil {
-			deploy.logger.Fatal("failed to get environment list", zap.Error(err))
+		// the environments and functions are read from the informer caches,
+		// the reaper polls too often to list them from API server.
+		if !deploy.envController.HasSynced() || !deploy.funcController.HasSynced() {
+			continue
 		}
 
 		envList := make(map[k8sTypes.UID]struct{})
-		for _, env := range envs.Items {
+		for _, obj := range deploy.envStore.List() {
+			env := obj.(*fv1.Environment)
 			envList[env.ObjectMeta.UID] = struct{}{}
 		}
 
-		funcSvcs, err := deploy.fsCache.ListOld(deploy.idlePodReapTime)
+		funcSvcs, err := deploy.fsCache.ListOld(deploy.idleTimeout)
 		if err != nil {
 			deploy.logger.Error("error reaping idle pods", zap.Error(err))
 			continue
 		}
 
 		for _, fsvc := range funcSvcs {
-			if fsvc.Executor != fv1.ExecutorTypeNewdeploy {
+			if fsvc.Executor != deploy.executorType {
 				continue
 			}
 
 			// For function with the environment that no longer exists, executor
 			// scales down the deployment as usual and prints log to notify user.
-			if _, ok := envList[fsvc.Environment.ObjectMeta.UID]; !ok {
+			if _, ok := envList[fsvc.Environment.ObjectMeta.UID]; !ok && deploy.executorType != fv1.ExecutorTypeContainer {
 				deploy.logger.Error("function environment no longer exists",
 					zap.String("environment", fsvc.Environment.ObjectMeta.Name),
 					zap.String("function", fsvc.Name))
 			}
 
-			fn, err := deploy.fissionClient.CoreV1().Functions(fsvc.Function.Namespace).Get(fsvc.Function.Name, metav1.GetOptions{})
-			if err != nil {
-				// Newdeploy manager handles the function delete event and clean cache/kubeobjs itself,
-				// so we ignore the not found error for functions with newdeploy executor type here.
-				if k8sErrs.IsNotFound(err) && fsvc.Executor == fv1.ExecutorTypeNewdeploy {
-					continue
-				}
-				deploy.logger.Error("error getting function", zap.Error(err), zap.String("function", fsvc.Function.Name))
+			// Newdeploy manager handles the function delete event and clean cache/kubeobjs itself,
+			// so we ignore the functions not found here.
+			fn := util.GetFunction(deploy.funcStore, fsvc)
+			if fn == nil {
 				continue
 			}
 
@@ -0,0 +1,36 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/crd"
+)
+
+// makeEnvController returns the informer cache of environments, which
+// idleObjectReaper reads instead of listing them from API server.
+func (gpm *GenericPoolManager) makeEnvController(fissionClient *crd.FissionClient) (k8sCache.Store, k8sCache.Controller) {
+	resyncPeriod := 30 * time.Second
+	lw := k8sCache.NewListWatchFromClient(fissionClient.CoreV1().RESTClient(), "environments", metav1.NamespaceAll, fields.Everything())
+	return k8sCache.NewInformer(lw, &fv1.Environment{}, resyncPeriod, k8sCache.ResourceEventHandlerFuncs{})
+}
@@ -20,7 +20,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"math/rand"
 	"net"
 	"os"
 	"strings"
@@ -39,11 +38,13 @@ import (
 	k8sTypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
+	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/crd"
 	"github.com/fission/fission/pkg/executor/fscache"
 	"github.com/fission/fission/pkg/executor/util"
+	"github.com/fission/fission/pkg/fetcher"
 	fetcherClient "github.com/fission/fission/pkg/fetcher/client"
 	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
 )
@@ -63,23 +64,14 @@ type (
 		useIstio               bool
 		poolInstanceId         string           // small random string to uniquify pod names
 		runtimeImagePullPolicy apiv1.PullPolicy // pull policy for generic pool to created env deployment
-		kubernetesClient       *kubernetes.Clientset
+		kubernetesClient       kubernetes.Interface
 		fissionClient          *crd.FissionClient
-		instanceId             string // poolmgr instance id
-		requestChannel         chan *choosePodRequest
+		instanceId             string         // poolmgr instance id
+		readyPods              *readyPodIndex // pods of the environment, to choose ready ones from
+		podSelector            PodSelector    // strategy choosing the pod to specialize
 		fetcherConfig          *fetcherConfig.Config
 		stopCh                 context.CancelFunc
 	}
-
-	// serialize the choosing of pods so that choices don't conflict
-	choosePodRequest struct {
-		newLabels       map[string]string
-		responseChannel chan *choosePodResponse
-	}
-	choosePodResponse struct {
-		pod *apiv1.Pod
-		error
-	}
 )
 
 func MakeGenericPool(
@@ -93,7 +85,8 @@ func MakeGenericPool(
 	fsCache *fscache.FunctionServiceCache,
 	fetcherConfig *fetcherConfig.Config,
 	instanceId string,
-	enableIstio bool) (*GenericPool, error) {
+	enableIstio bool,
+	podSelector PodSelector) (*GenericPool, error) {
 
 	gpLogger := logger.Named("generic_pool")
 
@@ -107,7 +100,6 @@ func MakeGenericPool(
 		logger:            gpLogger,
 		env:               env,
 		replicas:          initialReplicas, // TODO make this an env param instead?
-		requestChannel:    make(chan *choosePodRequest),
 		fissionClient:     fissionClient,
 		kubernetesClient:  kubernetesClient,
 		namespace:         namespace,
@@ -120,13 +112,14 @@ func MakeGenericPool(
 		instanceId:        instanceId,
 		useSvc:            false,       // defaults off -- svc takes a second or more to become routable, slowing cold start
 		useIstio:          enableIstio, // defaults off -- istio integration requires pod relabeling and it takes a second or more to become routable, slowing cold start
+		podSelector:       podSelector,
 		stopCh:            stopCh,
 	}
 
 	gp.runtimeImagePullPolicy = utils.GetImagePullPolicy(os.Getenv("RUNTIME_IMAGE_PULL_POLICY"))
 
 	// create fetcher SA in this ns, if not already created
-	err := fetcherConfig.SetupServiceAccount(gp.kubernetesClient, gp.namespace, nil)
+	err := fetcherConfig.SetupServiceAccount(kubernetesClient, gp.namespace, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error creating fetcher service account in namespace %q", gp.namespace)
 	}
@@ -141,7 +134,15 @@ func MakeGenericPool(
 	}
 	gpLogger.Info("deployment created", zap.Any("environment", env.ObjectMeta))
 
-	go gp.choosePodService(ctx)
+	// index both the generic pods and the ones specialized for functions
+	envLabels := gp.getEnvironmentPoolLabels()
+	delete(envLabels, "managed")
+	gp.readyPods = makeReadyPodIndex(gp.kubernetesClient, gp.namespace, envLabels)
+	go gp.readyPods.controller.Run(ctx.Done())
+	if !k8sCache.WaitForCacheSync(ctx.Done(), gp.readyPods.controller.HasSynced) {
+		stopCh()
+		return nil, errors.Errorf("error syncing pods of pool for environment %v", env.ObjectMeta.Name)
+	}
 
 	return gp, nil
 }
@@ -162,89 +163,41 @@ func (gp *GenericPool) getDeployAnnotations() map[string]string {
 	}
 }
 
-// choosePodService serializes the choosing of pods
-func (gp *GenericPool) choosePodService(ctx context.Context) {
-	for {
-		select {
-		case req := <-gp.requestChannel:
-			pod, err := gp._choosePod(req.newLabels)
-			if err != nil {
-				req.responseChannel <- &choosePodResponse{error: err}
-				continue
-			}
-			req.responseChannel <- &choosePodResponse{pod: pod}
-		case <-ctx.Done():
-			return
-		}
-	}
-}
+// choosePod picks a ready pod from the pool with the pod selection strategy
+// and relabels it, waiting if necessary. returns the pod API object.
+func (gp *GenericPool) choosePod(fn *metav1.ObjectMeta, newLabels map[string]string) (pod *apiv1.Pod, err error) {
+	startTime := time.Now()
+	defer func() {
+		observePodSelection(gp.podSelector.Name(), startTime, err)
+	}()
 
-// choosePod picks a ready pod from the pool and relabels it, waiting if necessary.
-// returns the pod API object.
-func (gp *GenericPool) choosePod(newLabels map[string]string) (*apiv1.Pod, error) {
-	req := &choosePodRequest{
-		newLabels:       newLabels,
-		responseChannel: make(chan *choosePodResponse),
-	}
-	gp.requestChannel <- req
-	resp := <-req.responseChannel
-	return resp.pod, resp.error
-}
+	// pods are shared by functions in environments allowing infinite functions per container
+	relabel := gp.env.Spec.AllowedFunctionsPerContainer != fv1.AllowedFunctionsPerContainerInfinite
 
-// _choosePod is called serially by choosePodService
-func (gp *GenericPool) _choosePod(newLabels map[string]string) (*apiv1.Pod, error) {
-	startTime := time.Now()
 	for {
 		// Retries took too long, error out.
 		if time.Since(startTime) > gp.podReadyTimeout {
 			gp.logger.Error("timed out waiting for pod", zap.Any("labels", newLabels), zap.Duration("timeout", gp.podReadyTimeout))
-			return nil, errors.New("timeout: waited too long to get a ready pod")
+			return nil, gp.readyPodTimeoutError()
 		}
 
-		// Get pods; filter the ones that are ready
-		podList, err := gp.kubernetesClient.CoreV1().Pods(gp.namespace).List(
-			metav1.ListOptions{
-				LabelSelector: labels.Set(
-					gp.deployment.Spec.Selector.MatchLabels).AsSelector().String(),
-			})
-		if err != nil {
-			return nil, err
-		}
-		readyPods := make([]*apiv1.Pod, 0, len(podList.Items))
-		for i := range podList.Items {
-			pod := podList.Items[i]
+		chosenPod, changed := gp.readyPods.claim(fn, gp.podSelector, relabel)
 
-			// Ignore not ready pod here
-			if !utils.IsReadyPod(&pod) {
-				continue
-			}
-
-			// add it to the list of ready pods
-			readyPods = append(readyPods, &pod)
-		}
-		gp.logger.Info("found ready pods",
-			zap.Any("labels", newLabels),
-			zap.Int("ready_count", len(readyPods)),
-			zap.Int("total", len(podList.Items)))
-
-		// If there are no ready pods, wait and retry.
-		if len(readyPods) == 0 {
-			err = gp.waitForReadyPod()
-			if err != nil {
-				return nil, err
+		// If there are no ready pods, wait for the pods to change and retry.
+		if chosenPod == nil {
+			timer := time.NewTimer(gp.podReadyTimeout - time.Since(startTime))
+			select {
+			case <-changed:
+			case <-timer.C:
 			}
+			timer.Stop()
 			continue
 		}
 
-		// Pick a ready pod.  For now just choose randomly;
-		// ideally we'd care about which node it's running on,
-		// and make a good scheduling decision.
-		chosenPod := readyPods[rand.Intn(len(readyPods))]
-
-		if gp.env.Spec.AllowedFunctionsPerContainer != fv1.AllowedFunctionsPerContainerInfinite {
-			// Relabel.  If the pod already got picked and
-			// modified, this should fail; in that case just
-			// retry.
+		if relabel {
+			// Relabel.  The resource version makes the patch fail if
+			// the pod was modified since the index saw it, e.g. it got
+			// picked by another executor; in that case just retry.
 			labelPatch, _ := json.Marshal(newLabels)
 
 			// Append executor instance id to pod annotations to
@@ -252,11 +205,18 @@ func (gp *GenericPool) _choosePod(newLabels map[string]string) (*apiv1.Pod, erro
 			annotations := gp.getDeployAnnotations()
 			annotationPatch, _ := json.Marshal(annotations)
 
-			patch := fmt.Sprintf(`{"metadata":{"annotations":%v, "labels":%v}}`, string(annotationPatch), string(labelPatch))
+			patch := fmt.Sprintf(`{"metadata":{"resourceVersion":%q, "annotations":%v, "labels":%v}}`,
+				chosenPod.ResourceVersion, string(annotationPatch), string(labelPatch))
 			gp.logger.Info("relabel pod", zap.String("pod", patch))
 			newPod, err := gp.kubernetesClient.CoreV1().Pods(chosenPod.Namespace).Patch(chosenPod.Name, k8sTypes.StrategicMergePatchType, []byte(patch))
 			if err != nil {
-				gp.logger.Error("failed to relabel pod", zap.Error(err), zap.String("pod", chosenPod.Name))
+				if k8sErrs.IsConflict(err) {
+					// the claim is over once the index sees the latest pod
+					gp.logger.Info("pod changed before relabel, choosing again", zap.String("pod", chosenPod.Name))
+				} else {
+					gp.logger.Error("failed to relabel pod", zap.Error(err), zap.String("pod", chosenPod.Name))
+					gp.readyPods.release(chosenPod.Name)
+				}
 				continue
 			}
 
@@ -265,12 +225,14 @@ func (gp *GenericPool) _choosePod(newLabels map[string]string) (*apiv1.Pod, erro
 			// So we have to check both of them to ensure the patch success.
 			for k, v := range newLabels {
 				if newPod.Labels[k] != v {
+					gp.readyPods.release(chosenPod.Name)
 					return nil, errors.Errorf("value of necessary labels '%v' mismatch: want '%v', get '%v'",
 						k, v, newPod.Labels[k])
 				}
 			}
 			for k, v := range annotations {
 				if newPod.Annotations[k] != v {
+					gp.readyPods.release(chosenPod.Name)
 					return nil, errors.Errorf("value of necessary annotations '%v' mismatch: want '%v', get '%v'",
 						k, v, newPod.Annotations[k])
 				}
@@ -278,7 +240,8 @@ func (gp *GenericPool) _choosePod(newLabels map[string]string) (*apiv1.Pod, erro
 		}
 
 		gp.logger.Info("chose pod", zap.Any("labels", newLabels),
-			zap.String("pod", chosenPod.Name), zap.Duration("elapsed_time", time.Since(startTime)))
+			zap.String("pod", chosenPod.Name), zap.String("node", chosenPod.Spec.NodeName),
+			zap.String("strategy", gp.podSelector.Name()), zap.Duration("elapsed_time", time.Since(startTime)))
 
 		return chosenPod, nil
 	}
@@ -333,11 +296,11 @@ func (gp *GenericPool) getFetcherUrl(podIP string) string {
 // specializePod chooses a pod, copies the required user-defined function to that pod
 // (via fetcher), and calls the function-run container to load it, resulting in a
 // specialized pod.
-func (gp *GenericPool) specializePod(ctx context.Context, pod *apiv1.Pod, fn *fv1.Function) error {
+func (gp *GenericPool) specializePod(ctx context.Context, pod *apiv1.Pod, fn *fv1.Function) (*fetcher.FunctionSpecializeResponse, error) {
 	// for fetcher we don't need to create a service, just talk to the pod directly
 	podIP := pod.Status.PodIP
 	if len(podIP) == 0 {
-		return errors.Errorf("Pod %s in namespace %s has no IP", pod.ObjectMeta.Name, pod.ObjectMeta.Namespace)
+		return nil, errors.Errorf("Pod %s in namespace %s has no IP", pod.ObjectMeta.Name, pod.ObjectMeta.Namespace)
 	}
 	// specialize pod with service
 	if gp.useIstio {
@@ -355,12 +318,7 @@ func (gp *GenericPool) specializePod(ctx context.Context, pod *apiv1.Pod, fn *fv
 
 	// Fetcher will download user function to share volume of pod, and
 	// invoke environment specialize api for pod specialization.
-	err := fetcherClient.MakeClient(gp.logger, fetcherUrl).Specialize(ctx, &specializeReq)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return fetcherClient.MakeClient(gp.logger, fetcherUrl).Specialize(ctx, &specializeReq)
 }
 
 // getPoolName returns a unique name of an environment
@@ -513,48 +471,24 @@ func (gp *GenericPool) createPool() error {
 	return nil
 }
 
-func (gp *GenericPool) waitForReadyPod() error {
-	startTime := time.Now()
-	for {
-		// TODO: for now we just poll; use a watch instead
-		depl, err := gp.kubernetesClient.AppsV1().Deployments(gp.namespace).Get(
-			gp.deployment.ObjectMeta.Name, metav1.GetOptions{})
-		if err != nil {
-			e := "error waiting for ready pod for deployment"
-			gp.logger.Error(e, zap.String("deployment", gp.deployment.ObjectMeta.Name), zap.String("namespace", gp.namespace))
-			return fmt.Errorf("%s %q in namespace %q", e, gp.deployment.ObjectMeta.Name, gp.namespace)
-		}
-
-		gp.deployment = depl
-		if gp.deployment.Status.AvailableReplicas > 0 {
-			return nil
-		}
-
-		if time.Since(startTime) > gp.podReadyTimeout {
-			podList, err := gp.kubernetesClient.CoreV1().Pods(gp.namespace).List(metav1.ListOptions{
-				LabelSelector: labels.Set(
-					gp.deployment.Spec.Selector.MatchLabels).AsSelector().String(),
-			})
-			if err != nil {
-				gp.logger.Error("error getting pod list after timeout waiting for ready pod", zap.Error(err))
-			}
-
-			// Since even single pod is not ready, choosing the first pod to inspect is a good approximation. In future this can be done better
-			pod := podList.Items[0]
-			errs := &multierror.Error{}
-			for _, cStatus := range pod.Status.ContainerStatuses {
-				if !cStatus.Ready {
-					errs = multierror.Append(errs, errors.New(fmt.Sprintf("%v: %v", cStatus.State.Waiting.Reason, cStatus.State.Waiting.Message)))
-				}
-			}
-			if errs.ErrorOrNil() != nil {
-				return errors.Wrapf(errs, "Timeout: waited too long for pod of deployment %v in namespace %v to be ready",
-					gp.deployment.ObjectMeta.Name, gp.namespace)
-			}
-			return nil
+// readyPodTimeoutError tells why no pod of the pool became ready in time.
+func (gp *GenericPool) readyPodTimeoutError() error {
+	// Since even single pod is not ready, choosing the first pod to inspect is a good approximation. In future this can be done better
+	pods := gp.readyPods.notReadyPods()
+	if len(pods) == 0 {
+		return errors.New("timeout: waited too long to get a ready pod")
+	}
+	errs := &multierror.Error{}
+	for _, cStatus := range pods[0].Status.ContainerStatuses {
+		if !cStatus.Ready && cStatus.State.Waiting != nil {
+			errs = multierror.Append(errs, errors.New(fmt.Sprintf("%v: %v", cStatus.State.Waiting.Reason, cStatus.State.Waiting.Message)))
 		}
-		time.Sleep(1000 * time.Millisecond)
 	}
+	if errs.ErrorOrNil() != nil {
+		return errors.Wrapf(errs, "Timeout: waited too long for pod of deployment %v in namespace %v to be ready",
+			gp.deployment.ObjectMeta.Name, gp.namespace)
+	}
+	return errors.New("timeout: waited too long to get a ready pod")
 }
 
 func (gp *GenericPool) createSvc(name string, labels map[string]string) (*apiv1.Service, error) {
@@ -581,7 +515,6 @@ func (gp *GenericPool) createSvc(name string, labels map[string]string) (*apiv1.
 
 func (gp *GenericPool) getFuncSvc(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
 	gp.logger.Info("choosing pod from pool", zap.Any("function", fn.ObjectMeta))
-	funcLabels := gp.labelsForFunction(&fn.ObjectMeta)
 
 	if gp.useIstio {
 		// Istio only allows accessing pod through k8s service, and requests come to
@@ -624,16 +557,69 @@ func (gp *GenericPool) getFuncSvc(ctx context.Context, fn *fv1.Function) (*fscac
 		}
 	}
 
-	pod, err := gp.choosePod(funcLabels)
+	fsvc, err := gp.specializeFuncSvc(ctx, fn)
 	if err != nil {
 		return nil, err
 	}
 
-	err = gp.specializePod(ctx, pod, fn)
+	_, err = gp.fsCache.Add(*fsvc)
+	if err != nil {
+		return nil, err
+	}
+
+	gp.fsCache.IncreaseColdStarts(fn.ObjectMeta.Name, string(fn.ObjectMeta.UID))
+
+	return fsvc, nil
+}
+
+// getFuncSvcInstance specializes another pod for a function whose pods are
+// saturated, the function keeps being served by the pods specialized before.
+func (gp *GenericPool) getFuncSvcInstance(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
+	// the requests to a service would be spread over all the pods of
+	// function, no matter which one router chose.
+	if gp.useSvc || gp.useIstio {
+		return nil, errors.New("serving a function with more than one pod is not supported with function services")
+	}
+	if gp.env.Spec.AllowedFunctionsPerContainer == fv1.AllowedFunctionsPerContainerInfinite {
+		return nil, errors.Errorf("serving a function with more than one pod is not supported by environment %v allowing infinite functions per container",
+			gp.env.ObjectMeta.Name)
+	}
+
+	gp.logger.Info("choosing additional pod from pool", zap.Any("function", fn.ObjectMeta))
+	fsvc, err := gp.specializeFuncSvc(ctx, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = gp.fsCache.AddInstance(*fsvc)
+	if err != nil {
+		return nil, err
+	}
+
+	gp.fsCache.IncreaseColdStarts(fn.ObjectMeta.Name, string(fn.ObjectMeta.UID))
+
+	return fsvc, nil
+}
+
+// specializeFuncSvc chooses a pod from the pool and specializes it for the function.
+func (gp *GenericPool) specializeFuncSvc(ctx context.Context, fn *fv1.Function) (*fscache.FuncSvc, error) {
+	funcLabels := gp.labelsForFunction(&fn.ObjectMeta)
+
+	start := time.Now()
+	pod, err := gp.choosePod(&fn.ObjectMeta, funcLabels)
+	if err != nil {
+		return nil, err
+	}
+	times := &fscache.SpecializationTimes{
+		PodSelection: time.Since(start),
+	}
+
+	specializeResp, err := gp.specializePod(ctx, pod, fn)
 	if err != nil {
 		gp.scheduleDeletePod(pod.ObjectMeta.Name)
 		return nil, err
 	}
+	times.Fetch, times.Load = specializeResp.FetchDuration, specializeResp.LoadDuration
 	gp.logger.Info("specialized pod", zap.String("pod", pod.ObjectMeta.Name), zap.Any("function", fn.ObjectMeta))
 
 	var svcHost string
@@ -694,25 +680,17 @@ func (gp *GenericPool) getFuncSvc(ctx context.Context, fn *fv1.Function) (*fscac
 	}
 
 	m := fn.ObjectMeta // only cache necessary part
-	fsvc := &fscache.FuncSvc{
+	return &fscache.FuncSvc{
 		Name:              pod.ObjectMeta.Name,
 		Function:          &m,
 		Environment:       gp.env,
 		Address:           svcHost,
 		KubernetesObjects: kubeObjRefs,
 		Executor:          fv1.ExecutorTypePoolmgr,
+		Specialization:    times,
 		Ctime:             time.Now(),
 		Atime:             time.Now(),
-	}
-
-	_, err = gp.fsCache.Add(*fsvc)
-	if err != nil {
-		return nil, err
-	}
-
-	gp.fsCache.IncreaseColdStarts(fn.ObjectMeta.Name, string(fn.ObjectMeta.UID))
-
-	return fsvc, nil
+	}, nil
 }
 
 // destroys the pool -- the deployment, replicaset and pods
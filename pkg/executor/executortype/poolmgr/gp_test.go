@@ -0,0 +1,291 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	k8sTesting "k8s.io/client-go/testing"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+const testPoolNamespace = "fission-function"
+
+type (
+	// slowClient adds the latency of a real API server to pod patches,
+	// the fake clientset serves requests one at a time.
+	slowClient struct {
+		kubernetes.Interface
+		latency time.Duration
+	}
+	slowCoreV1 struct {
+		corev1.CoreV1Interface
+		latency time.Duration
+	}
+	slowPods struct {
+		corev1.PodInterface
+		latency time.Duration
+	}
+)
+
+func (c slowClient) CoreV1() corev1.CoreV1Interface {
+	return slowCoreV1{CoreV1Interface: c.Interface.CoreV1(), latency: c.latency}
+}
+
+func (c slowCoreV1) Pods(namespace string) corev1.PodInterface {
+	return slowPods{PodInterface: c.CoreV1Interface.Pods(namespace), latency: c.latency}
+}
+
+func (p slowPods) Patch(name string, pt k8sTypes.PatchType, data []byte, subresources ...string) (*apiv1.Pod, error) {
+	time.Sleep(p.latency)
+	return p.PodInterface.Patch(name, pt, data, subresources...)
+}
+
+// makeTestPool makes a pool of ready pods served by a fake API server
+// taking patchLatency to patch a pod. beforePatch is called before a pod
+// is patched, e.g. to let another executor take it.
+func makeTestPool(t testing.TB, pods int, patchLatency time.Duration,
+	beforePatch func(client *fake.Clientset, name string)) *GenericPool {
+
+	env := &fv1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodejs", Namespace: metav1.NamespaceDefault, UID: "env-uid"},
+	}
+	gp := &GenericPool{
+		logger:          zap.NewNop(),
+		env:             env,
+		namespace:       testPoolNamespace,
+		podReadyTimeout: 5 * time.Second,
+		instanceId:      "test",
+	}
+	gp.podSelector, _ = MakePodSelector(PodSelectionRandom, nil)
+
+	objs := make([]runtime.Object, 0, pods)
+	for i := 0; i < pods; i++ {
+		pod := makeTestPod(fmt.Sprintf("pod-%v", i), "node", "")
+		pod.Namespace = testPoolNamespace
+		pod.ResourceVersion = "1"
+		for k, v := range gp.getEnvironmentPoolLabels() {
+			pod.Labels[k] = v
+		}
+		objs = append(objs, pod)
+	}
+	client := fake.NewSimpleClientset(objs...)
+	gp.kubernetesClient = slowClient{Interface: client, latency: patchLatency}
+
+	// the fake clientset ignores resource versions, check them like the API server does
+	var mu sync.Mutex
+	client.PrependReactor("patch", "pods", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		patchAction := action.(k8sTesting.PatchAction)
+		if beforePatch != nil {
+			beforePatch(client, patchAction.GetName())
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		gvr := apiv1.SchemeGroupVersion.WithResource("pods")
+		obj, err := client.Tracker().Get(gvr, testPoolNamespace, patchAction.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		pod := obj.(*apiv1.Pod)
+
+		precondition := &apiv1.Pod{}
+		err = json.Unmarshal(patchAction.GetPatch(), precondition)
+		if err != nil {
+			return true, nil, err
+		}
+		if len(precondition.ResourceVersion) > 0 && precondition.ResourceVersion != pod.ResourceVersion {
+			return true, nil, k8sErrs.NewConflict(gvr.GroupResource(), pod.Name, fmt.Errorf("resource version changed"))
+		}
+
+		podJson, _ := json.Marshal(pod)
+		patched, err := strategicpatch.StrategicMergePatch(podJson, patchAction.GetPatch(), apiv1.Pod{})
+		if err != nil {
+			return true, nil, err
+		}
+		newPod := &apiv1.Pod{}
+		err = json.Unmarshal(patched, newPod)
+		if err != nil {
+			return true, nil, err
+		}
+		newPod.ResourceVersion = nextResourceVersion(pod.ResourceVersion)
+		err = client.Tracker().Update(gvr, newPod, testPoolNamespace)
+		return true, newPod, err
+	})
+
+	// callers stop the informer with gp.stopCh
+	ctx, cancel := context.WithCancel(context.Background())
+	gp.stopCh = cancel
+
+	envLabels := gp.getEnvironmentPoolLabels()
+	delete(envLabels, "managed")
+	gp.readyPods = makeReadyPodIndex(client, testPoolNamespace, envLabels)
+	go gp.readyPods.controller.Run(ctx.Done())
+	if !k8sCache.WaitForCacheSync(ctx.Done(), gp.readyPods.controller.HasSynced) {
+		t.Fatal("error syncing pods of pool")
+	}
+	return gp
+}
+
+func nextResourceVersion(rv string) string {
+	v, _ := strconv.Atoi(rv)
+	return strconv.Itoa(v + 1)
+}
+
+func testFunction(i int) *metav1.ObjectMeta {
+	return &metav1.ObjectMeta{
+		Name:      fmt.Sprintf("fn-%v", i),
+		Namespace: metav1.NamespaceDefault,
+		UID:       "fn-uid",
+	}
+}
+
+// chooseConcurrently chooses a pod for each of functions at the same time,
+// and returns the names of chosen pods.
+func chooseConcurrently(t testing.TB, gp *GenericPool, functions int) []string {
+	chosen := make(chan string, functions)
+	errs := make(chan error, functions)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < functions; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			fn := testFunction(i)
+			pod, err := gp.choosePod(fn, gp.labelsForFunction(fn))
+			if err != nil {
+				errs <- err
+				return
+			}
+			chosen <- pod.Name
+		}(i)
+	}
+	wg.Wait()
+	close(chosen)
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("error choosing pod concurrently: %v", err)
+	}
+	var names []string
+	for name := range chosen {
+		names = append(names, name)
+	}
+	return names
+}
+
+// TestChoosePodConcurrently checks that a burst of functions of the same
+// environment choosing pods at the same time get a pod each.
+func TestChoosePodConcurrently(t *testing.T) {
+	const functions = 16
+
+	gp := makeTestPool(t, functions, 10*time.Millisecond, nil)
+	defer gp.stopCh()
+
+	pods := make(map[string]bool)
+	for _, name := range chooseConcurrently(t, gp, functions) {
+		if pods[name] {
+			t.Fatalf("pod %v was chosen for more than one function", name)
+		}
+		pods[name] = true
+	}
+	if len(pods) != functions {
+		t.Fatalf("expected %v pods chosen, got %v", functions, len(pods))
+	}
+}
+
+// BenchmarkChoosePod measures the cold start throughput of a burst of
+// functions of the same environment, choosing pods one at a time as the
+// serialized pool did versus all at once.
+func BenchmarkChoosePod(b *testing.B) {
+	const functions = 16
+	const patchLatency = 50 * time.Millisecond
+
+	b.Run("serial", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			gp := makeTestPool(b, functions, patchLatency, nil)
+			b.StartTimer()
+			for i := 0; i < functions; i++ {
+				fn := testFunction(i)
+				_, err := gp.choosePod(fn, gp.labelsForFunction(fn))
+				if err != nil {
+					b.Fatalf("error choosing pod: %v", err)
+				}
+			}
+			b.StopTimer()
+			gp.stopCh()
+		}
+	})
+
+	b.Run("concurrent", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			gp := makeTestPool(b, functions, patchLatency, nil)
+			b.StartTimer()
+			chooseConcurrently(b, gp, functions)
+			b.StopTimer()
+			gp.stopCh()
+		}
+	})
+}
+
+// TestChoosePodConflict checks that a pod modified after the index saw it,
+// e.g. taken by another executor, isn't chosen.
+func TestChoosePodConflict(t *testing.T) {
+	var once sync.Once
+	var taken string
+	gp := makeTestPool(t, 2, 0, func(client *fake.Clientset, name string) {
+		once.Do(func() {
+			taken = name
+			gvr := apiv1.SchemeGroupVersion.WithResource("pods")
+			obj, _ := client.Tracker().Get(gvr, testPoolNamespace, name)
+			pod := obj.(*apiv1.Pod).DeepCopy()
+			pod.Labels["managed"] = "false"
+			pod.ResourceVersion = nextResourceVersion(pod.ResourceVersion)
+			client.Tracker().Update(gvr, pod, testPoolNamespace)
+		})
+	})
+	defer gp.stopCh()
+
+	fn := testFunction(0)
+	pod, err := gp.choosePod(fn, gp.labelsForFunction(fn))
+	if err != nil {
+		t.Fatalf("error choosing pod: %v", err)
+	}
+	if pod.Name == taken {
+		t.Fatalf("expected pod %v taken by another executor not to be chosen", taken)
+	}
+}
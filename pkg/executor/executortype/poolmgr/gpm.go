@@ -42,14 +42,19 @@ import (
 	"github.com/fission/fission/pkg/executor/executortype"
 	"github.com/fission/fission/pkg/executor/fscache"
 	"github.com/fission/fission/pkg/executor/reaper"
+	"github.com/fission/fission/pkg/executor/util"
 	fetcherConfig "github.com/fission/fission/pkg/fetcher/config"
 	"github.com/fission/fission/pkg/utils"
 )
 
 var _ executortype.ExecutorType = &GenericPoolManager{}
+var _ executortype.InstanceScaler = &GenericPoolManager{}
 
 type requestType int
 
+// idleObjectReaperPollInterval is the interval idleObjectReaper looks for idle function services.
+const idleObjectReaperPollInterval = 10 * time.Second
+
 const (
 	GET_POOL requestType = iota
 	CLEANUP_POOLS
@@ -71,11 +76,16 @@ type (
 
 		enableIstio   bool
 		fetcherConfig *fetcherConfig.Config
+		podSelector   PodSelector
 
 		funcStore      k8sCache.Store
 		funcController k8sCache.Controller
 		pkgStore       k8sCache.Store
 		pkgController  k8sCache.Controller
+		envStore       k8sCache.Store
+		envController  k8sCache.Controller
+		nodeStore      k8sCache.Store
+		nodeController k8sCache.Controller
 
 		idlePodReapTime time.Duration
 	}
@@ -125,11 +135,27 @@ func MakeGenericPoolManager(
 		gpm.enableIstio = istio
 	}
 
+	// nodes are watched only for the zones the spread strategy needs
+	strategy := os.Getenv("POD_SELECTION_STRATEGY")
+	var zoneOf func(node string) string
+	if strategy == PodSelectionSpread {
+		gpm.nodeStore, gpm.nodeController = gpm.makeNodeController(gpm.kubernetesClient)
+		zoneOf = nodeZone(gpm.nodeStore)
+	}
+	podSelector, err := MakePodSelector(strategy, zoneOf)
+	if err != nil {
+		gpmLogger.Error("failed to parse 'POD_SELECTION_STRATEGY', set to random", zap.Error(err))
+		podSelector, _ = MakePodSelector(PodSelectionRandom, nil)
+	}
+	gpm.podSelector = podSelector
+
 	gpm.funcStore, gpm.funcController = gpm.makeFuncController(
 		gpm.fissionClient, gpm.kubernetesClient, gpm.namespace, gpm.enableIstio)
 
 	gpm.pkgStore, gpm.pkgController = gpm.makePkgController(gpm.fissionClient, gpm.kubernetesClient, gpm.namespace)
 
+	gpm.envStore, gpm.envController = gpm.makeEnvController(gpm.fissionClient)
+
 	return gpm
 }
 
@@ -139,6 +165,10 @@ func (gpm *GenericPoolManager) Run(ctx context.Context) {
 	go gpm.eagerPoolCreator()
 	go gpm.funcController.Run(ctx.Done())
 	go gpm.pkgController.Run(ctx.Done())
+	go gpm.envController.Run(ctx.Done())
+	if gpm.nodeController != nil {
+		go gpm.nodeController.Run(ctx.Done())
+	}
 	go gpm.idleObjectReaper()
 }
 
@@ -165,6 +195,39 @@ func (gpm *GenericPoolManager) GetFuncSvc(ctx context.Context, fn *fv1.Function)
 	return pool.getFuncSvc(ctx, fn)
 }
 
+func (gpm *GenericPoolManager) GetFuncSvcInstance(ctx context.Context, fn *fv1.Function, exclude []string) (*fscache.FuncSvc, error) {
+	excluded := make(map[string]bool, len(exclude))
+	for _, address := range exclude {
+		excluded[address] = true
+	}
+
+	// the router asking may not know all the pods of function,
+	// e.g. the ones specialized for another router.
+	for _, fsvc := range gpm.fsCache.ListByFunction(&fn.ObjectMeta) {
+		if excluded[fsvc.Address] {
+			continue
+		}
+		if gpm.IsValid(fsvc) {
+			gpm.fsCache.TouchByAddress(fsvc.Address)
+			return fsvc, nil
+		}
+		gpm.fsCache.DeleteEntry(fsvc)
+	}
+
+	env, err := gpm.getFunctionEnv(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := gpm.getPool(env)
+	if err != nil {
+		return nil, err
+	}
+
+	gpm.logger.Debug("getting additional function service from pool", zap.String("function", fn.ObjectMeta.Name))
+	return pool.getFuncSvcInstance(ctx, fn)
+}
+
 func (gpm *GenericPoolManager) GetFuncSvcFromCache(fn *fv1.Function) (*fscache.FuncSvc, error) {
 	return gpm.fsCache.GetByFunction(&fn.ObjectMeta)
 }
@@ -224,6 +287,13 @@ func (gpm *GenericPoolManager) RefreshFuncPods(logger *zap.Logger, f fv1.Functio
 		return err
 	}
 
+	// delete the additional function services first,
+	// or they would take over the deleted one.
+	for _, fsvc := range gp.fsCache.ListByFunction(&f.ObjectMeta) {
+		if fsvc.Address != funcSvc.Address {
+			gp.fsCache.DeleteEntry(fsvc)
+		}
+	}
 	gp.fsCache.DeleteEntry(funcSvc)
 
 	funcLabels := gp.labelsForFunction(&f.ObjectMeta)
@@ -359,7 +429,7 @@ func (gpm *GenericPoolManager) AdoptExistingResources() {
 				Atime:    time.Now(),
 			}
 
-			_, err = gpm.fsCache.Add(fsvc)
+			existing, err := gpm.fsCache.Add(fsvc)
 			if err != nil {
 				// If fsvc already exists we just skip the duplicate one. And let reaper to recycle the duplicate pods.
 				// This is for the case that there are multiple function pods for the same function due to unknown reason.
@@ -369,6 +439,15 @@ func (gpm *GenericPoolManager) AdoptExistingResources() {
 
 				return
 			}
+			if existing != nil && existing.Address != fsvc.Address {
+				// The function is served by more than one pod, adopt the pod as an additional
+				// function service, the reaper recycles it once it's idle.
+				err = gpm.fsCache.AddInstance(fsvc)
+				if err != nil {
+					gpm.logger.Warn("failed to adopt pod for function", zap.Error(err), zap.String("pod", pod.Name))
+					return
+				}
+			}
 
 			gpm.logger.Info("adopt function pod",
 				zap.String("pod", pod.Name), zap.Any("labels", pod.Labels), zap.Any("annotations", pod.Annotations))
@@ -426,7 +505,7 @@ func (gpm *GenericPoolManager) service() {
 
 				pool, err = MakeGenericPool(gpm.logger,
 					gpm.fissionClient, gpm.kubernetesClient, req.env, poolsize,
-					ns, gpm.namespace, gpm.fsCache, gpm.fetcherConfig, gpm.instanceId, gpm.enableIstio)
+					ns, gpm.namespace, gpm.fsCache, gpm.fetcherConfig, gpm.instanceId, gpm.enableIstio, gpm.podSelector)
 				if err != nil {
 					req.responseChannel <- &response{error: err}
 					continue
@@ -552,32 +631,72 @@ func (gpm *GenericPoolManager) getEnvPoolsize(env *fv1.Environment) int32 {
 	return poolsize
 }
 
+// idleTimeout returns the time the function service is kept after its last request.
+func (gpm *GenericPoolManager) idleTimeout(fsvc *fscache.FuncSvc) time.Duration {
+	return util.IdleTimeout(util.GetFunction(gpm.funcStore, fsvc), gpm.idlePodReapTime)
+}
+
+// keepWarm returns the idle function services to reap, that is all of them
+// but the one used last of each function that never scales to zero, when the
+// idle ones are all the function services left of the function.
+func (gpm *GenericPoolManager) keepWarm(funcSvcs []*fscache.FuncSvc) []*fscache.FuncSvc {
+	byFunction := make(map[string][]*fscache.FuncSvc)
+	for _, fsvc := range funcSvcs {
+		key := crd.CacheKey(fsvc.Function)
+		byFunction[key] = append(byFunction[key], fsvc)
+	}
+
+	reap := make([]*fscache.FuncSvc, 0, len(funcSvcs))
+	for _, fsvcs := range byFunction {
+		fn := util.GetFunction(gpm.funcStore, fsvcs[0])
+		if fn == nil || !fn.Spec.InvokeStrategy.ExecutionStrategy.NeverScaleToZero ||
+			// the pods of an old version of function are not used anymore
+			fn.ObjectMeta.ResourceVersion != fsvcs[0].Function.ResourceVersion ||
+			len(gpm.fsCache.ListByFunction(fsvcs[0].Function)) > len(fsvcs) {
+			reap = append(reap, fsvcs...)
+			continue
+		}
+
+		last := 0
+		for i := range fsvcs {
+			if fsvcs[i].Atime.After(fsvcs[last].Atime) {
+				last = i
+			}
+		}
+		reap = append(reap, fsvcs[:last]...)
+		reap = append(reap, fsvcs[last+1:]...)
+	}
+	return reap
+}
+
 // idleObjectReaper reaps objects after certain idle time
 func (gpm *GenericPoolManager) idleObjectReaper() {
 
-	pollSleep := time.Duration(gpm.idlePodReapTime)
+	// poll more often than the idle timeout, the one of function may be shorter.
+	pollSleep := idleObjectReaperPollInterval
 	for {
 		time.Sleep(pollSleep)
 
-		envs, err := gpm.fissionClient.CoreV1().Environments(metav1.NamespaceAll).List(metav1.ListOptions{})
-		if err != nil {
-			gpm.logger.Fatal("failed to get environment list", zap.Error(err))
+		// the environments and functions are read from the informer caches,
+		// the reaper polls too often to list them from API server.
+		if !gpm.envController.HasSynced() || !gpm.funcController.HasSynced() {
+			continue
 		}
 
 		envList := make(map[k8sTypes.UID]struct{})
-		for _, env := range envs.Items {
+		for _, obj := range gpm.envStore.List() {
+			env := obj.(*fv1.Environment)
 			envList[env.ObjectMeta.UID] = struct{}{}
 		}
 
-		funcSvcs, err := gpm.fsCache.ListOld(gpm.idlePodReapTime)
+		funcSvcs, err := gpm.fsCache.ListOld(gpm.idleTimeout)
 		if err != nil {
 			gpm.logger.Error("error reaping idle pods", zap.Error(err))
 			continue
 		}
 
-		for i := range funcSvcs {
-			fsvc := funcSvcs[i]
-
+		idle := make([]*fscache.FuncSvc, 0, len(funcSvcs))
+		for _, fsvc := range funcSvcs {
 			if fsvc.Executor != fv1.ExecutorTypePoolmgr {
 				continue
 			}
@@ -594,8 +713,14 @@ func (gpm *GenericPoolManager) idleObjectReaper() {
 				continue
 			}
 
+			idle = append(idle, fsvc)
+		}
+
+		reap := gpm.keepWarm(idle)
+		for i := range reap {
+			fsvc := reap[i]
 			go func() {
-				deleted, err := gpm.fsCache.DeleteOld(fsvc, gpm.idlePodReapTime)
+				deleted, err := gpm.fsCache.DeleteOld(fsvc, gpm.idleTimeout(fsvc))
 				if err != nil {
 					gpm.logger.Error("error deleting Kubernetes objects for function service",
 						zap.Error(err),
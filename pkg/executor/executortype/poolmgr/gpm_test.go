@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/fscache"
+)
+
+// addTestFuncSvcs caches pods function services of a function, the
+// ones after the first as its additional instances.
+func addTestFuncSvcs(t *testing.T, fsCache *fscache.FunctionServiceCache, fn *fv1.Function, pods int) {
+	for i := 0; i < pods; i++ {
+		fsvc := fscache.FuncSvc{
+			Name:     fmt.Sprintf("%v-pod-%v", fn.Name, i),
+			Function: &fn.ObjectMeta,
+			Address:  fmt.Sprintf("%v-%v:8888", fn.Name, i),
+			Executor: fv1.ExecutorTypePoolmgr,
+		}
+		var err error
+		if i == 0 {
+			_, err = fsCache.Add(fsvc)
+		} else {
+			err = fsCache.AddInstance(fsvc)
+		}
+		if err != nil {
+			t.Fatalf("error caching function service: %v", err)
+		}
+		// tell the access times apart
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestKeepWarm checks that a reaper pass spares the pod used last of a
+// function that never scales to zero when all of its pods are idle.
+func TestKeepWarm(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Fatalf("error creating logger: %v", err)
+	}
+
+	funcStore := k8sCache.NewStore(k8sCache.MetaNamespaceKeyFunc)
+	makeFunction := func(name string, neverScaleToZero bool) *fv1.Function {
+		fn := &fv1.Function{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       metav1.NamespaceDefault,
+				UID:             k8sTypes.UID(name + "-uid"),
+				ResourceVersion: "1",
+			},
+		}
+		fn.Spec.InvokeStrategy.ExecutionStrategy.NeverScaleToZero = neverScaleToZero
+		if err := funcStore.Add(fn); err != nil {
+			t.Fatalf("error adding function to store: %v", err)
+		}
+		return fn
+	}
+
+	gpm := &GenericPoolManager{
+		logger:    logger,
+		fsCache:   fscache.MakeFunctionServiceCache(logger),
+		funcStore: funcStore,
+	}
+
+	warm := makeFunction("warm", true)
+	addTestFuncSvcs(t, gpm.fsCache, warm, 3)
+	busy := makeFunction("busy", true)
+	addTestFuncSvcs(t, gpm.fsCache, busy, 3)
+	cold := makeFunction("cold", false)
+	addTestFuncSvcs(t, gpm.fsCache, cold, 2)
+
+	// all the pods are idle but the last one of busy
+	idle, err := gpm.fsCache.ListOld(func(fsvc *fscache.FuncSvc) time.Duration {
+		if fsvc.Name == "busy-pod-2" {
+			return time.Hour
+		}
+		return 0
+	})
+	if err != nil {
+		t.Fatalf("error listing idle function services: %v", err)
+	}
+	if len(idle) != 7 {
+		t.Fatalf("expected 7 idle function services, got %v", len(idle))
+	}
+
+	reaped := make(map[string]bool)
+	for _, fsvc := range gpm.keepWarm(idle) {
+		reaped[fsvc.Name] = true
+	}
+	expected := []string{"warm-pod-0", "warm-pod-1", "busy-pod-0", "busy-pod-1", "cold-pod-0", "cold-pod-1"}
+	for _, name := range expected {
+		if !reaped[name] {
+			t.Errorf("expected %v to be reaped", name)
+		}
+	}
+	if len(reaped) != len(expected) {
+		t.Errorf("expected %v reaped, got %v", expected, reaped)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// strategy: the pod selection strategy
+	// result: "success" or "error"
+	podSelectionSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "fission_poolmgr_pod_selection_seconds",
+			Help:    "The time in seconds to choose a ready pod from the pool, including the wait for one.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+		},
+		[]string{"strategy", "result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(podSelectionSeconds)
+}
+
+func observePodSelection(strategy string, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	podSelectionSeconds.WithLabelValues(strategy, result).Observe(time.Since(start).Seconds())
+}
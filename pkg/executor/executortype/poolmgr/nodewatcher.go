@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	k8sCache "k8s.io/client-go/tools/cache"
+)
+
+// labelZone is the zone label of nodes, the deprecated
+// apiv1.LabelZoneFailureDomain is used if it's not set.
+const labelZone = "topology.kubernetes.io/zone"
+
+// makeNodeController returns the informer cache of nodes, which the spread
+// pod selection strategy reads the zones of nodes from.
+func (gpm *GenericPoolManager) makeNodeController(kubernetesClient kubernetes.Interface) (k8sCache.Store, k8sCache.Controller) {
+	resyncPeriod := 30 * time.Second
+	lw := k8sCache.NewListWatchFromClient(kubernetesClient.CoreV1().RESTClient(), "nodes", metav1.NamespaceAll, fields.Everything())
+	return k8sCache.NewInformer(lw, &apiv1.Node{}, resyncPeriod, k8sCache.ResourceEventHandlerFuncs{})
+}
+
+// nodeZone returns the zone of node in the node cache, empty if the node
+// isn't known or has no zone label.
+func nodeZone(nodeStore k8sCache.Store) func(node string) string {
+	return func(node string) string {
+		obj, exists, err := nodeStore.GetByKey(node)
+		if err != nil || !exists {
+			return ""
+		}
+		labels := obj.(*apiv1.Node).Labels
+		if zone, ok := labels[labelZone]; ok {
+			return zone
+		}
+		return labels[apiv1.LabelZoneFailureDomain]
+	}
+}
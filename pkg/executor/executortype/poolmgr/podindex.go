@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"sync"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	"github.com/fission/fission/pkg/utils"
+)
+
+// readyPodIndex keeps the pods of a pool environment from an informer, so
+// that choosing a pod doesn't list them from the API server. Pods chosen
+// are claimed until the informer sees them changed, so that concurrent
+// choices don't conflict; the relabel patch of a claimed pod is conditioned
+// on the resource version it was claimed at, so that a stale pod is never
+// specialized twice.
+type readyPodIndex struct {
+	store      k8sCache.Store
+	controller k8sCache.Controller
+
+	mu sync.Mutex
+	// claimed maps the name of claimed pods to their resource version
+	claimed map[string]string
+	// changed is closed and replaced once the pods changed
+	changed chan struct{}
+}
+
+// makeReadyPodIndex makes the index of pods in namespace matching envLabels,
+// both the generic pods of pool and the ones specialized for functions.
+func makeReadyPodIndex(kubernetesClient kubernetes.Interface, namespace string, envLabels map[string]string) *readyPodIndex {
+	idx := &readyPodIndex{
+		claimed: make(map[string]string),
+		changed: make(chan struct{}),
+	}
+
+	selector := labels.Set(envLabels).AsSelector().String()
+	lw := &k8sCache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = selector
+			return kubernetesClient.CoreV1().Pods(namespace).List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = selector
+			return kubernetesClient.CoreV1().Pods(namespace).Watch(options)
+		},
+	}
+	idx.store, idx.controller = k8sCache.NewInformer(lw, &apiv1.Pod{}, 0, k8sCache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			idx.update(obj)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			idx.update(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(k8sCache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pod, ok := obj.(*apiv1.Pod); ok {
+				idx.mu.Lock()
+				delete(idx.claimed, pod.Name)
+				idx.mu.Unlock()
+			}
+			idx.broadcast()
+		},
+	})
+	return idx
+}
+
+func (idx *readyPodIndex) update(obj interface{}) {
+	pod := obj.(*apiv1.Pod)
+	idx.mu.Lock()
+	// the claim is over once the pod is relabeled, or changed so that
+	// the relabel patch conditioned on the claimed version fails.
+	if rv, ok := idx.claimed[pod.Name]; ok && (!isGenericPod(pod) || rv != pod.ResourceVersion) {
+		delete(idx.claimed, pod.Name)
+	}
+	idx.mu.Unlock()
+	idx.broadcast()
+}
+
+func (idx *readyPodIndex) broadcast() {
+	idx.mu.Lock()
+	close(idx.changed)
+	idx.changed = make(chan struct{})
+	idx.mu.Unlock()
+}
+
+// claim picks a ready unclaimed pod with selector. If keep is false the pod
+// isn't claimed, e.g. it will be shared by functions. The returned channel
+// is closed once the pods change, to wait on if there is no ready pod.
+func (idx *readyPodIndex) claim(fn *metav1.ObjectMeta, selector PodSelector, keep bool) (*apiv1.Pod, <-chan struct{}) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	var ready, specialized []*apiv1.Pod
+	for _, obj := range idx.store.List() {
+		pod := obj.(*apiv1.Pod)
+		if !isGenericPod(pod) {
+			specialized = append(specialized, pod)
+			continue
+		}
+		if _, claimed := idx.claimed[pod.Name]; !claimed && utils.IsReadyPod(pod) {
+			ready = append(ready, pod)
+		}
+	}
+
+	pod := selector.SelectPod(fn, ready, specialized)
+	if pod == nil {
+		return nil, idx.changed
+	}
+	if keep {
+		idx.claimed[pod.Name] = pod.ResourceVersion
+	}
+	// pods from the store are shared, don't let callers modify them
+	return pod.DeepCopy(), idx.changed
+}
+
+// release gives back a claimed pod that couldn't be relabeled.
+func (idx *readyPodIndex) release(name string) {
+	idx.mu.Lock()
+	delete(idx.claimed, name)
+	idx.mu.Unlock()
+	idx.broadcast()
+}
+
+// notReadyPods returns the generic pods not ready yet.
+func (idx *readyPodIndex) notReadyPods() []*apiv1.Pod {
+	var pods []*apiv1.Pod
+	for _, obj := range idx.store.List() {
+		pod := obj.(*apiv1.Pod)
+		if isGenericPod(pod) && !utils.IsReadyPod(pod) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// isGenericPod returns true if pod is managed by the pool deployment, i.e.
+// not specialized for a function.
+func isGenericPod(pod *apiv1.Pod) bool {
+	return pod.Labels["managed"] == "true"
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"math/rand"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+const (
+	// PodSelectionRandom picks any ready pod.
+	PodSelectionRandom = "random"
+	// PodSelectionSpread prefers the zones, then the nodes of zone,
+	// running the fewest pods of the function.
+	PodSelectionSpread = "spread"
+	// PodSelectionBinpack prefers the nodes running the most specialized pods.
+	PodSelectionBinpack = "binpack"
+	// PodSelectionLocality prefers the nodes already running a pod of the
+	// function. Packages are fetched by each pod, there's no cache of
+	// packages on nodes to prefer.
+	PodSelectionLocality = "locality"
+)
+
+type (
+	// PodSelector picks the pod to specialize for a function among the
+	// ready pods of a pool, specialized are the pods of the environment
+	// already specialized for a function.
+	PodSelector interface {
+		Name() string
+		SelectPod(fn *metav1.ObjectMeta, ready []*apiv1.Pod, specialized []*apiv1.Pod) *apiv1.Pod
+	}
+
+	randomSelector struct{}
+
+	// nodeScoreSelector picks a pod on the node with the highest score,
+	// choosing randomly among the nodes with the same score.
+	nodeScoreSelector struct {
+		name  string
+		score func(fn *metav1.ObjectMeta, node string, specialized []*apiv1.Pod) int
+	}
+)
+
+// MakePodSelector returns the pod selection strategy of the given name,
+// random is used if name is empty. zoneOf returns the zone of a node for
+// the spread strategy, nodes are all in the same zone if it's nil.
+func MakePodSelector(name string, zoneOf func(node string) string) (PodSelector, error) {
+	if zoneOf == nil {
+		zoneOf = func(string) string { return "" }
+	}
+
+	switch name {
+	case "", PodSelectionRandom:
+		return randomSelector{}, nil
+	case PodSelectionSpread:
+		return nodeScoreSelector{name: name, score: func(fn *metav1.ObjectMeta, node string, specialized []*apiv1.Pod) int {
+			zone := zoneOf(node)
+			inZone := 0
+			for _, pod := range specialized {
+				if isFunctionPod(pod, fn) && zoneOf(pod.Spec.NodeName) == zone {
+					inZone++
+				}
+			}
+			// the count on node never reaches len(specialized)+1, so
+			// the count in zone decides first.
+			return -(inZone*(len(specialized)+1) + countPods(specialized, node, fn))
+		}}, nil
+	case PodSelectionBinpack:
+		return nodeScoreSelector{name: name, score: func(fn *metav1.ObjectMeta, node string, specialized []*apiv1.Pod) int {
+			return countPods(specialized, node, nil)
+		}}, nil
+	case PodSelectionLocality:
+		return nodeScoreSelector{name: name, score: func(fn *metav1.ObjectMeta, node string, specialized []*apiv1.Pod) int {
+			if countPods(specialized, node, fn) > 0 {
+				return 1
+			}
+			return 0
+		}}, nil
+	default:
+		return nil, errors.Errorf("unknown pod selection strategy %q", name)
+	}
+}
+
+func (randomSelector) Name() string {
+	return PodSelectionRandom
+}
+
+func (randomSelector) SelectPod(fn *metav1.ObjectMeta, ready []*apiv1.Pod, specialized []*apiv1.Pod) *apiv1.Pod {
+	if len(ready) == 0 {
+		return nil
+	}
+	return ready[rand.Intn(len(ready))]
+}
+
+func (s nodeScoreSelector) Name() string {
+	return s.name
+}
+
+func (s nodeScoreSelector) SelectPod(fn *metav1.ObjectMeta, ready []*apiv1.Pod, specialized []*apiv1.Pod) *apiv1.Pod {
+	scores := make(map[string]int)
+	var best []*apiv1.Pod
+	bestScore := 0
+	for _, pod := range ready {
+		node := pod.Spec.NodeName
+		score, ok := scores[node]
+		if !ok {
+			score = s.score(fn, node, specialized)
+			scores[node] = score
+		}
+		if len(best) == 0 || score > bestScore {
+			best, bestScore = []*apiv1.Pod{pod}, score
+		} else if score == bestScore {
+			best = append(best, pod)
+		}
+	}
+	return randomSelector{}.SelectPod(fn, best, specialized)
+}
+
+// countPods counts the pods on node, only the ones of function fn if it's set.
+func countPods(pods []*apiv1.Pod, node string, fn *metav1.ObjectMeta) int {
+	count := 0
+	for _, pod := range pods {
+		if pod.Spec.NodeName != node {
+			continue
+		}
+		if fn != nil && !isFunctionPod(pod, fn) {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+// isFunctionPod checks whether the pod is specialized for function fn.
+func isFunctionPod(pod *apiv1.Pod, fn *metav1.ObjectMeta) bool {
+	return pod.Labels[fv1.FUNCTION_NAME] == fn.Name && pod.Labels[fv1.FUNCTION_NAMESPACE] == fn.Namespace
+}
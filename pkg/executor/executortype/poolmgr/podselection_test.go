@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolmgr
+
+import (
+	"fmt"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func makeTestPod(name string, node string, fn string) *apiv1.Pod {
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "fission-function",
+			Labels:    map[string]string{"managed": "true"},
+		},
+		Spec: apiv1.PodSpec{NodeName: node},
+		Status: apiv1.PodStatus{
+			Phase:             apiv1.PodRunning,
+			PodIP:             "10.0.0.1",
+			ContainerStatuses: []apiv1.ContainerStatus{{Ready: true}},
+		},
+	}
+	if len(fn) > 0 {
+		pod.Labels = map[string]string{
+			"managed":              "false",
+			fv1.FUNCTION_NAME:      fn,
+			fv1.FUNCTION_NAMESPACE: "default",
+		}
+	}
+	return pod
+}
+
+func TestPodSelectors(t *testing.T) {
+	fn := &metav1.ObjectMeta{Name: "foo", Namespace: "default"}
+	ready := []*apiv1.Pod{
+		makeTestPod("a", "node-a", ""),
+		makeTestPod("b", "node-b", ""),
+		makeTestPod("c", "node-c", ""),
+	}
+	specialized := []*apiv1.Pod{
+		makeTestPod("foo-1", "node-a", "foo"),
+		makeTestPod("bar-1", "node-b", "bar"),
+		makeTestPod("bar-2", "node-b", "bar"),
+		makeTestPod("baz-1", "node-c", "baz"),
+	}
+
+	tests := []struct {
+		strategy string
+		expected []string
+	}{
+		{strategy: PodSelectionSpread, expected: []string{"b", "c"}},
+		{strategy: PodSelectionBinpack, expected: []string{"b"}},
+		{strategy: PodSelectionLocality, expected: []string{"a"}},
+		{strategy: PodSelectionRandom, expected: []string{"a", "b", "c"}},
+	}
+	for _, test := range tests {
+		t.Run(test.strategy, func(t *testing.T) {
+			selector, err := MakePodSelector(test.strategy, nil)
+			if err != nil {
+				t.Fatalf("error making pod selector: %v", err)
+			}
+			for i := 0; i < 10; i++ {
+				pod := selector.SelectPod(fn, ready, specialized)
+				if pod == nil || !contains(test.expected, pod.Name) {
+					t.Fatalf("expected one of pods %v, got %v", test.expected, pod)
+				}
+			}
+		})
+	}
+
+	if _, err := MakePodSelector("nearest", nil); err == nil {
+		t.Fatal("expected error for unknown pod selection strategy")
+	}
+}
+
+// TestSpreadZones checks that the spread strategy prefers the zones
+// running fewer pods of the function over the nodes.
+func TestSpreadZones(t *testing.T) {
+	nodeStore := k8sCache.NewStore(k8sCache.MetaNamespaceKeyFunc)
+	for node, zone := range map[string]string{"node-a": "zone-1", "node-b": "zone-1", "node-c": "zone-2"} {
+		nodeStore.Add(&apiv1.Node{ObjectMeta: metav1.ObjectMeta{Name: node, Labels: map[string]string{labelZone: zone}}})
+	}
+	// a node labeled with the deprecated zone label only
+	nodeStore.Add(&apiv1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "node-d",
+		Labels: map[string]string{apiv1.LabelZoneFailureDomain: "zone-1"},
+	}})
+
+	fn := &metav1.ObjectMeta{Name: "foo", Namespace: "default"}
+	ready := []*apiv1.Pod{
+		makeTestPod("b", "node-b", ""),
+		makeTestPod("c", "node-c", ""),
+		makeTestPod("d", "node-d", ""),
+	}
+	specialized := []*apiv1.Pod{
+		makeTestPod("foo-1", "node-a", "foo"),
+		makeTestPod("foo-2", "node-c", "foo"),
+		makeTestPod("foo-3", "node-c", "foo"),
+	}
+
+	selector, err := MakePodSelector(PodSelectionSpread, nodeZone(nodeStore))
+	if err != nil {
+		t.Fatalf("error making pod selector: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		pod := selector.SelectPod(fn, ready, specialized)
+		if pod == nil || !contains([]string{"b", "d"}, pod.Name) {
+			t.Fatalf("expected one of pods [b d] in zone-1, got %v", pod)
+		}
+	}
+
+	// zone-2 runs fewer pods of the function once zone-1 runs more
+	specialized = append(specialized, makeTestPod("foo-4", "node-b", "foo"), makeTestPod("foo-5", "node-d", "foo"))
+	for i := 0; i < 10; i++ {
+		pod := selector.SelectPod(fn, ready, specialized)
+		if pod == nil || pod.Name != "c" {
+			t.Fatalf("expected pod c in zone-2, got %v", pod)
+		}
+	}
+}
+
+func TestReadyPodIndexClaim(t *testing.T) {
+	idx := &readyPodIndex{
+		store:   k8sCache.NewStore(k8sCache.MetaNamespaceKeyFunc),
+		claimed: make(map[string]string),
+		changed: make(chan struct{}),
+	}
+	for i := 0; i < 2; i++ {
+		idx.store.Add(makeTestPod(fmt.Sprintf("pod-%v", i), "node", ""))
+	}
+	notReady := makeTestPod("pod-not-ready", "node", "")
+	notReady.Status.ContainerStatuses[0].Ready = false
+	idx.store.Add(notReady)
+
+	fn := &metav1.ObjectMeta{Name: "foo", Namespace: "default"}
+	selector, _ := MakePodSelector(PodSelectionRandom, nil)
+
+	first, _ := idx.claim(fn, selector, true)
+	second, _ := idx.claim(fn, selector, true)
+	if first == nil || second == nil || first.Name == second.Name {
+		t.Fatalf("expected two different pods claimed, got %v and %v", first, second)
+	}
+
+	third, changed := idx.claim(fn, selector, true)
+	if third != nil {
+		t.Fatalf("expected no ready pod left, got %v", third.Name)
+	}
+
+	// the waiting chooser is woken up once the claimed pod is given back
+	idx.release(first.Name)
+	select {
+	case <-changed:
+	default:
+		t.Fatal("expected pod change to be broadcast")
+	}
+	third, _ = idx.claim(fn, selector, true)
+	if third == nil || third.Name != first.Name {
+		t.Fatalf("expected released pod %v to be claimed again, got %v", first.Name, third)
+	}
+
+	// the claim is over once the pod is relabeled
+	relabeled := makeTestPod(second.Name, "node", "foo")
+	idx.store.Update(relabeled)
+	idx.update(relabeled)
+	if _, ok := idx.claimed[second.Name]; ok {
+		t.Fatalf("expected claim of relabeled pod %v to be dropped", second.Name)
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -40,6 +40,9 @@ const (
 	TOUCH fscRequestType = iota
 	LISTOLD
 	LOG
+	LISTBYFUNCTION
+	PROMOTE
+	GETBYFUNCTION
 )
 
 type (
@@ -51,26 +54,45 @@ type (
 		KubernetesObjects []apiv1.ObjectReference // Kubernetes Objects (within the function namespace)
 		Executor          fv1.ExecutorType
 
+		// Specialization is the time spent on creating the function
+		// service, it's nil for the services adopted by executor.
+		Specialization *SpecializationTimes
+
 		Ctime time.Time
 		Atime time.Time
 	}
 
+	// SpecializationTimes is the time spent on the steps of creating a function
+	// service. The steps not taken by an executor type are left zero.
+	SpecializationTimes struct {
+		PodSelection time.Duration // choosing a pod from the pool
+		Fetch        time.Duration // downloading the package, secrets and configmaps
+		Load         time.Duration // loading the function in environment
+		Total        time.Duration
+	}
+
 	FunctionServiceCache struct {
 		logger        *zap.Logger
 		byFunction    *cache.Cache // function-key -> funcSvc  : map[string]*funcSvc
 		byAddress     *cache.Cache // address      -> function : map[string]metav1.ObjectMeta
 		byFunctionUID *cache.Cache // function uid -> function : map[string]metav1.ObjectMeta
 
+		// instances are the additional function services of the functions
+		// served by more than one pod, see ExecutionStrategy.ConcurrencyPerInstance.
+		instances *cache.Cache // address -> funcSvc : map[string]*funcSvc
+
 		requestChannel chan *fscRequest
 	}
 	fscRequest struct {
 		requestType     fscRequestType
 		address         string
-		age             time.Duration
+		function        *metav1.ObjectMeta
+		idleTimeout     func(*FuncSvc) time.Duration
 		responseChannel chan *fscResponse
 	}
 	fscResponse struct {
 		objects []*FuncSvc
+		object  *FuncSvc
 		error
 	}
 )
@@ -95,6 +117,7 @@ func MakeFunctionServiceCache(logger *zap.Logger) *FunctionServiceCache {
 		byFunction:     cache.MakeCache(0, 0),
 		byAddress:      cache.MakeCache(0, 0),
 		byFunctionUID:  cache.MakeCache(0, 0),
+		instances:      cache.MakeCache(0, 0),
 		requestChannel: make(chan *fscRequest),
 	}
 	go fsc.service()
@@ -110,12 +133,18 @@ func (fsc *FunctionServiceCache) service() {
 			// update atime for this function svc
 			resp.error = fsc._touchByAddress(req.address)
 		case LISTOLD:
-			// get svcs idle for > req.age
+			// get svcs idle for > their idle timeout
 			fscs := fsc.byFunction.Copy()
 			funcObjects := make([]*FuncSvc, 0)
 			for _, funcSvc := range fscs {
 				fsvc := funcSvc.(*FuncSvc)
-				if time.Since(fsvc.Atime) > req.age {
+				if time.Since(fsvc.Atime) > req.idleTimeout(fsvc) {
+					funcObjects = append(funcObjects, fsvc)
+				}
+			}
+			for _, funcSvc := range fsc.instances.Copy() {
+				fsvc := funcSvc.(*FuncSvc)
+				if time.Since(fsvc.Atime) > req.idleTimeout(fsvc) {
 					funcObjects = append(funcObjects, fsvc)
 				}
 			}
@@ -130,16 +159,37 @@ func (fsc *FunctionServiceCache) service() {
 					info = append(info, fmt.Sprintf("%v\t%v\t%v", key, kubeObj.Kind, kubeObj.Name))
 				}
 			}
-			fsc.logger.Info("function service cache", zap.Int("item_count", len(funcCopy)), zap.Strings("cache", info))
+			instanceCopy := fsc.instances.Copy()
+			for address, fsvcI := range instanceCopy {
+				fsvc := fsvcI.(*FuncSvc)
+				info = append(info, fmt.Sprintf("%v\t%v\tinstance", crd.CacheKey(fsvc.Function), address))
+			}
+			fsc.logger.Info("function service cache", zap.Int("item_count", len(funcCopy)),
+				zap.Int("instance_count", len(instanceCopy)), zap.Strings("cache", info))
+		case LISTBYFUNCTION:
+			resp.objects = fsc._listByFunction(req.function)
+		case PROMOTE:
+			fsc._promoteInstance(req.function)
+		case GETBYFUNCTION:
+			resp.object, resp.error = fsc._getByFunction(req.function)
 		}
 		req.responseChannel <- resp
 	}
 }
 
 func (fsc *FunctionServiceCache) GetByFunction(m *metav1.ObjectMeta) (*FuncSvc, error) {
-	key := crd.CacheKey(m)
+	responseChannel := make(chan *fscResponse)
+	fsc.requestChannel <- &fscRequest{
+		requestType:     GETBYFUNCTION,
+		function:        m,
+		responseChannel: responseChannel,
+	}
+	resp := <-responseChannel
+	return resp.object, resp.error
+}
 
-	fsvcI, err := fsc.byFunction.Get(key)
+func (fsc *FunctionServiceCache) _getByFunction(m *metav1.ObjectMeta) (*FuncSvc, error) {
+	fsvcI, err := fsc.byFunction.Get(crd.CacheKey(m))
 	if err != nil {
 		return nil, err
 	}
@@ -159,21 +209,15 @@ func (fsc *FunctionServiceCache) GetByFunctionUID(uid types.UID) (*FuncSvc, erro
 	}
 
 	m := mI.(metav1.ObjectMeta)
-
-	fsvcI, err := fsc.byFunction.Get(crd.CacheKey(&m))
-	if err != nil {
-		return nil, err
-	}
-
-	// update atime
-	fsvc := fsvcI.(*FuncSvc)
-	fsvc.Atime = time.Now()
-
-	fsvcCopy := *fsvc
-	return &fsvcCopy, nil
+	return fsc.GetByFunction(&m)
 }
 
 func (fsc *FunctionServiceCache) Add(fsvc FuncSvc) (*FuncSvc, error) {
+	// set the times before the function service is visible to others
+	now := time.Now()
+	fsvc.Ctime = now
+	fsvc.Atime = now
+
 	existing, err := fsc.byFunction.Set(crd.CacheKey(fsvc.Function), &fsvc)
 	if err != nil {
 		if IsNameExistError(err) {
@@ -187,9 +231,6 @@ func (fsc *FunctionServiceCache) Add(fsvc FuncSvc) (*FuncSvc, error) {
 		}
 		return nil, err
 	}
-	now := time.Now()
-	fsvc.Ctime = now
-	fsvc.Atime = now
 
 	// Add to byAddress cache. Ignore NameExists errors
 	// because of multiple-specialization. See issue #331.
@@ -219,6 +260,58 @@ func (fsc *FunctionServiceCache) Add(fsvc FuncSvc) (*FuncSvc, error) {
 	return nil, nil
 }
 
+// AddInstance adds an additional function service of a function that's
+// already served by the one added with Add.
+func (fsc *FunctionServiceCache) AddInstance(fsvc FuncSvc) error {
+	now := time.Now()
+	fsvc.Ctime = now
+	fsvc.Atime = now
+
+	_, err := fsc.instances.Set(fsvc.Address, &fsvc)
+	if err != nil {
+		return errors.Wrap(err, "error caching fsvc instance")
+	}
+
+	_, err = fsc.byAddress.Set(fsvc.Address, *fsvc.Function)
+	if err != nil && !IsNameExistError(err) {
+		return errors.Wrap(err, "error caching fsvc instance")
+	}
+	return nil
+}
+
+// ListByFunction returns all the function services of a function, the one
+// added with Add comes first.
+func (fsc *FunctionServiceCache) ListByFunction(m *metav1.ObjectMeta) []*FuncSvc {
+	responseChannel := make(chan *fscResponse)
+	fsc.requestChannel <- &fscRequest{
+		requestType:     LISTBYFUNCTION,
+		function:        m,
+		responseChannel: responseChannel,
+	}
+	resp := <-responseChannel
+	return resp.objects
+}
+
+// _listByFunction copies the function services in the service goroutine,
+// which is the one updating their access times.
+func (fsc *FunctionServiceCache) _listByFunction(m *metav1.ObjectMeta) []*FuncSvc {
+	key := crd.CacheKey(m)
+
+	fsvcs := make([]*FuncSvc, 0)
+	if fsvcI, err := fsc.byFunction.Get(key); err == nil {
+		fsvcCopy := *(fsvcI.(*FuncSvc))
+		fsvcs = append(fsvcs, &fsvcCopy)
+	}
+	for _, fsvcI := range fsc.instances.Copy() {
+		fsvc := fsvcI.(*FuncSvc)
+		if crd.CacheKey(fsvc.Function) == key {
+			fsvcCopy := *fsvc
+			fsvcs = append(fsvcs, &fsvcCopy)
+		}
+	}
+	return fsvcs
+}
+
 func (fsc *FunctionServiceCache) TouchByAddress(address string) error {
 	responseChannel := make(chan *fscResponse)
 	fsc.requestChannel <- &fscRequest{
@@ -231,6 +324,12 @@ func (fsc *FunctionServiceCache) TouchByAddress(address string) error {
 }
 
 func (fsc *FunctionServiceCache) _touchByAddress(address string) error {
+	if fsvcI, err := fsc.instances.Get(address); err == nil {
+		fsvc := fsvcI.(*FuncSvc)
+		fsvc.Atime = time.Now()
+		return nil
+	}
+
 	mI, err := fsc.byAddress.Get(address)
 	if err != nil {
 		return err
@@ -246,6 +345,12 @@ func (fsc *FunctionServiceCache) _touchByAddress(address string) error {
 }
 
 func (fsc *FunctionServiceCache) DeleteEntry(fsvc *FuncSvc) {
+	if fsvcI, err := fsc.instances.Get(fsvc.Address); err == nil && fsvcI.(*FuncSvc).Name == fsvc.Name {
+		fsc.instances.Delete(fsvc.Address)
+		fsc.byAddress.Delete(fsvc.Address)
+		return
+	}
+
 	fsc.byFunction.Delete(crd.CacheKey(fsvc.Function))
 	fsc.byAddress.Delete(fsvc.Address)
 	fsc.byFunctionUID.Delete(fsvc.Function.UID)
@@ -253,6 +358,51 @@ func (fsc *FunctionServiceCache) DeleteEntry(fsvc *FuncSvc) {
 	fsc.observeFuncRunningTime(fsvc.Function.Name, string(fsvc.Function.UID), fsvc.Atime.Sub(fsvc.Ctime).Seconds())
 	fsc.observeFuncAliveTime(fsvc.Function.Name, string(fsvc.Function.UID), time.Since(fsvc.Ctime).Seconds())
 	fsc.setFuncAlive(fsvc.Function.Name, string(fsvc.Function.UID), false)
+
+	fsc.promoteInstance(fsvc.Function)
+}
+
+// promoteInstance makes an additional function service of the function the
+// one returned by GetByFunction, so that executor doesn't specialize a new
+// pod while the function is still served by another one.
+func (fsc *FunctionServiceCache) promoteInstance(m *metav1.ObjectMeta) {
+	responseChannel := make(chan *fscResponse)
+	fsc.requestChannel <- &fscRequest{
+		requestType:     PROMOTE,
+		function:        m,
+		responseChannel: responseChannel,
+	}
+	<-responseChannel
+}
+
+// _promoteInstance runs in the service goroutine, so that the instance isn't
+// touched or listed while it's moved.
+func (fsc *FunctionServiceCache) _promoteInstance(m *metav1.ObjectMeta) {
+	key := crd.CacheKey(m)
+	for address, fsvcI := range fsc.instances.Copy() {
+		fsvc := fsvcI.(*FuncSvc)
+		if crd.CacheKey(fsvc.Function) != key {
+			continue
+		}
+
+		// the instance keeps its times for the reaper
+		_, err := fsc.byFunction.Set(key, fsvc)
+		if err != nil {
+			// a new function service was added meanwhile
+			if !IsNameExistError(err) {
+				fsc.logger.Error("error promoting function service instance", zap.Error(err), zap.Any("address", address))
+			}
+			return
+		}
+		fsc.instances.Delete(address)
+
+		_, err = fsc.byFunctionUID.Set(fsvc.Function.UID, *fsvc.Function)
+		if err != nil && !IsNameExistError(err) {
+			fsc.logger.Error("error caching promoted function service by function uid", zap.Error(err), zap.Any("address", address))
+		}
+		fsc.setFuncAlive(fsvc.Function.Name, string(fsvc.Function.UID), true)
+		return
+	}
 }
 
 func (fsc *FunctionServiceCache) DeleteOld(fsvc *FuncSvc, minAge time.Duration) (bool, error) {
@@ -265,11 +415,13 @@ func (fsc *FunctionServiceCache) DeleteOld(fsvc *FuncSvc, minAge time.Duration)
 	return true, nil
 }
 
-func (fsc *FunctionServiceCache) ListOld(age time.Duration) ([]*FuncSvc, error) {
+// ListOld returns the function services idle for longer than the
+// idle timeout returned for them.
+func (fsc *FunctionServiceCache) ListOld(idleTimeout func(*FuncSvc) time.Duration) ([]*FuncSvc, error) {
 	responseChannel := make(chan *fscResponse)
 	fsc.requestChannel <- &fscRequest{
 		requestType:     LISTOLD,
-		age:             age,
+		idleTimeout:     idleTimeout,
 		responseChannel: responseChannel,
 	}
 	resp := <-responseChannel
@@ -1,7 +1,9 @@
 package fscache
 
 import (
+	"fmt"
 	"log"
+	"sync"
 	"testing"
 	"time"
 
@@ -119,3 +121,133 @@ func TestFunctionServiceCache(t *testing.T) {
 		log.Panicf("found fsvc by function uid while expecting empty cache: %v", err)
 	}
 }
+
+func TestFunctionServiceCacheInstances(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	panicIf(err)
+
+	fsc := MakeFunctionServiceCache(logger)
+
+	fn := &metav1.ObjectMeta{
+		Name:      "foo",
+		Namespace: "default",
+		UID:       "1212",
+	}
+	primary := FuncSvc{
+		Name:     "pod-1",
+		Function: fn,
+		Address:  "10.0.0.1:8888",
+	}
+	instance := FuncSvc{
+		Name:     "pod-2",
+		Function: fn,
+		Address:  "10.0.0.2:8888",
+	}
+
+	_, err = fsc.Add(primary)
+	panicIf(err)
+	err = fsc.AddInstance(instance)
+	panicIf(err)
+
+	fsvcs := fsc.ListByFunction(fn)
+	if len(fsvcs) != 2 || fsvcs[0].Address != primary.Address || fsvcs[1].Address != instance.Address {
+		fsc.Log()
+		log.Panicf("expected primary and instance function services, got %v", fsvcs)
+	}
+
+	err = fsc.TouchByAddress(instance.Address)
+	if err != nil {
+		log.Panicf("failed to touch instance: %v", err)
+	}
+
+	// the instance takes over once the primary is deleted
+	fsc.DeleteEntry(fsvcs[0])
+	f, err := fsc.GetByFunction(fn)
+	if err != nil || f.Address != instance.Address {
+		fsc.Log()
+		log.Panicf("expected instance to take over deleted function service, got %v (%v)", f, err)
+	}
+	if fsvcs = fsc.ListByFunction(fn); len(fsvcs) != 1 {
+		log.Panicf("expected one function service after deletion, got %v", fsvcs)
+	}
+
+	// deleting an instance leaves the primary
+	err = fsc.AddInstance(primary)
+	panicIf(err)
+	fsc.DeleteEntry(&primary)
+	f, err = fsc.GetByFunction(fn)
+	if err != nil || f.Address != instance.Address {
+		log.Panicf("expected function service to remain after deleting instance, got %v (%v)", f, err)
+	}
+	if fsvcs = fsc.ListByFunction(fn); len(fsvcs) != 1 {
+		log.Panicf("expected one function service after deleting instance, got %v", fsvcs)
+	}
+}
+
+// the instances are promoted while they're touched and listed, run with -race
+func TestFunctionServiceCachePromoteConcurrently(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	panicIf(err)
+
+	fsc := MakeFunctionServiceCache(logger)
+	fn := &metav1.ObjectMeta{Name: "foo", Namespace: "default", UID: "1212"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		primary := FuncSvc{Name: fmt.Sprintf("pod-%v", i), Function: fn, Address: fmt.Sprintf("10.0.0.%v:8888", i)}
+		instance := FuncSvc{Name: fmt.Sprintf("pod-%v", i+10), Function: fn, Address: fmt.Sprintf("10.0.1.%v:8888", i)}
+		_, err = fsc.Add(primary)
+		panicIf(err)
+		panicIf(fsc.AddInstance(instance))
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			fsc.TouchByAddress(instance.Address)
+		}()
+		go func() {
+			defer wg.Done()
+			fsc.ListByFunction(fn)
+		}()
+		fsc.DeleteEntry(&primary)
+
+		f, err := fsc.GetByFunction(fn)
+		if err != nil || f.Address != instance.Address {
+			log.Panicf("expected instance to take over deleted function service, got %v (%v)", f, err)
+		}
+		fsc.DeleteEntry(f)
+	}
+	wg.Wait()
+}
+
+func TestFunctionServiceCacheListOld(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	panicIf(err)
+
+	fsc := MakeFunctionServiceCache(logger)
+
+	short := FuncSvc{
+		Function: &metav1.ObjectMeta{Name: "short", UID: "1"},
+		Address:  "10.0.0.1:8888",
+	}
+	long := FuncSvc{
+		Function: &metav1.ObjectMeta{Name: "long", UID: "2"},
+		Address:  "10.0.0.2:8888",
+	}
+	for _, fsvc := range []FuncSvc{short, long} {
+		_, err = fsc.Add(fsvc)
+		panicIf(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	old, err := fsc.ListOld(func(fsvc *FuncSvc) time.Duration {
+		if fsvc.Function.Name == "long" {
+			return time.Hour
+		}
+		return time.Millisecond
+	})
+	panicIf(err)
+	if len(old) != 1 || old[0].Function.Name != "short" {
+		log.Panicf("expected only function service with short idle timeout, got %v", old)
+	}
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/crd"
+)
+
+const (
+	// defaultPrewarmLeadTime is how long before the expected traffic a
+	// function gets specialized.
+	defaultPrewarmLeadTime = time.Minute
+
+	prewarmPollInterval = 15 * time.Second
+
+	// sessionGap is the time without invocations after which the next
+	// invocation of a function is taken as the start of a new session.
+	sessionGap = 2 * time.Minute
+
+	// the number of session starts kept, and needed to predict the next one
+	maxSessions = 5
+	minSessions = 3
+
+	// maxIntervalDeviation is how much the intervals between sessions may
+	// deviate from their mean for the pattern to be taken as periodic.
+	maxIntervalDeviation = 0.1
+
+	// historyExpiry drops the history of functions not invoked anymore.
+	historyExpiry = 7 * 24 * time.Hour
+)
+
+type (
+	// prewarmer specializes function services shortly before the traffic
+	// expected from the periodic invocation pattern of a function or from
+	// the cron schedule of a time trigger, so that the first requests
+	// don't wait for the fetch and specialization.
+	prewarmer struct {
+		logger        *zap.Logger
+		fissionClient *crd.FissionClient
+		leadTime      time.Duration
+		warm          func(fn *fv1.Function) error
+
+		mu      sync.Mutex
+		history map[string]*invocationHistory
+		// warmed is the last expected time warmed for, by function or time trigger
+		warmed map[string]time.Time
+
+		timeTriggerStore      k8sCache.Store
+		timeTriggerController k8sCache.Controller
+	}
+
+	invocationHistory struct {
+		namespace string
+		name      string
+		lastSeen  time.Time
+		sessions  []time.Time
+	}
+)
+
+func makePrewarmer(logger *zap.Logger, fissionClient *crd.FissionClient, leadTime time.Duration,
+	warm func(fn *fv1.Function) error) *prewarmer {
+
+	p := &prewarmer{
+		logger:        logger.Named("prewarmer"),
+		fissionClient: fissionClient,
+		leadTime:      leadTime,
+		warm:          warm,
+		history:       make(map[string]*invocationHistory),
+		warmed:        make(map[string]time.Time),
+	}
+	if fissionClient != nil {
+		lw := k8sCache.NewListWatchFromClient(fissionClient.CoreV1().RESTClient(), "timetriggers", metav1.NamespaceAll, fields.Everything())
+		p.timeTriggerStore, p.timeTriggerController = k8sCache.NewInformer(lw, &fv1.TimeTrigger{}, 0, k8sCache.ResourceEventHandlerFuncs{})
+	}
+	return p
+}
+
+func (p *prewarmer) Run(ctx context.Context) {
+	if p.timeTriggerController != nil {
+		go p.timeTriggerController.Run(ctx.Done())
+	}
+
+	ticker := time.NewTicker(prewarmPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, key := range p.dueFunctions(now) {
+				go p.warmFunction(key.namespace, key.name)
+			}
+			for _, tt := range p.dueTimeTriggers(now) {
+				for _, name := range p.triggerFunctions(tt) {
+					go p.warmFunction(tt.ObjectMeta.Namespace, name)
+				}
+			}
+		}
+	}
+}
+
+// observe records an invocation of function at t.
+func (p *prewarmer) observe(m *metav1.ObjectMeta, t time.Time) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := historyKey(m.Namespace, m.Name)
+	h, ok := p.history[key]
+	if !ok {
+		h = &invocationHistory{namespace: m.Namespace, name: m.Name}
+		p.history[key] = h
+	}
+	if t.Sub(h.lastSeen) > sessionGap {
+		h.sessions = append(h.sessions, t)
+		if len(h.sessions) > maxSessions {
+			h.sessions = h.sessions[len(h.sessions)-maxSessions:]
+		}
+	}
+	if t.After(h.lastSeen) {
+		h.lastSeen = t
+	}
+}
+
+// dueFunctions returns the functions whose next session is predicted to
+// start within the lead time.
+func (p *prewarmer) dueFunctions(now time.Time) []*invocationHistory {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var due []*invocationHistory
+	for key, h := range p.history {
+		if now.Sub(h.lastSeen) > historyExpiry {
+			delete(p.history, key)
+			delete(p.warmed, key)
+			continue
+		}
+		next, ok := h.predict()
+		if !ok || !p.isDue(key, next, now) {
+			continue
+		}
+		due = append(due, h)
+	}
+	return due
+}
+
+// dueTimeTriggers returns the time triggers firing within the lead time.
+func (p *prewarmer) dueTimeTriggers(now time.Time) []*fv1.TimeTrigger {
+	if p.timeTriggerStore == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var due []*fv1.TimeTrigger
+	for _, obj := range p.timeTriggerStore.List() {
+		tt := obj.(*fv1.TimeTrigger)
+		sched, err := cron.Parse(tt.Spec.Cron)
+		if err != nil {
+			continue
+		}
+		key := fmt.Sprintf("timetrigger:%v", crd.CacheKey(&tt.ObjectMeta))
+		if p.isDue(key, sched.Next(now), now) {
+			due = append(due, tt)
+		}
+	}
+	return due
+}
+
+// isDue returns true if next is within the lead time and wasn't warmed
+// for yet, it's called with p.mu held.
+func (p *prewarmer) isDue(key string, next time.Time, now time.Time) bool {
+	if next.Before(now) || next.Sub(now) > p.leadTime {
+		return false
+	}
+	if warmed, ok := p.warmed[key]; ok && warmed.Equal(next) {
+		return false
+	}
+	p.warmed[key] = next
+	return true
+}
+
+// triggerFunctions returns the names of the functions a time trigger invokes.
+func (p *prewarmer) triggerFunctions(tt *fv1.TimeTrigger) []string {
+	ref := tt.Spec.FunctionReference
+	switch ref.Type {
+	case fv1.FunctionReferenceTypeFunctionName:
+		return []string{ref.Name}
+	case fv1.FunctionReferenceTypeFunctionAlias:
+		alias, err := p.fissionClient.CoreV1().FunctionAliases(tt.ObjectMeta.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if err != nil {
+			p.logger.Error("error getting function alias of time trigger",
+				zap.Error(err),
+				zap.String("trigger", tt.ObjectMeta.Name),
+				zap.String("alias", ref.Name))
+			return nil
+		}
+		if len(alias.Spec.FunctionWeights) == 0 {
+			return []string{alias.Spec.FunctionName}
+		}
+		var names []string
+		for name, weight := range alias.Spec.FunctionWeights {
+			if weight > 0 {
+				names = append(names, name)
+			}
+		}
+		return names
+	default:
+		return nil
+	}
+}
+
+func (p *prewarmer) warmFunction(namespace string, name string) {
+	fn, err := p.fissionClient.CoreV1().Functions(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		p.logger.Error("error getting function to pre-warm",
+			zap.Error(err),
+			zap.String("function_name", name),
+			zap.String("function_namespace", namespace))
+		return
+	}
+
+	// every invocation of a job function runs a new job, there's nothing to warm up
+	if fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeJob {
+		return
+	}
+
+	p.logger.Info("pre-warming function",
+		zap.String("function_name", name),
+		zap.String("function_namespace", namespace))
+	err = p.warm(fn)
+	if err != nil {
+		p.logger.Error("error pre-warming function",
+			zap.Error(err),
+			zap.String("function_name", name),
+			zap.String("function_namespace", namespace))
+	}
+}
+
+// predict returns the start of the next session if the sessions so far
+// started at regular intervals.
+func (h *invocationHistory) predict() (time.Time, bool) {
+	if len(h.sessions) < minSessions {
+		return time.Time{}, false
+	}
+
+	n := len(h.sessions) - 1
+	mean := h.sessions[n].Sub(h.sessions[0]) / time.Duration(n)
+	for i := 1; i <= n; i++ {
+		interval := h.sessions[i].Sub(h.sessions[i-1])
+		if math.Abs(float64(interval-mean)) > maxIntervalDeviation*float64(mean) {
+			return time.Time{}, false
+		}
+	}
+	return h.sessions[n].Add(mean), true
+}
+
+func historyKey(namespace string, name string) string {
+	return fmt.Sprintf("%v/%v", namespace, name)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robfig/cron"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestPrewarmerPredict(t *testing.T) {
+	p := makePrewarmer(zap.NewNop(), nil, time.Minute, func(fn *fv1.Function) error { return nil })
+	m := &metav1.ObjectMeta{Name: "foo", Namespace: "default"}
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// a session every hour, with invocations a few seconds apart
+	for i := 0; i < 3; i++ {
+		session := start.Add(time.Duration(i) * time.Hour)
+		for j := 0; j < 5; j++ {
+			p.observe(m, session.Add(time.Duration(j)*10*time.Second))
+		}
+	}
+
+	h := p.history[historyKey(m.Namespace, m.Name)]
+	if len(h.sessions) != 3 {
+		t.Fatalf("expected 3 sessions, got %v", len(h.sessions))
+	}
+	next, ok := h.predict()
+	if !ok || !next.Equal(start.Add(3*time.Hour)) {
+		t.Fatalf("expected next session at %v, got %v (predicted %v)", start.Add(3*time.Hour), next, ok)
+	}
+
+	// due once within the lead time
+	if due := p.dueFunctions(next.Add(-2 * time.Minute)); len(due) != 0 {
+		t.Fatalf("expected no function due before the lead time, got %v", len(due))
+	}
+	if due := p.dueFunctions(next.Add(-30 * time.Second)); len(due) != 1 {
+		t.Fatalf("expected function due within the lead time, got %v", len(due))
+	}
+	if due := p.dueFunctions(next.Add(-15 * time.Second)); len(due) != 0 {
+		t.Fatalf("expected function to be warmed once, got %v", len(due))
+	}
+
+	// irregular sessions aren't predicted
+	p.observe(m, start.Add(3*time.Hour+20*time.Minute))
+	if _, ok := h.predict(); ok {
+		t.Fatal("expected no prediction for irregular sessions")
+	}
+}
+
+func TestPrewarmerCronDue(t *testing.T) {
+	p := makePrewarmer(zap.NewNop(), nil, time.Minute, func(fn *fv1.Function) error { return nil })
+
+	sched, err := cron.Parse("@every 5m")
+	if err != nil {
+		t.Fatalf("error parsing cron spec: %v", err)
+	}
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := sched.Next(now)
+
+	if p.isDue("timetrigger:foo", next, now) {
+		t.Fatal("expected time trigger not due 5 minutes before firing")
+	}
+	if !p.isDue("timetrigger:foo", next, next.Add(-time.Minute)) {
+		t.Fatal("expected time trigger due a minute before firing")
+	}
+	if p.isDue("timetrigger:foo", next, next.Add(-45*time.Second)) {
+		t.Fatal("expected time trigger to be warmed once per firing")
+	}
+}
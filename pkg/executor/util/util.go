@@ -21,6 +21,10 @@ import (
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/executor/fscache"
 )
 
 // ApplyImagePullSecret applies image pull secret to the give pod spec.
@@ -48,3 +52,29 @@ func WaitTimeout(wg *sync.WaitGroup, timeout time.Duration) {
 	case <-time.After(timeout):
 	}
 }
+
+// GetFunction returns the latest version of the function of a function
+// service from the informer store, or nil if the function was deleted.
+func GetFunction(store k8sCache.Store, fsvc *fscache.FuncSvc) *fv1.Function {
+	if store == nil {
+		return nil
+	}
+	obj, exists, err := store.GetByKey(fsvc.Function.Namespace + "/" + fsvc.Function.Name)
+	if err != nil || !exists {
+		return nil
+	}
+	fn, ok := obj.(*fv1.Function)
+	if !ok {
+		return nil
+	}
+	return fn
+}
+
+// IdleTimeout returns the time the resources of a function are kept after
+// its last request, the one set for function or the given default one.
+func IdleTimeout(fn *fv1.Function, defaultTimeout time.Duration) time.Duration {
+	if fn == nil || fn.Spec.InvokeStrategy.ExecutionStrategy.IdleTimeout <= 0 {
+		return defaultTimeout
+	}
+	return time.Duration(fn.Spec.InvokeStrategy.ExecutionStrategy.IdleTimeout) * time.Second
+}
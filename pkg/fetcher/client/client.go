@@ -48,9 +48,23 @@ func (c *Client) getUploadUrl() string {
 	return c.url + "/upload"
 }
 
-func (c *Client) Specialize(ctx context.Context, req *fetcher.FunctionSpecializeRequest) error {
-	_, err := sendRequest(c.logger, ctx, c.httpClient, req, c.getSpecializeUrl())
-	return err
+// Specialize asks fetcher to specialize the pod, and returns the time
+// spent on the steps of specialization.
+func (c *Client) Specialize(ctx context.Context, req *fetcher.FunctionSpecializeRequest) (*fetcher.FunctionSpecializeResponse, error) {
+	body, err := sendRequest(c.logger, ctx, c.httpClient, req, c.getSpecializeUrl())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := fetcher.FunctionSpecializeResponse{}
+	// older fetchers reply with an empty body
+	if len(bytes.TrimSpace(body)) > 0 {
+		err = json.Unmarshal(body, &resp)
+		if err != nil {
+			c.logger.Warn("error decoding specialize response", zap.Error(err))
+		}
+	}
+	return &resp, nil
 }
 
 func (c *Client) Fetch(ctx context.Context, fr *fetcher.FunctionFetchRequest) error {
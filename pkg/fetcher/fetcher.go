@@ -194,7 +194,7 @@ func (fetcher *Fetcher) SpecializeHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = fetcher.SpecializePod(r.Context(), req.FetchReq, req.LoadReq)
+	resp, err := fetcher.SpecializePod(r.Context(), req.FetchReq, req.LoadReq)
 	if err != nil {
 		fetcher.logger.Error("error specializing pod", zap.Error(err))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -202,7 +202,9 @@ func (fetcher *Fetcher) SpecializeHandler(w http.ResponseWriter, r *http.Request
 	}
 
 	// all done
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
 }
 
 // Fetch takes FetchRequest and makes the fetch call
@@ -568,7 +570,7 @@ func (fetcher *Fetcher) getPkgInformation(req FunctionFetchRequest) (pkg *fv1.Pa
 	return nil, err
 }
 
-func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetchRequest, loadReq FunctionLoadRequest) error {
+func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetchRequest, loadReq FunctionLoadRequest) (*FunctionSpecializeResponse, error) {
 	startTime := time.Now()
 	defer func() {
 		elapsed := time.Since(startTime)
@@ -577,18 +579,23 @@ func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetc
 
 	pkg, err := fetcher.getPkgInformation(fetchReq)
 	if err != nil {
-		return errors.Wrap(err, "error getting package information")
+		return nil, errors.Wrap(err, "error getting package information")
 	}
 
 	_, err = fetcher.Fetch(ctx, pkg, fetchReq)
 	if err != nil {
-		return errors.Wrap(err, "error fetching deploy package")
+		return nil, errors.Wrap(err, "error fetching deploy package")
 	}
 
 	_, err = fetcher.FetchSecretsAndCfgMaps(fetchReq.Secrets, fetchReq.ConfigMaps)
 	if err != nil {
-		return errors.Wrap(err, "error fetching secrets/configs")
+		return nil, errors.Wrap(err, "error fetching secrets/configs")
+	}
+
+	resp := &FunctionSpecializeResponse{
+		FetchDuration: time.Since(startTime),
 	}
+	loadStartTime := time.Now()
 
 	// Specialize the pod
 
@@ -599,7 +606,7 @@ func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetc
 
 	loadPayload, err := json.Marshal(loadReq)
 	if err != nil {
-		return errors.Wrap(err, "error encoding load request")
+		return nil, errors.Wrap(err, "error encoding load request")
 	}
 
 	// Instead of using "localhost", here we use "127.0.0.1" for
@@ -618,11 +625,12 @@ func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetc
 	}
 
 	for i := 0; i < maxRetries; i++ {
-		resp, err := http.Post(specializeURL, contentType, reader)
-		if err == nil && resp.StatusCode < 300 {
+		specializeResp, err := http.Post(specializeURL, contentType, reader)
+		if err == nil && specializeResp.StatusCode < 300 {
 			// Success
-			resp.Body.Close()
-			return nil
+			specializeResp.Body.Close()
+			resp.LoadDuration = time.Since(loadStartTime)
+			return resp, nil
 		}
 
 		netErr := network.Adapter(err)
@@ -637,11 +645,11 @@ func (fetcher *Fetcher) SpecializePod(ctx context.Context, fetchReq FunctionFetc
 
 		// for 4xx, 5xx
 		if err == nil {
-			err = ferror.MakeErrorFromHTTP(resp)
+			err = ferror.MakeErrorFromHTTP(specializeResp)
 		}
 
-		return errors.Wrap(err, "error specializing function pod")
+		return nil, errors.Wrap(err, "error specializing function pod")
 	}
 
-	return errors.Wrapf(err, "error specializing function pod after %v times", maxRetries)
+	return nil, errors.Wrapf(err, "error specializing function pod after %v times", maxRetries)
 }
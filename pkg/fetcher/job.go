@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// The job executor can't tell the stdout of function from its stderr in the
+// logs of pod, so the fetcher binary is copied into the job pod to run the
+// function command with its stdout kept in a file of the job directory, and
+// to print the file once the function exits. The output printed is the
+// response of invocation, and the exit code of function is the exit code of
+// the output container.
+const (
+	// JobRunCommand runs the function command: fetcher jobrun <job dir> <command> [args...]
+	JobRunCommand = "jobrun"
+	// JobOutputCommand prints the function stdout: fetcher joboutput <job dir>
+	JobOutputCommand = "joboutput"
+
+	jobStdoutFile   = "stdout"
+	jobExitCodeFile = "exitcode"
+)
+
+// RunJob runs the function command of a job with the arguments of jobrun,
+// and returns the exit code of fetcher. Its stdout goes to both the container
+// log and the job directory, its stderr only to the container log.
+func RunJob(args []string) int {
+	if len(args) < 2 {
+		fmt.Fprintf(os.Stderr, "usage: fetcher %v <job dir> <command> [args...]\n", JobRunCommand)
+		return 2
+	}
+	dir := args[0]
+
+	stdout, err := os.Create(filepath.Join(dir, jobStdoutFile))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error creating stdout file of function: %v\n", err)
+		return 1
+	}
+	defer stdout.Close()
+
+	cmd := exec.Command(args[1], args[2:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = io.MultiWriter(os.Stdout, stdout)
+	cmd.Stderr = os.Stderr
+
+	exitCode := 0
+	if err := cmd.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "error running function command: %v\n", err)
+		exitCode = 127
+	} else {
+		// pass the termination at the job deadline on to the function
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGTERM, syscall.SIGINT)
+		go func() {
+			for sig := range sigs {
+				cmd.Process.Signal(sig)
+			}
+		}()
+
+		err = cmd.Wait()
+		signal.Stop(sigs)
+		close(sigs)
+		if err != nil {
+			exitErr, ok := err.(*exec.ExitError)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "error waiting for function command: %v\n", err)
+				return 1
+			}
+			exitCode = exitErr.ExitCode()
+		}
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, jobExitCodeFile), []byte(strconv.Itoa(exitCode)), 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error writing exit code of function: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// JobOutput prints the stdout the function of job wrote with jobrun,
+// and returns the exit code of function.
+func JobOutput(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "usage: fetcher %v <job dir>\n", JobOutputCommand)
+		return 2
+	}
+	dir := args[0]
+
+	code, err := ioutil.ReadFile(filepath.Join(dir, jobExitCodeFile))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading exit code of function: %v\n", err)
+		return 1
+	}
+	exitCode, err := strconv.Atoi(strings.TrimSpace(string(code)))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing exit code of function: %v\n", err)
+		return 1
+	}
+
+	stdout, err := os.Open(filepath.Join(dir, jobStdoutFile))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error opening stdout file of function: %v\n", err)
+		return 1
+	}
+	defer stdout.Close()
+
+	_, err = io.Copy(os.Stdout, stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error printing stdout of function: %v\n", err)
+		return 1
+	}
+	return exitCode
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetcher
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunJob(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fission-job")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	code := RunJob([]string{dir, "sh", "-c", "echo report; echo warning >&2; exit 3"})
+	if code != 0 {
+		t.Fatalf("expected jobrun to exit 0 once the function exited, got %v", code)
+	}
+
+	stdout, err := ioutil.ReadFile(filepath.Join(dir, jobStdoutFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(stdout) != "report\n" {
+		t.Fatalf("expected only the stdout of function to be kept, got %q", stdout)
+	}
+	if code := JobOutput([]string{dir}); code != 3 {
+		t.Fatalf("expected joboutput to exit with the exit code of function, got %v", code)
+	}
+
+	if code := RunJob([]string{dir, filepath.Join(dir, "missing")}); code != 0 {
+		t.Fatalf("expected jobrun to exit 0 for a missing command, got %v", code)
+	}
+	if code := JobOutput([]string{dir}); code != 127 {
+		t.Fatalf("expected exit code 127 for a missing command, got %v", code)
+	}
+}
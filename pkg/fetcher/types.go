@@ -17,6 +17,8 @@ limitations under the License.
 package fetcher
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
@@ -35,6 +37,15 @@ type (
 		LoadReq  FunctionLoadRequest
 	}
 
+	// FunctionSpecializeResponse is the time spent on the steps of
+	// specialization, older fetchers reply with an empty body.
+	FunctionSpecializeResponse struct {
+		// FetchDuration is the time to download the package, secrets and configmaps.
+		FetchDuration time.Duration `json:"fetchDuration"`
+		// LoadDuration is the time the environment took to load the function.
+		LoadDuration time.Duration `json:"loadDuration"`
+	}
+
 	FunctionFetchRequest struct {
 		FetchType     FetchRequestType         `json:"fetchType"`
 		Package       metav1.ObjectMeta        `json:"package"`
@@ -34,7 +34,9 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{
 			flag.FnEnvName, flag.FnEntryPoint, flag.FnPkgName,
 			flag.FnExecutorType, flag.FnCfgMap, flag.FnSecret,
-			flag.FnSpecializationTimeout, flag.FnExecutionTimeout,
+			flag.FnSpecializationTimeout, flag.FnExecutionTimeout, flag.FnConcurrency,
+			flag.FnIdleTimeout, flag.FnNeverScaleToZero,
+			flag.FnImage, flag.FnPort, flag.FnImagePullSecret, flag.FnCommand,
 
 			// TODO retired pkg & trigger related flags from function cmd
 			flag.PkgCode, flag.PkgSrcArchive, flag.PkgDeployArchive,
@@ -47,6 +49,8 @@ func Commands() *cobra.Command {
 			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
 			flag.RunTimeMaxMemory, flag.ReplicasMin,
 			flag.ReplicasMax, flag.RunTimeTargetCPU,
+			flag.RunTimeTargetConcurrency, flag.RunTimeTargetRPS, flag.RunTimeScaleMetric,
+			flag.RunTimeScaleMetricType, flag.RunTimeScaleMetricTarget,
 
 			flag.NamespaceFunction, flag.NamespaceEnvironment, flag.SpecSave, flag.SpecDry},
 	})
@@ -84,7 +88,9 @@ func Commands() *cobra.Command {
 		Optional: []flag.Flag{
 			flag.FnEnvName, flag.FnEntryPoint, flag.FnPkgName,
 			flag.FnExecutorType, flag.FnSecret, flag.FnCfgMap,
-			flag.FnSpecializationTimeout, flag.FnExecutionTimeout,
+			flag.FnSpecializationTimeout, flag.FnExecutionTimeout, flag.FnConcurrency,
+			flag.FnIdleTimeout, flag.FnNeverScaleToZero,
+			flag.FnImage, flag.FnPort, flag.FnImagePullSecret, flag.FnCommand,
 
 			flag.PkgCode, flag.PkgSrcArchive, flag.PkgDeployArchive,
 			flag.PkgSrcChecksum, flag.PkgDeployChecksum, flag.PkgInsecure,
@@ -92,7 +98,8 @@ func Commands() *cobra.Command {
 
 			flag.RunTimeMinCPU, flag.RunTimeMaxCPU, flag.RunTimeMinMemory,
 			flag.RunTimeMaxMemory, flag.ReplicasMin, flag.ReplicasMax,
-			flag.RunTimeTargetCPU,
+			flag.RunTimeTargetCPU, flag.RunTimeTargetConcurrency, flag.RunTimeTargetRPS,
+			flag.RunTimeScaleMetric, flag.RunTimeScaleMetricType, flag.RunTimeScaleMetricTarget,
 
 			flag.NamespaceFunction, flag.NamespaceEnvironment, flag.SpecSave,
 		},
@@ -133,6 +140,18 @@ func Commands() *cobra.Command {
 			flag.FnLogDetail, flag.FnLogPod, flag.NamespaceFunction, flag.FnLogDBType},
 	})
 
+	jobsCmd := &cobra.Command{
+		Use:     "jobs",
+		Aliases: []string{},
+		Short:   "List the job history of function",
+		Long:    "List the jobs run for the invocations of a function with executor type \"job\", the latest first",
+		RunE:    wrapper.Wrapper(Jobs),
+	}
+	wrapper.SetFlags(jobsCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.FnName},
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
 	testCmd := &cobra.Command{
 		Use:     "test",
 		Aliases: []string{},
@@ -155,7 +174,7 @@ func Commands() *cobra.Command {
 		Short:   "Create, update and manage functions",
 	}
 
-	command.AddCommand(createCmd, getCmd, getmetaCmd, updateCmd, deleteCmd, listCmd, logsCmd, testCmd)
+	command.AddCommand(createCmd, getCmd, getmetaCmd, updateCmd, deleteCmd, listCmd, logsCmd, jobsCmd, testCmd)
 
 	return command
 }
@@ -110,8 +110,15 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 
 	var pkgMetadata *metav1.ObjectMeta
 	var envName string
+	var container *fv1.FunctionContainer
 
-	if len(pkgName) > 0 {
+	if executorType := invokeStrategy.ExecutionStrategy.ExecutorType; isContainerExecutor(executorType) {
+		// the container image is run as-is, there is no environment or package
+		container, err = getContainer(input, nil, executorType)
+		if err != nil {
+			return err
+		}
+	} else if len(pkgName) > 0 {
 		var pkg *fv1.Package
 
 		if toSpec {
@@ -274,26 +281,30 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 			Namespace: fnNamespace,
 		},
 		Spec: fv1.FunctionSpec{
-			Environment: fv1.EnvironmentReference{
-				Name:      envName,
-				Namespace: envNamespace,
-			},
-			Package: fv1.FunctionPackageRef{
-				FunctionName: entrypoint,
-				PackageRef: fv1.PackageRef{
-					Namespace:       pkgMetadata.Namespace,
-					Name:            pkgMetadata.Name,
-					ResourceVersion: pkgMetadata.ResourceVersion,
-				},
-			},
 			Secrets:         secrets,
 			ConfigMaps:      cfgmaps,
 			Resources:       *resourceReq,
 			InvokeStrategy:  *invokeStrategy,
 			FunctionTimeout: fnTimeout,
+			Container:       container,
 		},
 	}
 
+	if pkgMetadata != nil {
+		opts.function.Spec.Environment = fv1.EnvironmentReference{
+			Name:      envName,
+			Namespace: envNamespace,
+		}
+		opts.function.Spec.Package = fv1.FunctionPackageRef{
+			FunctionName: entrypoint,
+			PackageRef: fv1.PackageRef{
+				Namespace:       pkgMetadata.Namespace,
+				Name:            pkgMetadata.Name,
+				ResourceVersion: pkgMetadata.ResourceVersion,
+			},
+		}
+	}
+
 	return nil
 }
 
@@ -388,8 +399,16 @@ func getExecutionStrategy(input cli.Input) (strategy *fv1.ExecutionStrategy, err
 		fnExecutor = fv1.ExecutorTypePoolmgr
 	case string(fv1.ExecutorTypeNewdeploy):
 		fnExecutor = fv1.ExecutorTypeNewdeploy
+	case string(fv1.ExecutorTypeContainer):
+		fnExecutor = fv1.ExecutorTypeContainer
+	case string(fv1.ExecutorTypeJob):
+		fnExecutor = fv1.ExecutorTypeJob
 	default:
-		return nil, errors.Errorf("executor type must be one of '%v' or '%v'", fv1.ExecutorTypePoolmgr, fv1.ExecutorTypeNewdeploy)
+		return nil, errors.Errorf("executor type must be one of '%v', '%v', '%v' or '%v'", fv1.ExecutorTypePoolmgr, fv1.ExecutorTypeNewdeploy, fv1.ExecutorTypeContainer, fv1.ExecutorTypeJob)
+	}
+
+	if !isContainerExecutor(fnExecutor) && isContainerSet(input) {
+		return nil, errors.Errorf("--%v, --%v, --%v and --%v are only supported by executor types \"container\" and \"job\"", flagkey.FnImage, flagkey.FnPort, flagkey.FnImagePullSecret, flagkey.FnCommand)
 	}
 
 	specializationTimeout := fv1.DefaultSpecializationTimeOut
@@ -401,20 +420,46 @@ func getExecutionStrategy(input cli.Input) (strategy *fv1.ExecutionStrategy, err
 		}
 	}
 
+	idleTimeout, err := getIdleTimeout(input, 0)
+	if err != nil {
+		return nil, err
+	}
+
 	if fnExecutor == fv1.ExecutorTypePoolmgr {
-		if input.IsSet(flagkey.RuntimeTargetcpu) || input.IsSet(flagkey.ReplicasMinscale) || input.IsSet(flagkey.ReplicasMaxscale) {
-			return nil, errors.New("to set target CPU or min/max scale for function, please specify \"--executortype newdeploy\"")
+		if input.IsSet(flagkey.RuntimeTargetcpu) || input.IsSet(flagkey.ReplicasMinscale) || input.IsSet(flagkey.ReplicasMaxscale) || isScalingTargetSet(input) {
+			return nil, errors.New("to set scaling targets or min/max scale for function, please specify \"--executortype newdeploy\"")
 		}
 
 		if input.IsSet(flagkey.RuntimeMincpu) || input.IsSet(flagkey.RuntimeMaxcpu) || input.IsSet(flagkey.RuntimeMinmemory) || input.IsSet(flagkey.RuntimeMaxmemory) {
 			console.Warn("To limit CPU/Memory for function with executor type \"poolmgr\", please specify resources limits when creating environment")
 		}
 
+		concurrency, err := getConcurrency(input, 0)
+		if err != nil {
+			return nil, err
+		}
+
 		strategy = &fv1.ExecutionStrategy{
-			ExecutorType:          fv1.ExecutorTypePoolmgr,
+			ExecutorType:           fv1.ExecutorTypePoolmgr,
+			SpecializationTimeout:  specializationTimeout,
+			ConcurrencyPerInstance: concurrency,
+			IdleTimeout:            idleTimeout,
+			NeverScaleToZero:       input.Bool(flagkey.FnNeverScaleToZero),
+		}
+	} else if fnExecutor == fv1.ExecutorTypeJob {
+		if isScalingSet(input) {
+			return nil, errors.New("executor type \"job\" runs a job for every invocation, it has no scaling options")
+		}
+
+		strategy = &fv1.ExecutionStrategy{
+			ExecutorType:          fv1.ExecutorTypeJob,
 			SpecializationTimeout: specializationTimeout,
 		}
 	} else {
+		if input.IsSet(flagkey.FnConcurrency) || input.IsSet(flagkey.FnNeverScaleToZero) {
+			return nil, errors.Errorf("--%v and --%v are only supported by executor type \"poolmgr\"", flagkey.FnConcurrency, flagkey.FnNeverScaleToZero)
+		}
+
 		targetCPU := DEFAULT_TARGET_CPU_PERCENTAGE
 		if input.IsSet(flagkey.RuntimeTargetcpu) {
 			targetCPU, err = getTargetCPU(input)
@@ -448,6 +493,11 @@ func getExecutionStrategy(input cli.Input) (strategy *fv1.ExecutionStrategy, err
 			MaxScale:              maxScale,
 			TargetCPUPercent:      targetCPU,
 			SpecializationTimeout: specializationTimeout,
+			IdleTimeout:           idleTimeout,
+		}
+		err = setScalingTargets(input, strategy)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -466,11 +516,19 @@ func updateExecutionStrategy(input cli.Input, existingExecutionStrategy *fv1.Exe
 			fnExecutor = fv1.ExecutorTypePoolmgr
 		case string(fv1.ExecutorTypeNewdeploy):
 			fnExecutor = fv1.ExecutorTypeNewdeploy
+		case string(fv1.ExecutorTypeContainer):
+			fnExecutor = fv1.ExecutorTypeContainer
+		case string(fv1.ExecutorTypeJob):
+			fnExecutor = fv1.ExecutorTypeJob
 		default:
-			return nil, errors.Errorf("executor type must be one of '%v' or '%v'", fv1.ExecutorTypePoolmgr, fv1.ExecutorTypeNewdeploy)
+			return nil, errors.Errorf("executor type must be one of '%v', '%v', '%v' or '%v'", fv1.ExecutorTypePoolmgr, fv1.ExecutorTypeNewdeploy, fv1.ExecutorTypeContainer, fv1.ExecutorTypeJob)
 		}
 	}
 
+	if !isContainerExecutor(fnExecutor) && isContainerSet(input) {
+		return nil, errors.Errorf("--%v, --%v, --%v and --%v are only supported by executor types \"container\" and \"job\"", flagkey.FnImage, flagkey.FnPort, flagkey.FnImagePullSecret, flagkey.FnCommand)
+	}
+
 	specializationTimeout := existingExecutionStrategy.SpecializationTimeout
 
 	if input.IsSet(flagkey.FnSpecializationTimeout) {
@@ -484,19 +542,51 @@ func updateExecutionStrategy(input cli.Input, existingExecutionStrategy *fv1.Exe
 		}
 	}
 
+	idleTimeout, err := getIdleTimeout(input, existingExecutionStrategy.IdleTimeout)
+	if err != nil {
+		return nil, err
+	}
+
 	if fnExecutor == fv1.ExecutorTypePoolmgr {
-		if input.IsSet(flagkey.RuntimeTargetcpu) || input.IsSet(flagkey.ReplicasMinscale) || input.IsSet(flagkey.ReplicasMaxscale) {
-			return nil, errors.New("to set target CPU or min/max scale for function, please specify \"--executortype newdeploy\"")
+		if input.IsSet(flagkey.RuntimeTargetcpu) || input.IsSet(flagkey.ReplicasMinscale) || input.IsSet(flagkey.ReplicasMaxscale) || isScalingTargetSet(input) {
+			return nil, errors.New("to set scaling targets or min/max scale for function, please specify \"--executortype newdeploy\"")
 		}
 
 		if input.IsSet(flagkey.RuntimeMincpu) || input.IsSet(flagkey.RuntimeMaxcpu) || input.IsSet(flagkey.RuntimeMinmemory) || input.IsSet(flagkey.RuntimeMaxmemory) {
 			console.Warn("To limit CPU/Memory for function with executor type \"poolmgr\", please specify resources limits when creating environment")
 		}
+
+		concurrency, err := getConcurrency(input, existingExecutionStrategy.ConcurrencyPerInstance)
+		if err != nil {
+			return nil, err
+		}
+
+		neverScaleToZero := existingExecutionStrategy.NeverScaleToZero
+		if input.IsSet(flagkey.FnNeverScaleToZero) {
+			neverScaleToZero = input.Bool(flagkey.FnNeverScaleToZero)
+		}
+
+		strategy = &fv1.ExecutionStrategy{
+			ExecutorType:           fv1.ExecutorTypePoolmgr,
+			SpecializationTimeout:  specializationTimeout,
+			ConcurrencyPerInstance: concurrency,
+			IdleTimeout:            idleTimeout,
+			NeverScaleToZero:       neverScaleToZero,
+		}
+	} else if fnExecutor == fv1.ExecutorTypeJob {
+		if isScalingSet(input) {
+			return nil, errors.New("executor type \"job\" runs a job for every invocation, it has no scaling options")
+		}
+
 		strategy = &fv1.ExecutionStrategy{
-			ExecutorType:          fv1.ExecutorTypePoolmgr,
+			ExecutorType:          fv1.ExecutorTypeJob,
 			SpecializationTimeout: specializationTimeout,
 		}
 	} else {
+		if input.IsSet(flagkey.FnConcurrency) || input.IsSet(flagkey.FnNeverScaleToZero) {
+			return nil, errors.Errorf("--%v and --%v are only supported by executor type \"poolmgr\"", flagkey.FnConcurrency, flagkey.FnNeverScaleToZero)
+		}
+
 		targetCPU := existingExecutionStrategy.TargetCPUPercent
 		minScale := existingExecutionStrategy.MinScale
 		maxScale := existingExecutionStrategy.MaxScale
@@ -545,12 +635,142 @@ func updateExecutionStrategy(input cli.Input, existingExecutionStrategy *fv1.Exe
 			MaxScale:              maxScale,
 			TargetCPUPercent:      targetCPU,
 			SpecializationTimeout: specializationTimeout,
+			IdleTimeout:           idleTimeout,
+		}
+		if fnExecutor == oldExecutor {
+			strategy.TargetConcurrency = existingExecutionStrategy.TargetConcurrency
+			strategy.TargetRequestsPerSecond = existingExecutionStrategy.TargetRequestsPerSecond
+			strategy.ScaleMetricName = existingExecutionStrategy.ScaleMetricName
+			strategy.ScaleMetricType = existingExecutionStrategy.ScaleMetricType
+			strategy.ScaleMetricTarget = existingExecutionStrategy.ScaleMetricTarget
+		}
+		err = setScalingTargets(input, strategy)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	return strategy, nil
 }
 
+func isContainerSet(input cli.Input) bool {
+	return input.IsSet(flagkey.FnImage) || input.IsSet(flagkey.FnPort) || input.IsSet(flagkey.FnImagePullSecret) ||
+		input.IsSet(flagkey.FnCommand)
+}
+
+// isContainerExecutor returns true if the executor type runs the
+// container image of function in place of an environment and package.
+func isContainerExecutor(executorType fv1.ExecutorType) bool {
+	return executorType == fv1.ExecutorTypeContainer || executorType == fv1.ExecutorTypeJob
+}
+
+// isScalingSet returns true if any flag scaling the pods of function is set.
+func isScalingSet(input cli.Input) bool {
+	return input.IsSet(flagkey.RuntimeTargetcpu) || input.IsSet(flagkey.ReplicasMinscale) ||
+		input.IsSet(flagkey.ReplicasMaxscale) || isScalingTargetSet(input) ||
+		input.IsSet(flagkey.FnConcurrency) || input.IsSet(flagkey.FnNeverScaleToZero) ||
+		input.IsSet(flagkey.FnIdleTimeout)
+}
+
+// getContainer returns the container of function given by flags on top of
+// the existing one, if any. The port is only required by the container
+// executor, the job executor runs the container to completion instead.
+func getContainer(input cli.Input, existing *fv1.FunctionContainer, executorType fv1.ExecutorType) (*fv1.FunctionContainer, error) {
+	container := &fv1.FunctionContainer{}
+	if existing != nil {
+		container = existing.DeepCopy()
+	}
+
+	if input.IsSet(flagkey.FnImage) {
+		container.Image = input.String(flagkey.FnImage)
+	}
+	if input.IsSet(flagkey.FnPort) {
+		container.Port = int32(input.Int(flagkey.FnPort))
+	}
+	if input.IsSet(flagkey.FnImagePullSecret) {
+		container.ImagePullSecret = input.String(flagkey.FnImagePullSecret)
+	}
+	if input.IsSet(flagkey.FnCommand) {
+		container.Command = input.StringSlice(flagkey.FnCommand)
+		container.Args = nil
+	}
+
+	if len(container.Image) == 0 {
+		return nil, errors.Errorf("need --%v argument for executor type \"%v\"", flagkey.FnImage, executorType)
+	}
+	if executorType == fv1.ExecutorTypeJob {
+		if input.IsSet(flagkey.FnPort) {
+			return nil, errors.Errorf("--%v is not supported by executor type \"job\"", flagkey.FnPort)
+		}
+		if len(container.Command) == 0 {
+			return nil, errors.Errorf("need --%v argument for executor type \"job\", its stdout is the response", flagkey.FnCommand)
+		}
+		return container, nil
+	}
+	if container.Port <= 0 || container.Port > 65535 {
+		return nil, errors.Errorf("--%v must be a value between 1 - 65535", flagkey.FnPort)
+	}
+	return container, nil
+}
+
+func isScalingTargetSet(input cli.Input) bool {
+	return input.IsSet(flagkey.RuntimeTargetConcurrency) || input.IsSet(flagkey.RuntimeTargetRPS) ||
+		input.IsSet(flagkey.RuntimeScaleMetric) || input.IsSet(flagkey.RuntimeScaleMetricType) ||
+		input.IsSet(flagkey.RuntimeScaleMetricTarget)
+}
+
+// setScalingTargets sets the scaling targets of newdeploy given by flags on strategy.
+func setScalingTargets(input cli.Input, strategy *fv1.ExecutionStrategy) error {
+	if input.IsSet(flagkey.RuntimeTargetConcurrency) {
+		strategy.TargetConcurrency = input.Int(flagkey.RuntimeTargetConcurrency)
+		if strategy.TargetConcurrency < 0 {
+			return errors.Errorf("--%v must be greater than or equal to 0", flagkey.RuntimeTargetConcurrency)
+		}
+	}
+	if input.IsSet(flagkey.RuntimeTargetRPS) {
+		strategy.TargetRequestsPerSecond = input.Int(flagkey.RuntimeTargetRPS)
+		if strategy.TargetRequestsPerSecond < 0 {
+			return errors.Errorf("--%v must be greater than or equal to 0", flagkey.RuntimeTargetRPS)
+		}
+	}
+	if input.IsSet(flagkey.RuntimeScaleMetric) {
+		strategy.ScaleMetricName = input.String(flagkey.RuntimeScaleMetric)
+	}
+	if input.IsSet(flagkey.RuntimeScaleMetricType) {
+		strategy.ScaleMetricType = fv1.ScaleMetricType(input.String(flagkey.RuntimeScaleMetricType))
+	}
+	if input.IsSet(flagkey.RuntimeScaleMetricTarget) {
+		strategy.ScaleMetricTarget = input.String(flagkey.RuntimeScaleMetricTarget)
+	}
+	return nil
+}
+
+// getConcurrency returns the concurrency per instance set by flag, or the
+// given default one if the flag isn't set.
+func getConcurrency(input cli.Input, defaultConcurrency int) (int, error) {
+	if !input.IsSet(flagkey.FnConcurrency) {
+		return defaultConcurrency, nil
+	}
+	concurrency := input.Int(flagkey.FnConcurrency)
+	if concurrency < 0 {
+		return 0, errors.Errorf("--%v must be greater than or equal to 0", flagkey.FnConcurrency)
+	}
+	return concurrency, nil
+}
+
+// getIdleTimeout returns the idle timeout set by flag, or the given
+// default one if the flag isn't set.
+func getIdleTimeout(input cli.Input, defaultTimeout int) (int, error) {
+	if !input.IsSet(flagkey.FnIdleTimeout) {
+		return defaultTimeout, nil
+	}
+	idleTimeout := input.Int(flagkey.FnIdleTimeout)
+	if idleTimeout < 0 {
+		return 0, errors.Errorf("--%v must be greater than or equal to 0", flagkey.FnIdleTimeout)
+	}
+	return idleTimeout, nil
+}
+
 func getTargetCPU(input cli.Input) (int, error) {
 	targetCPU := input.Int(flagkey.RuntimeTargetcpu)
 	if targetCPU <= 0 || targetCPU > 100 {
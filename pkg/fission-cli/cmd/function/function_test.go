@@ -347,6 +347,49 @@ func TestGetInvokeStrategy(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name:                   "executor type set to container",
+			testArgs:               map[string]interface{}{flagkey.FnExecutorType: string(fv1.ExecutorTypeContainer), flagkey.FnImage: "nginx:1.17"},
+			existingInvokeStrategy: nil,
+			expectedResult: &fv1.InvokeStrategy{
+				StrategyType: fv1.StrategyTypeExecution,
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType:          fv1.ExecutorTypeContainer,
+					MinScale:              DEFAULT_MIN_SCALE,
+					MaxScale:              DEFAULT_MIN_SCALE,
+					TargetCPUPercent:      DEFAULT_TARGET_CPU_PERCENTAGE,
+					SpecializationTimeout: fv1.DefaultSpecializationTimeOut,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:                   "executor type set to job",
+			testArgs:               map[string]interface{}{flagkey.FnExecutorType: string(fv1.ExecutorTypeJob), flagkey.FnImage: "example/report:1.0"},
+			existingInvokeStrategy: nil,
+			expectedResult: &fv1.InvokeStrategy{
+				StrategyType: fv1.StrategyTypeExecution,
+				ExecutionStrategy: fv1.ExecutionStrategy{
+					ExecutorType:          fv1.ExecutorTypeJob,
+					SpecializationTimeout: fv1.DefaultSpecializationTimeOut,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:                   "job has no scaling options",
+			testArgs:               map[string]interface{}{flagkey.FnExecutorType: string(fv1.ExecutorTypeJob), flagkey.ReplicasMinscale: 1},
+			existingInvokeStrategy: nil,
+			expectedResult:         nil,
+			expectError:            true,
+		},
+		{
+			name:                   "image is only supported by container",
+			testArgs:               map[string]interface{}{flagkey.FnExecutorType: string(fv1.ExecutorTypeNewdeploy), flagkey.FnImage: "nginx:1.17"},
+			existingInvokeStrategy: nil,
+			expectedResult:         nil,
+			expectError:            true,
+		},
 		{
 			name: "specializationtimeout should not be less than 120",
 			testArgs: map[string]interface{}{
@@ -383,3 +426,77 @@ func TestGetInvokeStrategy(t *testing.T) {
 		})
 	}
 }
+
+func TestGetContainer(t *testing.T) {
+	existing := &fv1.FunctionContainer{Image: "nginx:1.17", Port: 80}
+	cases := []struct {
+		name           string
+		testArgs       map[string]interface{}
+		existing       *fv1.FunctionContainer
+		executorType   fv1.ExecutorType
+		expectedResult *fv1.FunctionContainer
+		expectError    bool
+	}{
+		{
+			name:           "image and port",
+			testArgs:       map[string]interface{}{flagkey.FnImage: "nginx:1.17", flagkey.FnPort: 8080},
+			expectedResult: &fv1.FunctionContainer{Image: "nginx:1.17", Port: 8080},
+		},
+		{
+			name:        "image is required",
+			testArgs:    map[string]interface{}{flagkey.FnPort: 8080},
+			expectError: true,
+		},
+		{
+			name:        "port is required",
+			testArgs:    map[string]interface{}{flagkey.FnImage: "nginx:1.17"},
+			expectError: true,
+		},
+		{
+			name:           "update image of existing container",
+			testArgs:       map[string]interface{}{flagkey.FnImage: "nginx:1.18"},
+			existing:       existing,
+			expectedResult: &fv1.FunctionContainer{Image: "nginx:1.18", Port: 80},
+		},
+		{
+			name:           "job without port",
+			testArgs:       map[string]interface{}{flagkey.FnImage: "example/report:1.0", flagkey.FnCommand: []string{"/report", "--daily"}},
+			executorType:   fv1.ExecutorTypeJob,
+			expectedResult: &fv1.FunctionContainer{Image: "example/report:1.0", Command: []string{"/report", "--daily"}},
+		},
+		{
+			name:         "job with port",
+			testArgs:     map[string]interface{}{flagkey.FnImage: "example/report:1.0", flagkey.FnCommand: []string{"/report"}, flagkey.FnPort: 8080},
+			executorType: fv1.ExecutorTypeJob,
+			expectError:  true,
+		},
+		{
+			name:         "command is required by job",
+			testArgs:     map[string]interface{}{flagkey.FnImage: "example/report:1.0"},
+			executorType: fv1.ExecutorTypeJob,
+			expectError:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			flags := dummy.TestFlagSet()
+			for k, v := range c.testArgs {
+				flags.Set(k, v)
+			}
+
+			executorType := c.executorType
+			if len(executorType) == 0 {
+				executorType = fv1.ExecutorTypeContainer
+			}
+			container, err := getContainer(flags, c.existing, executorType)
+			if c.expectError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, c.expectedResult, container)
+			}
+		})
+	}
+	assert.Equal(t, "nginx:1.17", existing.Image, "existing container should not be modified")
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package function
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+type JobsSubCommand struct {
+	cmd.CommandActioner
+}
+
+func Jobs(input cli.Input) error {
+	return (&JobsSubCommand{}).do(input)
+}
+
+func (opts *JobsSubCommand) do(input cli.Input) error {
+	m := &metav1.ObjectMeta{
+		Name:      input.String(flagkey.FnName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	}
+
+	jobs, err := opts.Client().V1().Function().ListJobs(m)
+	if err != nil {
+		return errors.Wrap(err, "error listing jobs of function")
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+
+	fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", "NAME", "STATUS", "STARTED", "DURATION", "EXITCODE", "REASON")
+	for _, job := range jobs {
+		var started, duration string
+		if job.Status.StartTime != nil {
+			started = job.Status.StartTime.Format(time.RFC3339)
+			end := time.Now()
+			if job.Status.CompletionTime != nil {
+				end = job.Status.CompletionTime.Time
+			} else if c := jobFinishedCondition(&job); c != nil {
+				end = c.LastTransitionTime.Time
+			}
+			duration = end.Sub(job.Status.StartTime.Time).Round(time.Second).String()
+		}
+
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n",
+			job.ObjectMeta.Name, jobStatus(&job), started, duration,
+			job.ObjectMeta.Annotations[fv1.ANNOTATION_JOB_EXIT_CODE],
+			job.ObjectMeta.Annotations[fv1.ANNOTATION_JOB_REASON])
+	}
+	w.Flush()
+
+	return nil
+}
+
+// jobFinishedCondition returns the condition job finished with, or nil if it's still running.
+func jobFinishedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for i, c := range job.Status.Conditions {
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == apiv1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func jobStatus(job *batchv1.Job) string {
+	c := jobFinishedCondition(job)
+	switch {
+	case c == nil:
+		return "running"
+	case c.Type == batchv1.JobComplete:
+		return "succeeded"
+	default:
+		return "failed"
+	}
+}
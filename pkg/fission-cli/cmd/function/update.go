@@ -167,6 +167,20 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 
 	function.Spec.Resources = *resReqs
 
+	if executorType := strategy.ExecutionStrategy.ExecutorType; isContainerExecutor(executorType) {
+		container, err := getContainer(input, function.Spec.Container, executorType)
+		if err != nil {
+			return err
+		}
+		// the container image is run as-is, there is no environment or package
+		function.Spec.Container = container
+		function.Spec.Environment = fv1.EnvironmentReference{}
+		function.Spec.Package = fv1.FunctionPackageRef{}
+		opts.function = function
+		return nil
+	}
+	function.Spec.Container = nil
+
 	pkg, err := opts.Client().V1().Package().Get(&metav1.ObjectMeta{
 		Namespace: fnNamespace,
 		Name:      pkgName,
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functionalias
+
+import (
+	"github.com/spf13/cobra"
+
+	wrapper "github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/cobra"
+	"github.com/fission/fission/pkg/fission-cli/flag"
+)
+
+func Commands() *cobra.Command {
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a function alias",
+		RunE:  wrapper.Wrapper(Create),
+	}
+	wrapper.SetFlags(createCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.AliasName, flag.AliasFnName},
+		Optional: []flag.Flag{flag.AliasFnWeight, flag.NamespaceFunction},
+	})
+
+	getCmd := &cobra.Command{
+		Use:     "get",
+		Aliases: []string{},
+		Short:   "View the functions a function alias points to",
+		RunE:    wrapper.Wrapper(Get),
+	}
+	wrapper.SetFlags(getCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.AliasName},
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
+	updateCmd := &cobra.Command{
+		Use:     "update",
+		Aliases: []string{},
+		Short:   "Point a function alias to other functions",
+		Long:    "Point a function alias to other functions, triggers referencing the alias follow it",
+		RunE:    wrapper.Wrapper(Update),
+	}
+	wrapper.SetFlags(updateCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.AliasName, flag.AliasFnName},
+		Optional: []flag.Flag{flag.AliasFnWeight, flag.NamespaceFunction},
+	})
+
+	deleteCmd := &cobra.Command{
+		Use:     "delete",
+		Aliases: []string{},
+		Short:   "Delete a function alias",
+		RunE:    wrapper.Wrapper(Delete),
+	}
+	wrapper.SetFlags(deleteCmd, flag.FlagSet{
+		Required: []flag.Flag{flag.AliasName},
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{},
+		Short:   "List function aliases",
+		Long:    "List all function aliases in a namespace if specified, else, list function aliases across all namespaces",
+		RunE:    wrapper.Wrapper(List),
+	}
+	wrapper.SetFlags(listCmd, flag.FlagSet{
+		Optional: []flag.Flag{flag.NamespaceFunction},
+	})
+
+	command := &cobra.Command{
+		Use:     "alias",
+		Aliases: []string{"function-alias"},
+		Short:   "Create, Update and manage function aliases",
+	}
+
+	command.AddCommand(createCmd, getCmd, updateCmd, deleteCmd, listCmd)
+
+	return command
+}
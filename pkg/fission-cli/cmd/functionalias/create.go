@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functionalias
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type CreateSubCommand struct {
+	cmd.CommandActioner
+	alias *fv1.FunctionAlias
+}
+
+func Create(input cli.Input) error {
+	return (&CreateSubCommand{}).do(input)
+}
+
+func (opts *CreateSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *CreateSubCommand) complete(input cli.Input) error {
+	functionList := input.StringSlice(flagkey.AliasFnName)
+	fnNamespace := input.String(flagkey.NamespaceFunction)
+
+	aliasSpec, err := getAliasSpec(functionList, input.IntSlice(flagkey.AliasFnWeight))
+	if err != nil {
+		return err
+	}
+
+	// function aliases can be created for functions in the same namespace
+	err = util.CheckFunctionExistence(opts.Client(), functionList, fnNamespace)
+	if err != nil {
+		console.Warn(err.Error())
+	}
+
+	opts.alias = &fv1.FunctionAlias{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      input.String(flagkey.AliasName),
+			Namespace: fnNamespace,
+		},
+		Spec: *aliasSpec,
+	}
+
+	return opts.alias.Validate()
+}
+
+func (opts *CreateSubCommand) run(input cli.Input) error {
+	_, err := opts.Client().V1().FunctionAlias().Create(opts.alias)
+	if err != nil {
+		return errors.Wrap(err, "error creating function alias")
+	}
+
+	fmt.Printf("function alias '%v' created\n", opts.alias.ObjectMeta.Name)
+	return nil
+}
+
+// getAliasSpec returns the spec of alias pointing to the functions, a single
+// function is referenced by name and more than one by their weights.
+func getAliasSpec(functionList []string, functionWeightsList []int) (*fv1.FunctionAliasSpec, error) {
+	if len(functionList) == 0 {
+		return nil, errors.New("need a function name for the alias, use --function")
+	}
+
+	if len(functionList) == 1 {
+		return &fv1.FunctionAliasSpec{
+			FunctionName: functionList[0],
+		}, nil
+	}
+
+	if len(functionWeightsList) != len(functionList) {
+		return nil, errors.New("weights of the functions need to be specified when more than one function is supplied")
+	}
+
+	functionWeights := make(map[string]int)
+	for index := range functionList {
+		functionWeights[functionList[index]] = functionWeightsList[index]
+	}
+
+	return &fv1.FunctionAliasSpec{
+		FunctionWeights: functionWeights,
+	}, nil
+}
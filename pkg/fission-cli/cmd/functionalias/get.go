@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functionalias
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+type GetSubCommand struct {
+	cmd.CommandActioner
+}
+
+func Get(input cli.Input) error {
+	return (&GetSubCommand{}).run(input)
+}
+
+func (opts *GetSubCommand) run(input cli.Input) error {
+	alias, err := opts.Client().V1().FunctionAlias().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.AliasName),
+		Namespace: input.String(flagkey.NamespaceFunction),
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function alias")
+	}
+
+	printAliases([]fv1.FunctionAlias{*alias})
+	return nil
+}
+
+func printAliases(aliases []fv1.FunctionAlias) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "%v\t%v\n", "NAME", "FUNCTION(s)")
+	for _, alias := range aliases {
+		fmt.Fprintf(w, "%v\t%v\n", alias.ObjectMeta.Name, aliasFunctions(&alias.Spec))
+	}
+	w.Flush()
+}
+
+func aliasFunctions(spec *fv1.FunctionAliasSpec) string {
+	if len(spec.FunctionWeights) == 0 {
+		return spec.FunctionName
+	}
+	functions := make([]string, 0, len(spec.FunctionWeights))
+	for k, v := range spec.FunctionWeights {
+		functions = append(functions, fmt.Sprintf("%s:%v", k, v))
+	}
+	sort.Strings(functions)
+	return strings.Join(functions, " ")
+}
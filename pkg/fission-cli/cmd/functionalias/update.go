@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functionalias
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	"github.com/fission/fission/pkg/fission-cli/cmd"
+	"github.com/fission/fission/pkg/fission-cli/console"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+	"github.com/fission/fission/pkg/fission-cli/util"
+)
+
+type UpdateSubCommand struct {
+	cmd.CommandActioner
+	alias *fv1.FunctionAlias
+}
+
+func Update(input cli.Input) error {
+	return (&UpdateSubCommand{}).do(input)
+}
+
+func (opts *UpdateSubCommand) do(input cli.Input) error {
+	err := opts.complete(input)
+	if err != nil {
+		return err
+	}
+	return opts.run(input)
+}
+
+func (opts *UpdateSubCommand) complete(input cli.Input) error {
+	functionList := input.StringSlice(flagkey.AliasFnName)
+	fnNamespace := input.String(flagkey.NamespaceFunction)
+
+	aliasSpec, err := getAliasSpec(functionList, input.IntSlice(flagkey.AliasFnWeight))
+	if err != nil {
+		return err
+	}
+
+	alias, err := opts.Client().V1().FunctionAlias().Get(&metav1.ObjectMeta{
+		Name:      input.String(flagkey.AliasName),
+		Namespace: fnNamespace,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error getting function alias")
+	}
+
+	err = util.CheckFunctionExistence(opts.Client(), functionList, fnNamespace)
+	if err != nil {
+		console.Warn(err.Error())
+	}
+
+	alias.Spec = *aliasSpec
+	opts.alias = alias
+
+	return opts.alias.Validate()
+}
+
+func (opts *UpdateSubCommand) run(input cli.Input) error {
+	_, err := opts.Client().V1().FunctionAlias().Update(opts.alias)
+	if err != nil {
+		return errors.Wrap(err, "error updating function alias")
+	}
+	fmt.Printf("function alias '%v' updated\n", opts.alias.ObjectMeta.Name)
+	return nil
+}
@@ -93,7 +93,7 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		triggerUrl = fmt.Sprintf("/%s", triggerUrl)
 	}
 
-	method, err := GetMethod(input.String(flagkey.HtMethod))
+	methods, err := GetMethods(input.StringSlice(flagkey.HtMethods))
 	if err != nil {
 		return err
 	}
@@ -145,12 +145,12 @@ func (opts *CreateSubCommand) complete(input cli.Input) error {
 		Spec: fv1.HTTPTriggerSpec{
 			Host:              host,
 			RelativeURL:       triggerUrl,
-			Method:            method,
 			FunctionReference: *functionRef,
 			CreateIngress:     createIngress,
 			IngressConfig:     *ingressConfig,
 		},
 	}
+	SetMethods(&opts.trigger.Spec, methods)
 
 	return nil
 }
@@ -207,6 +207,36 @@ func GetMethod(method string) (string, error) {
 	}
 }
 
+// GetMethods returns the HTTP methods, a method given more than once is only kept once.
+func GetMethods(methods []string) ([]string, error) {
+	var result []string
+	seen := make(map[string]bool)
+	for _, m := range methods {
+		method, err := GetMethod(m)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[method] {
+			seen[method] = true
+			result = append(result, method)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.New("need at least one HTTP method")
+	}
+	return result, nil
+}
+
+// SetMethods sets the HTTP methods of trigger. A single method is kept in
+// Method so that routers that don't know about Methods still serve it.
+func SetMethods(spec *fv1.HTTPTriggerSpec, methods []string) {
+	spec.Method = methods[0]
+	spec.Methods = nil
+	if len(methods) > 1 {
+		spec.Methods = methods
+	}
+}
+
 func setHtFunctionRef(functionList []string, functionWeightsList []int) (*fv1.FunctionReference, error) {
 	if len(functionList) == 1 {
 		return &fv1.FunctionReference{
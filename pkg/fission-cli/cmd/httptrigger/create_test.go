@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httptrigger
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
+	wrapper "github.com/fission/fission/pkg/fission-cli/cliwrapper/driver/cobra"
+	"github.com/fission/fission/pkg/fission-cli/flag"
+	flagkey "github.com/fission/fission/pkg/fission-cli/flag/key"
+)
+
+func TestMethodsFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "default", args: nil, want: []string{"GET"}},
+		{name: "repeated method flag", args: []string{"--method", "GET", "--method", "post"}, want: []string{"GET", "POST"}},
+		{name: "repeated methods flag", args: []string{"--methods", "PUT", "--methods", "DELETE"}, want: []string{"PUT", "DELETE"}},
+		{name: "duplicate methods", args: []string{"--method", "GET", "--method", "get"}, want: []string{"GET"}},
+		{name: "invalid method", args: []string{"--method", "GET", "--method", "FOO"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			var err error
+			c := &cobra.Command{
+				Use: "create",
+				RunE: wrapper.Wrapper(func(input cli.Input) error {
+					got, err = GetMethods(input.StringSlice(flagkey.HtMethods))
+					return nil
+				}),
+			}
+			wrapper.SetFlags(c, flag.FlagSet{Optional: []flag.Flag{flag.HtMethods}})
+			c.SetArgs(tt.args)
+			if e := c.Execute(); e != nil {
+				t.Fatalf("error executing command: %v", e)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetMethods() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetMethods() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/fission-cli/cliwrapper/cli"
@@ -65,6 +66,10 @@ func printHtSummary(triggers []fv1.HTTPTrigger) {
 		function := ""
 		if trigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionName {
 			function = trigger.Spec.FunctionReference.Name
+		} else if trigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionAlias {
+			function = fmt.Sprintf("alias:%s", trigger.Spec.FunctionReference.Name)
+		} else if trigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionSelector {
+			function = fmt.Sprintf("selector:%s", labels.Set(trigger.Spec.FunctionReference.Selector).String())
 		} else {
 			for k, v := range trigger.Spec.FunctionReference.FunctionWeights {
 				function += fmt.Sprintf("%s:%v ", k, v)
@@ -87,7 +92,7 @@ func printHtSummary(triggers []fv1.HTTPTrigger) {
 		ann := strings.Join(msg, ", ")
 
 		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			trigger.ObjectMeta.Name, trigger.Spec.Method, trigger.Spec.RelativeURL, function, trigger.Spec.CreateIngress, host, path, trigger.Spec.IngressConfig.TLS, ann)
+			trigger.ObjectMeta.Name, strings.Join(trigger.Spec.GetMethods(), ","), trigger.Spec.RelativeURL, function, trigger.Spec.CreateIngress, host, path, trigger.Spec.IngressConfig.TLS, ann)
 	}
 	w.Flush()
 }
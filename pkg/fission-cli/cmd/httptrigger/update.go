@@ -63,8 +63,12 @@ func (opts *UpdateSubCommand) complete(input cli.Input) error {
 		ht.Spec.RelativeURL = input.String(flagkey.HtUrl)
 	}
 
-	if input.IsSet(flagkey.HtMethod) {
-		ht.Spec.Method = input.String(flagkey.HtMethod)
+	if input.IsSet(flagkey.HtMethods) {
+		methods, err := GetMethods(input.StringSlice(flagkey.HtMethods))
+		if err != nil {
+			return err
+		}
+		SetMethods(&ht.Spec, methods)
 	}
 
 	if input.IsSet(flagkey.HtFnName) {
@@ -350,6 +350,10 @@ func applyResources(fclient client.Interface, specDir string, fr *FissionResourc
 	// of the package. This ensures that various caches can invalidate themselves
 	// when the package changes.
 	for i, f := range fr.Functions {
+		// functions of the container and job executors have no package
+		if et := f.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType; et == fv1.ExecutorTypeContainer || et == fv1.ExecutorTypeJob {
+			continue
+		}
 		k := mapKey(&metav1.ObjectMeta{
 			Namespace: f.Spec.Package.PackageRef.Namespace,
 			Name:      f.Spec.Package.PackageRef.Name,
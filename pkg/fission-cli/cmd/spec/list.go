@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 	"github.com/fission/fission/pkg/controller/client"
@@ -306,6 +307,10 @@ func ShowHTTPTriggers(hts []fv1.HTTPTrigger) {
 			function := ""
 			if trigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionName {
 				function = trigger.Spec.FunctionReference.Name
+			} else if trigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionAlias {
+				function = fmt.Sprintf("alias:%s", trigger.Spec.FunctionReference.Name)
+			} else if trigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionSelector {
+				function = fmt.Sprintf("selector:%s", labels.Set(trigger.Spec.FunctionReference.Selector).String())
 			} else {
 				for k, v := range trigger.Spec.FunctionReference.FunctionWeights {
 					function += fmt.Sprintf("%s:%v ", k, v)
@@ -328,7 +333,7 @@ func ShowHTTPTriggers(hts []fv1.HTTPTrigger) {
 			ann := strings.Join(msg, ", ")
 
 			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-				trigger.ObjectMeta.Name, trigger.Spec.Method, trigger.Spec.RelativeURL, function, trigger.Spec.CreateIngress, host, path, trigger.Spec.IngressConfig.TLS, ann)
+				trigger.ObjectMeta.Name, strings.Join(trigger.Spec.GetMethods(), ","), trigger.Spec.RelativeURL, function, trigger.Spec.CreateIngress, host, path, trigger.Spec.IngressConfig.TLS, ann)
 		}
 		fmt.Fprintf(w, "\n")
 		w.Flush()
@@ -361,6 +361,12 @@ func (fr *FissionResources) Validate(input cli.Input) ([]string, error) {
 	for _, f := range fr.Functions {
 		functions[MapKey(&f.ObjectMeta)] = false
 
+		// functions of the container and job executors have no package, secrets or configmaps
+		if et := f.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType; et == fv1.ExecutorTypeContainer || et == fv1.ExecutorTypeJob {
+			result = multierror.Append(result, f.Validate())
+			continue
+		}
+
 		pkgMeta := &metav1.ObjectMeta{
 			Name:      f.Spec.Package.PackageRef.Name,
 			Namespace: f.Spec.Package.PackageRef.Namespace,
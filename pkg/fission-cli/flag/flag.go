@@ -84,6 +84,12 @@ var (
 	RunTimeMinMemory = Flag{Type: Int, Name: flagkey.RuntimeMinmemory, Usage: "Minimum memory to be assigned to pod (In megabyte)"}
 	RunTimeMaxMemory = Flag{Type: Int, Name: flagkey.RuntimeMaxmemory, Usage: "Maximum memory to be assigned to pod (In megabyte)"}
 
+	RunTimeTargetConcurrency = Flag{Type: Int, Name: flagkey.RuntimeTargetConcurrency, Usage: "Target average in-flight requests per pod for scaling (newdeploy only, 0 disables it)"}
+	RunTimeTargetRPS         = Flag{Type: Int, Name: flagkey.RuntimeTargetRPS, Usage: "Target average requests per second per pod for scaling (newdeploy only, 0 disables it)"}
+	RunTimeScaleMetric       = Flag{Type: String, Name: flagkey.RuntimeScaleMetric, Usage: "Name of a custom or external metric to scale on (newdeploy only)"}
+	RunTimeScaleMetricType   = Flag{Type: String, Name: flagkey.RuntimeScaleMetricType, Usage: "Type of the metric to scale on, one of 'pods' or 'external' (default 'pods')"}
+	RunTimeScaleMetricTarget = Flag{Type: String, Name: flagkey.RuntimeScaleMetricTarget, Usage: "Target average value per pod of the metric to scale on, e.g. '100' or '500m'"}
+
 	ReplicasMin = Flag{Type: Int, Name: flagkey.ReplicasMinscale, Usage: "Minimum number of pods (Uses resource inputs to configure HPA)", DefaultValue: 1}
 	ReplicasMax = Flag{Type: Int, Name: flagkey.ReplicasMaxscale, Usage: "Maximum number of pods (Uses resource inputs to configure HPA)", DefaultValue: 1}
 
@@ -95,8 +101,15 @@ var (
 	FnBuildCmd              = Flag{Type: String, Name: flagkey.FnBuildCmd, Usage: "Package build command for builder to run with"}
 	FnSecret                = Flag{Type: StringSlice, Name: flagkey.FnSecret, Usage: "Function access to secret, should be present in the same namespace as the function. You can provide multiple secrets using multiple --secrets flags. In the case of fn update the the secrets will be replaced by the provided list of secrets."}
 	FnCfgMap                = Flag{Type: StringSlice, Name: flagkey.FnCfgMap, Usage: "Function access to configmap, should be present in the same namespace as the function. You can provide multiple configmaps using multiple --configmap flags. In case of fn update the configmaps will be replaced by the provided list of configmaps."}
-	FnExecutorType          = Flag{Type: String, Name: flagkey.FnExecutorType, Usage: "Executor type for execution; one of 'poolmgr', 'newdeploy'", DefaultValue: string(fv1.ExecutorTypePoolmgr)}
+	FnExecutorType          = Flag{Type: String, Name: flagkey.FnExecutorType, Usage: "Executor type for execution; one of 'poolmgr', 'newdeploy', 'container', 'job'", DefaultValue: string(fv1.ExecutorTypePoolmgr)}
 	FnExecutionTimeout      = Flag{Type: Int, Name: flagkey.FnExecutionTimeout, Aliases: []string{"ft"}, Usage: "Maximum time for a request to wait for the response from the function", DefaultValue: 60}
+	FnIdleTimeout           = Flag{Type: Int, Name: flagkey.FnIdleTimeout, Usage: "Idle time in seconds after which function pods are released, or the deployment is scaled down to min scale for newdeploy (default 120)"}
+	FnNeverScaleToZero      = Flag{Type: Bool, Name: flagkey.FnNeverScaleToZero, Usage: "Keep the last specialized pod of function even when it's idle (poolmgr only)"}
+	FnImage                 = Flag{Type: String, Name: flagkey.FnImage, Usage: "Container image the function runs as-is (container and job executors only)"}
+	FnPort                  = Flag{Type: Int, Name: flagkey.FnPort, Usage: "Port the container image of function listens on for HTTP requests (container executor only)"}
+	FnImagePullSecret       = Flag{Type: String, Name: flagkey.FnImagePullSecret, Usage: "Secret for Kubernetes to pull the container image of function from a private registry (container and job executors only)"}
+	FnCommand               = Flag{Type: StringSlice, Name: flagkey.FnCommand, Usage: "Command of the container image of function with its arguments, one per flag: --command /report --command=--daily (container and job executors only, required by job)"}
+	FnConcurrency           = Flag{Type: Int, Name: flagkey.FnConcurrency, Usage: "Maximum concurrent requests served by a function pod, more pods are specialized under pressure (poolmgr only, 0 means no limit)"}
 	FnLogPod                = Flag{Type: String, Name: flagkey.FnLogPod, Usage: "Function pod name (use the latest pod name if unspecified)"}
 	FnLogFollow             = Flag{Type: Bool, Name: flagkey.FnLogFollow, Short: "f", Usage: "Specify if the logs should be streamed"}
 	FnLogDetail             = Flag{Type: Bool, Name: flagkey.FnLogDetail, Short: "d", Usage: "Display detailed information"}
@@ -110,7 +123,8 @@ var (
 
 	HtName              = Flag{Type: String, Name: flagkey.HtName, Usage: "HTTP trigger name"}
 	HtMethod            = Flag{Type: String, Name: flagkey.HtMethod, Usage: "HTTP Method: GET|POST|PUT|DELETE|HEAD", DefaultValue: http.MethodGet}
-	HtUrl               = Flag{Type: String, Name: flagkey.HtUrl, Usage: "URL pattern (See gorilla/mux supported patterns)"}
+	HtMethods           = Flag{Type: StringSlice, Name: flagkey.HtMethods, Aliases: []string{flagkey.HtMethod}, Usage: "HTTP Method(s): GET|POST|PUT|DELETE|HEAD, repeat the flag to accept multiple methods: --method GET --method POST", DefaultValue: []string{http.MethodGet}}
+	HtUrl               = Flag{Type: String, Name: flagkey.HtUrl, Usage: "URL pattern (See gorilla/mux supported patterns), path parameters can be declared as {name} or {name:regex} and are passed to the function in X-Fission-Params-<name> headers"}
 	HtHost              = Flag{Type: String, Name: flagkey.HtHost, Usage: "Use --ingressrule instead", Deprecated: true, Substitute: flagkey.HtIngressRule}
 	HtIngress           = Flag{Type: Bool, Name: flagkey.HtIngress, Usage: "Creates ingress with same URL"}
 	HtIngressRule       = Flag{Type: String, Name: flagkey.HtIngressRule, Usage: "Host for Ingress rule: --ingressrule host=path (the format of host/path depends on what ingress controller you used)"}
@@ -184,4 +198,8 @@ var (
 	CanaryWeightIncrement   = Flag{Type: Int, Name: flagkey.CanaryWeightIncrement, Aliases: []string{"step"}, Usage: "Weight increment step for function", DefaultValue: 20}
 	CanaryIncrementInterval = Flag{Type: String, Name: flagkey.CanaryIncrementInterval, Aliases: []string{"internal"}, Usage: "Weight increment interval, string representation of time.Duration, ex : 1m, 2h, 2d", DefaultValue: "2m"}
 	CanaryFailureThreshold  = Flag{Type: Int, Name: flagkey.CanaryFailureThreshold, Aliases: []string{"threshold"}, Usage: "Threshold in percentage beyond which the new version of the function is considered unstable", DefaultValue: 10}
+
+	AliasName     = Flag{Type: String, Name: flagkey.AliasName, Usage: "Function alias name"}
+	AliasFnName   = Flag{Type: StringSlice, Name: flagkey.AliasFnName, Usage: "Name(s) of the function the alias points to. (If more than one function is supplied with this flag, traffic gets routed to them based on weights supplied with --weight flag.)"}
+	AliasFnWeight = Flag{Type: IntSlice, Name: flagkey.AliasFnWeight, Usage: "Weight for each function supplied with --function flag, in the same order"}
 )
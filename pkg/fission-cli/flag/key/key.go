@@ -37,6 +37,12 @@ const (
 	RuntimeMaxmemory = "maxmemory"
 	RuntimeTargetcpu = "targetcpu"
 
+	RuntimeTargetConcurrency = "targetconcurrency"
+	RuntimeTargetRPS         = "targetrps"
+	RuntimeScaleMetric       = "scalemetric"
+	RuntimeScaleMetricType   = "scalemetrictype"
+	RuntimeScaleMetricTarget = "scalemetrictarget"
+
 	ReplicasMinscale = "minscale"
 	ReplicasMaxscale = "maxscale"
 
@@ -51,6 +57,13 @@ const (
 	FnCfgMap                = "configmap"
 	FnExecutorType          = "executortype"
 	FnExecutionTimeout      = "fntimeout"
+	FnConcurrency           = "concurrency"
+	FnIdleTimeout           = "idletimeout"
+	FnNeverScaleToZero      = "neverscaletozero"
+	FnImage                 = "image"
+	FnPort                  = "port"
+	FnImagePullSecret       = "imagepullsecret"
+	FnCommand               = "command"
 	FnTestTimeout           = "timeout"
 	FnLogPod                = "pod"
 	FnLogFollow             = "follow"
@@ -64,6 +77,7 @@ const (
 
 	HtName              = resourceName
 	HtMethod            = "method"
+	HtMethods           = "methods"
 	HtUrl               = "url"
 	HtHost              = "host"
 	HtIngress           = "createingress"
@@ -139,5 +153,9 @@ const (
 	CanaryIncrementInterval = "increment-interval"
 	CanaryFailureThreshold  = "failure-threshold"
 
+	AliasName     = resourceName
+	AliasFnName   = "function"
+	AliasFnWeight = "weight"
+
 	DefaultSpecOutputDir = "fission-dump"
 )
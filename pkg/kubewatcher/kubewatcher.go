@@ -310,18 +310,16 @@ func (ws *watchSubscription) eventDispatchLoop() {
 			"X-Kubernetes-Object-Type": reflect.TypeOf(ev.Object).Elem().Name(),
 		}
 
-		// TODO support other function ref types. Or perhaps delegate to router?
-		if ws.watch.Spec.FunctionReference.Type != fv1.FunctionReferenceTypeFunctionName {
+		// with the addition of multi-tenancy, the users can create functions in any namespace. however,
+		// the triggers can only be created in the same namespace as the function.
+		// so essentially, function namespace = trigger namespace.
+		url, err := utils.UrlForFunctionReference(ws.watch.Spec.FunctionReference, ws.watch.ObjectMeta.Namespace)
+		if err != nil {
 			ws.logger.Error("unsupported function ref type - cannot publish event",
 				zap.Any("type", ws.watch.Spec.FunctionReference.Type),
 				zap.String("watch_name", ws.watch.ObjectMeta.Name))
 			continue
 		}
-
-		// with the addition of multi-tenancy, the users can create functions in any namespace. however,
-		// the triggers can only be created in the same namespace as the function.
-		// so essentially, function namespace = trigger namespace.
-		url := utils.UrlForFunction(ws.watch.Spec.FunctionReference.Name, ws.watch.ObjectMeta.Namespace)
 		ws.publisher.Publish(buf.String(), headers, url)
 	}
 }
@@ -209,7 +209,11 @@ func New(logger *zap.Logger, routerURL string, config messageQueue.Config) (mess
 func (asc AzureStorageConnection) Subscribe(trigger *fv1.MessageQueueTrigger) (messageQueue.Subscription, error) {
 	asc.logger.Info("subscribing to Azure storage queue", zap.String("queue", trigger.Spec.Topic))
 
-	if trigger.Spec.FunctionReference.Type != fv1.FunctionReferenceTypeFunctionName {
+	// with the addition of multi-tenancy, the users can create functions in any namespace. however,
+	// the triggers can only be created in the same namespace as the function.
+	// so essentially, function namespace = trigger namespace.
+	fnURL, err := utils.UrlForFunctionReference(trigger.Spec.FunctionReference, trigger.ObjectMeta.Namespace)
+	if err != nil {
 		return nil, fmt.Errorf("unsupported function reference type (%v) for trigger %q", trigger.Spec.FunctionReference.Type, trigger.ObjectMeta.Name)
 	}
 
@@ -217,13 +221,10 @@ func (asc AzureStorageConnection) Subscribe(trigger *fv1.MessageQueueTrigger) (m
 		queue:           asc.service.GetQueue(trigger.Spec.Topic),
 		queueName:       trigger.Spec.Topic,
 		outputQueueName: trigger.Spec.ResponseTopic,
-		// with the addition of multi-tenancy, the users can create functions in any namespace. however,
-		// the triggers can only be created in the same namespace as the function.
-		// so essentially, function namespace = trigger namespace.
-		functionURL: asc.routerURL + "/" + strings.TrimPrefix(utils.UrlForFunction(trigger.Spec.FunctionReference.Name, trigger.ObjectMeta.Namespace), "/"),
-		contentType: trigger.Spec.ContentType,
-		unsubscribe: make(chan bool),
-		done:        make(chan bool),
+		functionURL:     asc.routerURL + "/" + strings.TrimPrefix(fnURL, "/"),
+		contentType:     trigger.Spec.ContentType,
+		unsubscribe:     make(chan bool),
+		done:            make(chan bool),
 	}
 
 	go runAzureQueueSubscription(asc, subscription)
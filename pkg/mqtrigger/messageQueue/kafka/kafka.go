@@ -203,14 +203,14 @@ func (kafka Kafka) Unsubscribe(subscription messageQueue.Subscription) error {
 
 func kafkaMsgHandler(kafka *Kafka, producer sarama.SyncProducer, trigger *fv1.MessageQueueTrigger, msg *sarama.ConsumerMessage, consumer *cluster.Consumer) {
 	var value string = string(msg.Value[:])
-	// Support other function ref types
-	if trigger.Spec.FunctionReference.Type != fv1.FunctionReferenceTypeFunctionName {
+	fnUrl, err := utils.UrlForFunctionReference(trigger.Spec.FunctionReference, trigger.ObjectMeta.Namespace)
+	if err != nil {
 		kafka.logger.Fatal("unsupported function reference type for trigger",
 			zap.Any("function_reference_type", trigger.Spec.FunctionReference.Type),
 			zap.String("trigger", trigger.ObjectMeta.Name))
 	}
 
-	url := kafka.routerUrl + "/" + strings.TrimPrefix(utils.UrlForFunction(trigger.Spec.FunctionReference.Name, trigger.ObjectMeta.Namespace), "/")
+	url := kafka.routerUrl + "/" + strings.TrimPrefix(fnUrl, "/")
 	kafka.logger.Debug("making HTTP request", zap.String("url", url))
 
 	// Generate the Headers
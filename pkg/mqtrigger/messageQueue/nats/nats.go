@@ -100,17 +100,16 @@ func (nats Nats) Unsubscribe(subscription messageQueue.Subscription) error {
 func msgHandler(nats *Nats, trigger *fv1.MessageQueueTrigger) func(*ns.Msg) {
 	return func(msg *ns.Msg) {
 
-		// Support other function ref types
-		if trigger.Spec.FunctionReference.Type != fv1.FunctionReferenceTypeFunctionName {
+		// with the addition of multi-tenancy, the users can create functions in any namespace. however,
+		// the triggers can only be created in the same namespace as the function.
+		// so essentially, function namespace = trigger namespace.
+		fnUrl, err := utils.UrlForFunctionReference(trigger.Spec.FunctionReference, trigger.ObjectMeta.Namespace)
+		if err != nil {
 			nats.logger.Fatal("unsupported function reference type for trigger",
 				zap.Any("function_reference_type", trigger.Spec.FunctionReference.Type),
 				zap.String("trigger", trigger.ObjectMeta.Name))
 		}
-
-		// with the addition of multi-tenancy, the users can create functions in any namespace. however,
-		// the triggers can only be created in the same namespace as the function.
-		// so essentially, function namespace = trigger namespace.
-		url := nats.routerUrl + "/" + strings.TrimPrefix(utils.UrlForFunction(trigger.Spec.FunctionReference.Name, trigger.ObjectMeta.Namespace), "/")
+		url := nats.routerUrl + "/" + strings.TrimPrefix(fnUrl, "/")
 		nats.logger.Debug("making HTTP request", zap.String("url", url))
 
 		headers := map[string]string{
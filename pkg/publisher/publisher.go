@@ -16,6 +16,12 @@ limitations under the License.
 
 package publisher
 
+// HEADER_FISSION_JOB_DETACH asks router to reply 202 with the name of job
+// once it's started for a function run as a job, instead of waiting for the
+// job to finish. The webhook publisher sets it, as it doesn't read the result.
+// Router only honors it on the internal routes of functions.
+const HEADER_FISSION_JOB_DETACH = "X-Fission-Job-Detach"
+
 type (
 	Publisher interface {
 		// Publish an request to a "target".  Target's meaning depends on the
@@ -19,7 +19,9 @@ package publisher
 import (
 	"bytes"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -32,6 +34,10 @@ type (
 		logger *zap.Logger
 
 		requestChannel chan *publishRequest
+		// inflight holds a slot for each request being sent
+		inflight chan struct{}
+
+		httpClient *http.Client
 
 		maxRetries int
 		retryDelay time.Duration
@@ -47,11 +53,24 @@ type (
 	}
 )
 
+const (
+	// maxInflightRequests is the most requests sent at once, so that
+	// a slow function doesn't hold up the requests to the others.
+	maxInflightRequests = 16
+
+	// requestTimeout bounds a request to a function that never replies,
+	// the functions run as jobs reply once the job is started. A request
+	// timed out isn't retried, the function may be running still.
+	requestTimeout = 5 * time.Minute
+)
+
 func MakeWebhookPublisher(logger *zap.Logger, baseUrl string) *WebhookPublisher {
 	p := &WebhookPublisher{
 		logger:         logger.Named("webhook_publisher"),
 		baseUrl:        baseUrl,
 		requestChannel: make(chan *publishRequest, 32), // buffered channel
+		inflight:       make(chan struct{}, maxInflightRequests),
+		httpClient:     &http.Client{Timeout: requestTimeout},
 		// TODO make this configurable
 		maxRetries: 10,
 		retryDelay: 500 * time.Millisecond,
@@ -61,7 +80,8 @@ func MakeWebhookPublisher(logger *zap.Logger, baseUrl string) *WebhookPublisher
 }
 
 func (p *WebhookPublisher) Publish(body string, headers map[string]string, target string) {
-	// serializing the request gives user a guarantee that the request is sent in sequence order
+	// the requests are sent in sequence order, though
+	// up to maxInflightRequests of them are sent at once
 	p.requestChannel <- &publishRequest{
 		body:       body,
 		headers:    headers,
@@ -74,7 +94,11 @@ func (p *WebhookPublisher) Publish(body string, headers map[string]string, targe
 func (p *WebhookPublisher) svc() {
 	for {
 		r := <-p.requestChannel
-		p.makeHttpRequest(r)
+		p.inflight <- struct{}{}
+		go func() {
+			defer func() { <-p.inflight }()
+			p.makeHttpRequest(r)
+		}()
 	}
 }
 
@@ -104,13 +128,20 @@ func (p *WebhookPublisher) makeHttpRequest(r *publishRequest) {
 	for k, v := range r.headers {
 		req.Header.Set(k, v)
 	}
+	// the response is only logged, don't wait for a job to finish
+	req.Header.Set(HEADER_FISSION_JOB_DETACH, "true")
 	// Make the request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := p.httpClient.Do(req)
 	if err != nil {
 		fields = append(fields, zap.Error(err), zap.Any("request", r))
+		if timedOut(err) {
+			msg = "request timed out, not retrying"
+			return
+		}
 	} else {
 		var body []byte
 		body, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
 			fields = append(fields, zap.Error(err), zap.Any("request", r))
 			msg = "read response body error"
@@ -140,3 +171,16 @@ func (p *WebhookPublisher) makeHttpRequest(r *publishRequest) {
 		// Event dropped
 	}
 }
+
+// timedOut returns true if the request timed out once it was sent,
+// a request not sent because connecting timed out can be retried.
+func timedOut(err error) bool {
+	urlErr, ok := err.(*url.Error)
+	if !ok || !urlErr.Timeout() {
+		return false
+	}
+	if opErr, ok := urlErr.Err.(*net.OpError); ok && opErr.Op == "dial" {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publisher
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type publishedRequest struct {
+	method string
+	path   string
+	body   string
+	header http.Header
+}
+
+func makeTestPublisher(t *testing.T, baseUrl string) *WebhookPublisher {
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		t.Fatalf("error creating logger: %v", err)
+	}
+	p := MakeWebhookPublisher(logger, baseUrl)
+	p.retryDelay = time.Millisecond
+	return p
+}
+
+func TestWebhookPublisher(t *testing.T) {
+	requests := make(chan publishedRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- publishedRequest{method: r.Method, path: r.URL.Path, body: string(body), header: r.Header}
+	}))
+	defer server.Close()
+
+	p := makeTestPublisher(t, server.URL)
+	p.Publish("hello", map[string]string{"X-Fission-Timer-Name": "tick"}, "/fission-function/hello")
+
+	select {
+	case r := <-requests:
+		assert.Equal(t, http.MethodPost, r.method)
+		assert.Equal(t, "/fission-function/hello", r.path)
+		assert.Equal(t, "hello", r.body)
+		assert.Equal(t, "tick", r.header.Get("X-Fission-Timer-Name"))
+		assert.Equal(t, "true", r.header.Get(HEADER_FISSION_JOB_DETACH))
+	case <-time.After(5 * time.Second):
+		t.Fatal("request not published")
+	}
+}
+
+// TestWebhookPublisherRetry checks that a request failing to get
+// a response is sent again.
+func TestWebhookPublisherRetry(t *testing.T) {
+	var attempts int32
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			// drop the connection without a response
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		close(done)
+	}))
+	defer server.Close()
+
+	p := makeTestPublisher(t, server.URL)
+	p.Publish("", nil, "fission-function/hello")
+
+	select {
+	case <-done:
+		assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+	case <-time.After(5 * time.Second):
+		t.Fatalf("request not retried, %v attempts", atomic.LoadInt32(&attempts))
+	}
+}
+
+// TestWebhookPublisherTimeout checks that a request timed out isn't sent
+// again, the function may be running still.
+func TestWebhookPublisherTimeout(t *testing.T) {
+	var attempts int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	p := makeTestPublisher(t, server.URL)
+	p.httpClient = &http.Client{Timeout: 50 * time.Millisecond}
+	p.Publish("", nil, "fission-function/slow")
+
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
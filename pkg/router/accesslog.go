@@ -0,0 +1,408 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	accessLogFormatJSON = "json"
+	accessLogFormatCLF  = "clf"
+
+	accessLogSinkStdout  = "stdout"
+	accessLogSinkFile    = "file"
+	accessLogSinkWebhook = "webhook"
+
+	serviceSourceCache    = "cache"
+	serviceSourceExecutor = "executor"
+
+	// clfTimeFormat is the time format of Common Log Format
+	clfTimeFormat = "02/Jan/2006:15:04:05 -0700"
+
+	webhookBatchSize     = 100
+	webhookFlushInterval = time.Second
+	webhookQueueSize     = 10000
+)
+
+type (
+	// accessLogConfig is the access log config of router.
+	accessLogConfig struct {
+		// Sink is where the records are written to: "stdout", "file" or "webhook".
+		// Access log is disabled if it's empty.
+		Sink string
+		// Format of records, "json" or "clf" (Common Log Format).
+		Format string
+		// File is the path of log file of the "file" sink.
+		File string
+		// WebhookURL is the URL the "webhook" sink posts batches of records to.
+		WebhookURL string
+		// SampleRate is the fraction of requests to log, between 0 and 1.
+		SampleRate float64
+	}
+
+	// accessLogger writes a record of each sampled invocation to its sink.
+	accessLogger struct {
+		logger     *zap.Logger
+		format     string
+		sink       accessLogSink
+		sampleRate float64
+	}
+
+	// accessLogSink writes formatted records.
+	accessLogSink interface {
+		write(line []byte) error
+	}
+
+	// accessLogRecord is the access log of an invocation, it's filled in
+	// by the router while the request is served.
+	accessLogRecord struct {
+		Time          time.Time `json:"time"`
+		RemoteAddr    string    `json:"remoteAddr"`
+		Method        string    `json:"method"`
+		Host          string    `json:"host"`
+		URI           string    `json:"uri"`
+		Proto         string    `json:"proto"`
+		UserAgent     string    `json:"userAgent,omitempty"`
+		Namespace     string    `json:"namespace,omitempty"`
+		Trigger       string    `json:"trigger,omitempty"`
+		Function      string    `json:"function,omitempty"`
+		CanaryBackend string    `json:"canaryBackend,omitempty"`
+		InvocationID  string    `json:"invocationId,omitempty"`
+		ServiceSource string    `json:"serviceSource,omitempty"`
+		Retries       int       `json:"retries"`
+		ColdStart     bool      `json:"coldStart"`
+		Status        int       `json:"status"`
+		RequestBytes  int64     `json:"requestBytes"`
+		ResponseBytes int64     `json:"responseBytes"`
+		DurationMs    float64   `json:"durationMs"`
+
+		body *countingBody
+	}
+
+	accessLogContextKey struct{}
+
+	// accessLogWriter records the status and size of response.
+	accessLogWriter struct {
+		http.ResponseWriter
+		status int
+		size   int64
+	}
+
+	// countingBody records the size of request body read by router.
+	countingBody struct {
+		io.ReadCloser
+		size int64
+	}
+
+	// writerSink writes records line by line to stdout or a file.
+	writerSink struct {
+		lock sync.Mutex
+		w    io.Writer
+	}
+
+	// webhookSink posts batches of records to a URL in background,
+	// records are dropped if the webhook can't keep up.
+	webhookSink struct {
+		logger      *zap.Logger
+		url         string
+		contentType string
+		client      *http.Client
+		queue       chan []byte
+	}
+)
+
+func makeAccessLogger(logger *zap.Logger, config accessLogConfig) (*accessLogger, error) {
+	if len(config.Sink) == 0 {
+		return nil, nil
+	}
+
+	format := config.Format
+	switch format {
+	case "":
+		format = accessLogFormatJSON
+	case accessLogFormatJSON, accessLogFormatCLF:
+	default:
+		return nil, errors.Errorf("unsupported access log format %q", config.Format)
+	}
+
+	sampleRate := config.SampleRate
+	if sampleRate <= 0 || sampleRate > 1 {
+		sampleRate = 1
+	}
+
+	var sink accessLogSink
+	switch config.Sink {
+	case accessLogSinkStdout:
+		sink = &writerSink{w: os.Stdout}
+	case accessLogSinkFile:
+		if len(config.File) == 0 {
+			return nil, errors.New("access log file is not set")
+		}
+		f, err := os.OpenFile(config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, errors.Wrap(err, "error opening access log file")
+		}
+		sink = &writerSink{w: f}
+	case accessLogSinkWebhook:
+		if len(config.WebhookURL) == 0 {
+			return nil, errors.New("access log webhook url is not set")
+		}
+		contentType := "application/x-ndjson"
+		if format == accessLogFormatCLF {
+			contentType = "text/plain"
+		}
+		sink = makeWebhookSink(logger, config.WebhookURL, contentType)
+	default:
+		return nil, errors.Errorf("unsupported access log sink %q", config.Sink)
+	}
+
+	return &accessLogger{
+		logger:     logger.Named("access_log"),
+		format:     format,
+		sink:       sink,
+		sampleRate: sampleRate,
+	}, nil
+}
+
+// start returns the record of request if it's sampled, the record
+// is carried by the returned request and its size and status by
+// the returned response writer.
+func (al *accessLogger) start(rw http.ResponseWriter, req *http.Request, fh *functionHandler) (*accessLogRecord, *accessLogWriter, *http.Request) {
+	if al.sampleRate < 1 && rand.Float64() >= al.sampleRate {
+		return nil, nil, req
+	}
+
+	rec := &accessLogRecord{
+		Time:       time.Now(),
+		RemoteAddr: req.RemoteAddr,
+		Method:     req.Method,
+		Host:       req.Host,
+		URI:        req.RequestURI,
+		Proto:      req.Proto,
+		UserAgent:  req.UserAgent(),
+	}
+	if len(rec.URI) == 0 {
+		rec.URI = req.URL.RequestURI()
+	}
+	if fh.httpTrigger != nil {
+		rec.Namespace, rec.Trigger = fh.httpTrigger.ObjectMeta.Namespace, fh.httpTrigger.ObjectMeta.Name
+	}
+	if fh.function != nil {
+		rec.Namespace, rec.Function = fh.function.ObjectMeta.Namespace, fh.function.ObjectMeta.Name
+	}
+
+	if req.Body != nil {
+		rec.body = &countingBody{ReadCloser: req.Body}
+		req.Body = rec.body
+	}
+	return rec, &accessLogWriter{ResponseWriter: rw}, req.WithContext(context.WithValue(req.Context(), accessLogContextKey{}, rec))
+}
+
+// finish completes the record with the response and writes it to sink.
+func (al *accessLogger) finish(rec *accessLogRecord, rw *accessLogWriter) {
+	rec.DurationMs = float64(time.Since(rec.Time)) / float64(time.Millisecond)
+	rec.Status = rw.status
+	rec.ResponseBytes = rw.size
+	if rec.body != nil {
+		rec.RequestBytes = rec.body.size
+	}
+
+	line, err := al.formatRecord(rec)
+	if err != nil {
+		al.logger.Error("error formatting access log", zap.Error(err))
+		return
+	}
+	err = al.sink.write(line)
+	if err != nil {
+		al.logger.Error("error writing access log", zap.Error(err))
+	}
+}
+
+// formatRecord returns the record as a line in the format of access log.
+func (al *accessLogger) formatRecord(rec *accessLogRecord) ([]byte, error) {
+	if al.format == accessLogFormatCLF {
+		host, _, err := net.SplitHostPort(rec.RemoteAddr)
+		if err != nil {
+			host = rec.RemoteAddr
+		}
+		size := "-"
+		if rec.ResponseBytes > 0 {
+			size = fmt.Sprint(rec.ResponseBytes)
+		}
+		return []byte(fmt.Sprintf("%v - - [%v] \"%v %v %v\" %v %v\n",
+			clfField(host), rec.Time.Format(clfTimeFormat), rec.Method, rec.URI, rec.Proto, rec.Status, size)), nil
+	}
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
+
+func clfField(s string) string {
+	if len(s) == 0 {
+		return "-"
+	}
+	return s
+}
+
+// getAccessLogRecord returns the access log record of request, if the request is sampled.
+func getAccessLogRecord(req *http.Request) *accessLogRecord {
+	rec, _ := req.Context().Value(accessLogContextKey{}).(*accessLogRecord)
+	return rec
+}
+
+// withoutAccessLog returns a context without the access log record of parent,
+// for the work that outlives the logged request.
+func withoutAccessLog(ctx context.Context) context.Context {
+	return context.WithValue(ctx, accessLogContextKey{}, (*accessLogRecord)(nil))
+}
+
+// recordRoundTrip fills in the record with how the function service was reached.
+func (rec *accessLogRecord) recordRoundTrip(rrt *RetryingRoundTripper) {
+	rec.Retries = rrt.totalRetry
+	rec.ColdStart = rrt.coldStart
+	if rrt.urlFromExecutor {
+		rec.ServiceSource = serviceSourceExecutor
+	} else if rrt.serviceUrl != nil {
+		rec.ServiceSource = serviceSourceCache
+	}
+}
+
+func (w *accessLogWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *accessLogWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *accessLogWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack hands over the connection for a protocol switch, e.g. WebSocket.
+func (w *accessLogWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil && w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += int64(n)
+	return n, err
+}
+
+func (s *writerSink) write(line []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, err := s.w.Write(line)
+	return err
+}
+
+func makeWebhookSink(logger *zap.Logger, url string, contentType string) *webhookSink {
+	s := &webhookSink{
+		logger:      logger.Named("access_log_webhook"),
+		url:         url,
+		contentType: contentType,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		queue:       make(chan []byte, webhookQueueSize),
+	}
+	go s.run()
+	return s
+}
+
+func (s *webhookSink) write(line []byte) error {
+	select {
+	case s.queue <- line:
+		return nil
+	default:
+		return errors.New("access log webhook queue is full, record dropped")
+	}
+}
+
+// run posts the queued records once a batch is full or the flush interval passed.
+func (s *webhookSink) run() {
+	ticker := time.NewTicker(webhookFlushInterval)
+	defer ticker.Stop()
+
+	var batch bytes.Buffer
+	count := 0
+	for {
+		select {
+		case line := <-s.queue:
+			batch.Write(line)
+			count++
+			if count < webhookBatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if count == 0 {
+				continue
+			}
+		}
+		s.post(batch.Bytes(), count)
+		batch.Reset()
+		count = 0
+	}
+}
+
+func (s *webhookSink) post(body []byte, count int) {
+	resp, err := s.client.Post(s.url, s.contentType, bytes.NewReader(body))
+	if err != nil {
+		s.logger.Error("error posting access log", zap.Error(err), zap.Int("records", count))
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		s.logger.Error("access log webhook rejected records",
+			zap.String("status", resp.Status), zap.Int("records", count))
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestAccessLog(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(append(body, body...))
+	}))
+	defer backend.Close()
+	backendURL, err := url.Parse(backend.URL)
+	assert.Nil(t, err)
+
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+	fnMeta := metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault, UID: "1"}
+
+	var out bytes.Buffer
+	fh := makeTestFunctionHandler(logger, fnMeta, backendURL)
+	fh.httpTrigger = &fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "xxx", Namespace: metav1.NamespaceDefault},
+		Spec: fv1.HTTPTriggerSpec{
+			FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName},
+		},
+	}
+	fh.accessLog = &accessLogger{
+		logger:     logger,
+		format:     accessLogFormatJSON,
+		sink:       &writerSink{w: &out},
+		sampleRate: 1,
+	}
+	server := httptest.NewServer(http.HandlerFunc(fh.handler))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/foo?x=1", "text/plain", strings.NewReader("hello"))
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var rec accessLogRecord
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &rec))
+	assert.Equal(t, "POST", rec.Method)
+	assert.Equal(t, "/foo?x=1", rec.URI)
+	assert.Equal(t, "xxx", rec.Trigger)
+	assert.Equal(t, "foo", rec.Function)
+	assert.Equal(t, serviceSourceCache, rec.ServiceSource)
+	assert.False(t, rec.ColdStart)
+	assert.Equal(t, 0, rec.Retries)
+	assert.Equal(t, http.StatusCreated, rec.Status)
+	assert.Equal(t, int64(5), rec.RequestBytes)
+	assert.Equal(t, int64(10), rec.ResponseBytes)
+
+	// nothing is logged if no request is sampled
+	out.Reset()
+	fh.accessLog.sampleRate = 0.000001
+	for i := 0; i < 5; i++ {
+		resp, err = http.Get(server.URL + "/foo")
+		assert.Nil(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, 0, out.Len())
+}
+
+func TestAccessLogCommonLogFormat(t *testing.T) {
+	al := &accessLogger{format: accessLogFormatCLF}
+	line, err := al.formatRecord(&accessLogRecord{
+		Time:          time.Date(2020, 3, 1, 10, 20, 30, 0, time.UTC),
+		RemoteAddr:    "10.0.0.1:4321",
+		Method:        "GET",
+		URI:           "/foo?x=1",
+		Proto:         "HTTP/1.1",
+		Status:        200,
+		ResponseBytes: 12,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.1 - - [01/Mar/2020:10:20:30 +0000] \"GET /foo?x=1 HTTP/1.1\" 200 12\n", string(line))
+}
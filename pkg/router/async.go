@@ -0,0 +1,714 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fission/fission/pkg/cache"
+)
+
+const (
+	// HEADER_FISSION_ASYNC asks router to invoke the function asynchronously.
+	HEADER_FISSION_ASYNC = "X-Fission-Async"
+	// HEADER_FISSION_CALLBACK_URL is the URL router posts the result of an async
+	// invocation to, its host has to be in the AsyncCallbackHosts of trigger.
+	HEADER_FISSION_CALLBACK_URL      = "X-Fission-Callback-Url"
+	HEADER_FISSION_INVOCATION_ID     = "X-Fission-Invocation-Id"
+	HEADER_FISSION_INVOCATION_STATUS = "X-Fission-Invocation-Status"
+	// HEADER_FISSION_INVOCATION_TOKEN carries the token of an async invocation
+	// replied with 202, which is required to read its status and result.
+	HEADER_FISSION_INVOCATION_TOKEN = "X-Fission-Invocation-Token"
+
+	asyncInvocationPrefix = "/fission-async/invocations/"
+
+	// maxAsyncBodyBytes is the size limit of the request and response
+	// bodies of async invocations, which are buffered in router. The
+	// request is kept until the function responded and the response
+	// until the result expires, both have to fit in a secret.
+	maxAsyncBodyBytes = 512 << 10
+
+	defaultAsyncWorkers    = 10
+	defaultAsyncQueueSize  = 1000
+	defaultAsyncResultTTL  = time.Hour
+	asyncInvocationEntries = 10000
+
+	// asyncRecoveryInterval is how often router looks for the invocations
+	// left by the routers that are gone, and deletes the expired ones.
+	asyncRecoveryInterval = time.Minute
+
+	callbackTimeout    = 10 * time.Second
+	callbackMaxRetries = 3
+
+	rejectReasonAsyncQueueFull = "async-queue-full"
+
+	invocationQueued    invocationStatus = "queued"
+	invocationRunning   invocationStatus = "running"
+	invocationSucceeded invocationStatus = "succeeded"
+	invocationFailed    invocationStatus = "failed"
+)
+
+type (
+	invocationStatus string
+
+	// invocation is the state of an async invocation, and its result once
+	// the function responded.
+	invocation struct {
+		ID         string           `json:"id"`
+		Namespace  string           `json:"namespace"`
+		Trigger    string           `json:"trigger,omitempty"`
+		Function   string           `json:"function,omitempty"`
+		Status     invocationStatus `json:"status"`
+		StatusCode int              `json:"statusCode,omitempty"`
+		CreatedAt  time.Time        `json:"createdAt"`
+		StartedAt  *time.Time       `json:"startedAt,omitempty"`
+		FinishedAt *time.Time       `json:"finishedAt,omitempty"`
+
+		// handler is the key of the route that runs the invocation, see asyncHandlerKey.
+		handler string
+		// subject is the authenticated subject of the request that queued the
+		// invocation, only the same subject can read its status and result.
+		subject string
+		// tokenHash is the SHA-256 of the token of invocation, in hex.
+		tokenHash   string
+		callbackURL string
+		// request is kept until the function responded, so that another
+		// router can run the invocation if this one is gone.
+		request *asyncRequest
+
+		header http.Header
+		body   []byte
+	}
+
+	// asyncRequest is the request of a queued invocation.
+	asyncRequest struct {
+		Method     string            `json:"method"`
+		URL        string            `json:"url"`
+		Host       string            `json:"host,omitempty"`
+		Header     http.Header       `json:"header,omitempty"`
+		PathParams map[string]string `json:"pathParams,omitempty"`
+		Body       []byte            `json:"body,omitempty"`
+	}
+
+	// invocationStore keeps the async invocations until their results expire.
+	invocationStore interface {
+		put(inv *invocation) error
+		get(id string) (*invocation, bool)
+	}
+
+	// invocationRecoverer is implemented by the stores shared by routers.
+	invocationRecoverer interface {
+		// claimOrphans takes over the unfinished invocations of the routers
+		// that are gone, and returns them.
+		claimOrphans() []*invocation
+		// unclaim gives up a claimed invocation, for a later claim
+		// by this or another router.
+		unclaim(inv *invocation) error
+		// expire deletes the invocations whose results expired.
+		expire()
+	}
+
+	// memoryInvocationStore keeps the invocations in memory of router. They are
+	// lost when router restarts, and are only visible to the router replica
+	// that accepted the invocation. It's used when router can't use secrets.
+	// The results expire after the TTL since the invocation was queued.
+	memoryInvocationStore struct {
+		cache *cache.Cache
+		ttl   time.Duration
+	}
+
+	memoryInvocation struct {
+		sync.Mutex
+		inv invocation
+	}
+
+	asyncJob struct {
+		fh  functionHandler
+		inv *invocation
+		// release frees the slot the invocation takes in MaxInFlight of trigger.
+		release func()
+	}
+
+	// asyncInvoker runs the queued async invocations with a fixed number of workers.
+	asyncInvoker struct {
+		logger *zap.Logger
+		store  invocationStore
+		queue  chan *asyncJob
+		client *http.Client
+
+		// callbackIPAllowed checks the address a callback connects to.
+		callbackIPAllowed func(ip net.IP) bool
+
+		handlersLock sync.RWMutex
+		handlers     map[string]functionHandler
+	}
+
+	// asyncResponseWriter keeps the response of function for an async invocation.
+	asyncResponseWriter struct {
+		header    http.Header
+		status    int
+		body      bytes.Buffer
+		truncated bool
+	}
+)
+
+// credentialHeaders carry the credentials of client, they aren't kept with
+// the request of an async invocation, which is stored until it's invoked.
+// The function gets the auth info router set once the request was
+// authenticated instead.
+var credentialHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	defaultAPIKeyHeader,
+	defaultHMACHeader,
+	hmacTimestampHeader,
+}
+
+// privateNetworks are the networks callbacks can't connect to, in addition to
+// the loopback, link-local, unspecified and multicast addresses.
+var privateNetworks = func() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7"} {
+		_, n, _ := net.ParseCIDR(cidr)
+		nets = append(nets, n)
+	}
+	return nets
+}()
+
+func makeMemoryInvocationStore(ttl time.Duration) *memoryInvocationStore {
+	return &memoryInvocationStore{
+		cache: cache.MakeLRUCache(0, 0, asyncInvocationEntries),
+		ttl:   ttl,
+	}
+}
+
+// put updates the stored invocation in place, so that it's never missing
+// from the cache for the status requests.
+func (s *memoryInvocationStore) put(inv *invocation) error {
+	entry := &memoryInvocation{inv: *inv}
+	existing, err := s.cache.SetWithTTL(inv.ID, entry, s.ttl)
+	if err != nil && existing != nil {
+		entry = existing.(*memoryInvocation)
+		entry.Lock()
+		entry.inv = *inv
+		entry.Unlock()
+	}
+	return nil
+}
+
+func (s *memoryInvocationStore) get(id string) (*invocation, bool) {
+	v, err := s.cache.Get(id)
+	if err != nil {
+		return nil, false
+	}
+	entry := v.(*memoryInvocation)
+	entry.Lock()
+	defer entry.Unlock()
+	inv := entry.inv
+	return &inv, true
+}
+
+func makeAsyncInvoker(logger *zap.Logger, store invocationStore, workers int, queueSize int) *asyncInvoker {
+	ai := &asyncInvoker{
+		logger:            logger.Named("async_invoker"),
+		store:             store,
+		queue:             make(chan *asyncJob, queueSize),
+		callbackIPAllowed: isPublicIP,
+		handlers:          make(map[string]functionHandler),
+	}
+	dialer := &net.Dialer{
+		Timeout: callbackTimeout,
+		// check the address after the host is resolved, so that a
+		// public host name can't point callbacks to the cluster network
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !ai.callbackIPAllowed(ip) {
+				return fmt.Errorf("callback to address %v is not allowed", host)
+			}
+			return nil
+		},
+	}
+	ai.client = &http.Client{
+		Timeout: callbackTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: callbackTimeout,
+		},
+		// a redirect could lead to a host out of the allowlist
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	for i := 0; i < workers; i++ {
+		go ai.worker()
+	}
+	if _, ok := store.(invocationRecoverer); ok {
+		go ai.recoverLoop()
+	}
+	return ai
+}
+
+// isAsyncRequest returns true if client asks for an async invocation.
+func isAsyncRequest(req *http.Request) bool {
+	async, _ := strconv.ParseBool(req.Header.Get(HEADER_FISSION_ASYNC))
+	return async
+}
+
+// isPublicIP returns false for the addresses of loopback, private
+// and link-local networks, which callbacks can't connect to.
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, n := range privateNetworks {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// callbackHostAllowed returns true if host is in the allowlist, where
+// "*.example.com" matches the subdomains of example.com.
+func callbackHostAllowed(allowed []string, host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, h := range allowed {
+		h = strings.ToLower(h)
+		if strings.HasPrefix(h, "*.") {
+			if strings.HasSuffix(host, h[1:]) {
+				return true
+			}
+		} else if host == h {
+			return true
+		}
+	}
+	return false
+}
+
+// asyncHandlerKey returns the key of the route of a trigger, function or
+// function alias, which identifies it across routers.
+func asyncHandlerKey(kind string, meta *metav1.ObjectMeta) string {
+	return fmt.Sprintf("%v/%v/%v", kind, meta.Namespace, meta.Name)
+}
+
+// setHandlers replaces the handlers of routes, which run the invocations
+// recovered from other routers and authenticate the status requests.
+func (ai *asyncInvoker) setHandlers(handlers map[string]functionHandler) {
+	ai.handlersLock.Lock()
+	defer ai.handlersLock.Unlock()
+	ai.handlers = handlers
+}
+
+func (ai *asyncInvoker) getHandler(key string) (functionHandler, bool) {
+	ai.handlersLock.RLock()
+	defer ai.handlersLock.RUnlock()
+	fh, ok := ai.handlers[key]
+	return fh, ok
+}
+
+// enqueue queues the request for the function of handler, and replies 202
+// Accepted with the invocation ID to client. The slot the request takes in
+// MaxInFlight of trigger is released by calling release once the function
+// responded.
+func (ai *asyncInvoker) enqueue(rw http.ResponseWriter, req *http.Request, fh functionHandler, release func()) {
+	queued := false
+	defer func() {
+		if !queued {
+			release()
+		}
+	}()
+
+	var trigger string
+	if fh.httpTrigger != nil {
+		trigger = fh.httpTrigger.ObjectMeta.Name
+	}
+
+	callbackURL := req.Header.Get(HEADER_FISSION_CALLBACK_URL)
+	if len(callbackURL) > 0 {
+		if err := ai.checkCallbackURL(fh, callbackURL); err != nil {
+			writeErrorResponse(rw, http.StatusBadRequest, err.Error(), trigger)
+			return
+		}
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(io.LimitReader(req.Body, maxAsyncBodyBytes+1))
+		req.Body.Close()
+		if bodyLimitExceeded(req.Body) {
+			fh.recordRejection(req, rejectReasonBodyTooLarge)
+			fh.writeBodyTooLarge(rw)
+			return
+		}
+		if err != nil {
+			writeErrorResponse(rw, http.StatusBadRequest, "error reading request body", trigger)
+			return
+		}
+		if len(body) > maxAsyncBodyBytes {
+			fh.recordRejection(req, rejectReasonBodyTooLarge)
+			writeErrorResponse(rw, http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("request body of async invocation exceeds the limit of %v bytes", maxAsyncBodyBytes), trigger)
+			return
+		}
+	}
+
+	header := copyHeader(req.Header)
+	header.Del(HEADER_FISSION_ASYNC)
+	header.Del(HEADER_FISSION_CALLBACK_URL)
+	for _, name := range credentialHeaders {
+		header.Del(name)
+	}
+	if fh.auth != nil && len(fh.auth.spec.Header) > 0 {
+		header.Del(fh.auth.spec.Header)
+	}
+
+	token, err := newInvocationToken()
+	if err != nil {
+		ai.logger.Error("error generating async invocation token", zap.Error(err))
+		writeErrorResponse(rw, http.StatusInternalServerError, "error generating async invocation token", trigger)
+		return
+	}
+
+	inv := &invocation{
+		ID:          uuid.NewV4().String(),
+		Trigger:     trigger,
+		Status:      invocationQueued,
+		CreatedAt:   time.Now(),
+		handler:     fh.asyncKey,
+		subject:     req.Header.Get(HEADERS_FISSION_AUTH_PREFIX + "Subject"),
+		tokenHash:   hashInvocationToken(token),
+		callbackURL: callbackURL,
+		request: &asyncRequest{
+			Method:     req.Method,
+			URL:        req.URL.String(),
+			Host:       req.Host,
+			Header:     header,
+			PathParams: mux.Vars(req),
+			Body:       body,
+		},
+	}
+	// the function of a canary trigger is chosen when it's invoked
+	if fh.function != nil {
+		inv.Namespace, inv.Function = fh.function.ObjectMeta.Namespace, fh.function.ObjectMeta.Name
+	} else if fh.httpTrigger != nil {
+		inv.Namespace = fh.httpTrigger.ObjectMeta.Namespace
+	}
+	if err := ai.store.put(inv); err != nil {
+		ai.logger.Error("error storing async invocation", zap.Error(err), zap.String("invocation", inv.ID))
+		writeErrorResponse(rw, http.StatusInternalServerError, "error storing async invocation", trigger)
+		return
+	}
+
+	select {
+	case ai.queue <- &asyncJob{fh: fh, inv: inv, release: release}:
+		queued = true
+	default:
+		ai.finish(inv, errorResult(http.StatusServiceUnavailable, "async invocation queue is full"))
+		fh.recordRejection(req, rejectReasonAsyncQueueFull)
+		rw.Header().Set("Retry-After", "1")
+		writeErrorResponse(rw, http.StatusServiceUnavailable, "async invocation queue is full", trigger)
+		return
+	}
+
+	statusURL := asyncInvocationPrefix + inv.ID
+	rw.Header().Set("Location", statusURL)
+	rw.Header().Set(HEADER_FISSION_INVOCATION_ID, inv.ID)
+	rw.Header().Set(HEADER_FISSION_INVOCATION_TOKEN, token)
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(rw).Encode(map[string]string{
+		"id":        inv.ID,
+		"token":     token,
+		"status":    string(invocationQueued),
+		"statusUrl": statusURL,
+		"resultUrl": statusURL + "/result",
+	})
+}
+
+// checkCallbackURL returns an error if the invocations of handler can't be
+// called back at callbackURL. The address a host name resolves to is
+// checked again when router connects to it.
+func (ai *asyncInvoker) checkCallbackURL(fh functionHandler, callbackURL string) error {
+	u, err := url.Parse(callbackURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return fmt.Errorf("invalid callback url")
+	}
+	if fh.httpTrigger == nil || !callbackHostAllowed(fh.httpTrigger.Spec.AsyncCallbackHosts, u.Hostname()) {
+		return fmt.Errorf("callback host %v is not allowed by the trigger", u.Hostname())
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !ai.callbackIPAllowed(ip) {
+		return fmt.Errorf("callback to address %v is not allowed", ip)
+	}
+	return nil
+}
+
+func (ai *asyncInvoker) worker() {
+	for job := range ai.queue {
+		ai.run(job)
+	}
+}
+
+// recoverLoop takes over the invocations left by the routers that are gone
+// and deletes the expired invocations, until router exits.
+func (ai *asyncInvoker) recoverLoop() {
+	recoverer := ai.store.(invocationRecoverer)
+	for {
+		for _, inv := range recoverer.claimOrphans() {
+			ai.recover(recoverer, inv)
+		}
+		recoverer.expire()
+		time.Sleep(asyncRecoveryInterval)
+	}
+}
+
+// recover queues an invocation taken over from another router. The
+// invocations that were running when the router was gone are failed
+// rather than invoked again, an invocation runs at most once. The
+// invocation is unclaimed if the queue is full, to be recovered later.
+func (ai *asyncInvoker) recover(recoverer invocationRecoverer, inv *invocation) {
+	if inv.Status != invocationQueued || inv.request == nil {
+		ai.finish(inv, errorResult(http.StatusBadGateway, "router stopped while invoking the function"))
+		return
+	}
+	fh, ok := ai.getHandler(inv.handler)
+	if !ok {
+		ai.finish(inv, errorResult(http.StatusNotFound, "route of the invocation not found"))
+		return
+	}
+	select {
+	case ai.queue <- &asyncJob{fh: fh, inv: inv, release: func() {}}:
+		ai.logger.Info("recovered async invocation", zap.String("invocation", inv.ID), zap.String("handler", inv.handler))
+	default:
+		if err := recoverer.unclaim(inv); err != nil {
+			ai.logger.Error("error unclaiming async invocation", zap.Error(err), zap.String("invocation", inv.ID))
+		}
+	}
+}
+
+// run invokes the function of job and stores the result.
+func (ai *asyncInvoker) run(job *asyncJob) {
+	inv := job.inv
+	started := time.Now()
+	inv.Status, inv.StartedAt = invocationRunning, &started
+	if err := ai.store.put(inv); err != nil {
+		ai.logger.Error("error storing async invocation", zap.Error(err), zap.String("invocation", inv.ID))
+	}
+
+	rec := &asyncResponseWriter{}
+	job.fh.invokeAsync(rec, inv.request.httpRequest(), inv.ID)
+	job.release()
+	ai.finish(inv, rec)
+
+	if len(inv.callbackURL) > 0 {
+		ai.callback(inv.callbackURL, inv)
+	}
+}
+
+// finish stores the result of invocation, the request isn't kept anymore.
+func (ai *asyncInvoker) finish(inv *invocation, rec *asyncResponseWriter) {
+	finished := time.Now()
+	inv.FinishedAt = &finished
+	inv.StatusCode = rec.statusCode()
+	inv.header, inv.body = rec.Header(), rec.body.Bytes()
+	inv.request = nil
+	inv.Status = invocationSucceeded
+	if inv.StatusCode >= 500 || rec.truncated {
+		inv.Status = invocationFailed
+	}
+	if err := ai.store.put(inv); err != nil {
+		ai.logger.Error("error storing result of async invocation", zap.Error(err), zap.String("invocation", inv.ID))
+	}
+}
+
+// callback posts the result of invocation to the callback URL.
+func (ai *asyncInvoker) callback(callbackURL string, inv *invocation) {
+	delay := 500 * time.Millisecond
+	for i := 0; i < callbackMaxRetries; i++ {
+		req, err := http.NewRequest(http.MethodPost, callbackURL, bytes.NewReader(inv.body))
+		if err != nil {
+			ai.logger.Error("error creating callback request", zap.Error(err), zap.String("invocation", inv.ID))
+			return
+		}
+		req.Header.Set("Content-Type", inv.header.Get("Content-Type"))
+		req.Header.Set(HEADER_FISSION_INVOCATION_ID, inv.ID)
+		req.Header.Set(HEADER_FISSION_INVOCATION_STATUS, string(inv.Status))
+		req.Header.Set("X-Fission-Function-Status-Code", strconv.Itoa(inv.StatusCode))
+
+		resp, err := ai.client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < 500 {
+				return
+			}
+			err = fmt.Errorf("callback returned status code %v", resp.StatusCode)
+		}
+		ai.logger.Error("error calling back async invocation result", zap.Error(err),
+			zap.String("invocation", inv.ID), zap.Int("retry", i))
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// statusHandler replies the state of an async invocation, or its result
+// if the path ends with "/result". The request has to carry the token of
+// invocation in the X-Fission-Invocation-Token header, and is authenticated
+// by the trigger of invocation. An invocation queued by an authenticated
+// subject is only visible to the same subject.
+func (ai *asyncInvoker) statusHandler(rw http.ResponseWriter, req *http.Request) {
+	inv, ok := ai.store.get(mux.Vars(req)["id"])
+	if !ok {
+		writeErrorResponse(rw, http.StatusNotFound, "invocation not found or its result expired", "")
+		return
+	}
+
+	fh, found := ai.getHandler(inv.handler)
+	if found && !fh.authenticateRequest(rw, req) {
+		return
+	}
+	if !found {
+		removeAuthInfoFromHeader(req)
+	}
+	token := hashInvocationToken(req.Header.Get(HEADER_FISSION_INVOCATION_TOKEN))
+	if subtle.ConstantTimeCompare([]byte(token), []byte(inv.tokenHash)) != 1 ||
+		req.Header.Get(HEADERS_FISSION_AUTH_PREFIX+"Subject") != inv.subject {
+		// don't tell whether the invocation exists
+		writeErrorResponse(rw, http.StatusNotFound, "invocation not found or its result expired", "")
+		return
+	}
+
+	rw.Header().Set(HEADER_FISSION_INVOCATION_ID, inv.ID)
+	rw.Header().Set(HEADER_FISSION_INVOCATION_STATUS, string(inv.Status))
+
+	finished := inv.FinishedAt != nil
+	if strings.HasSuffix(req.URL.Path, "/result") && finished {
+		for k, v := range copyHeader(inv.header) {
+			rw.Header()[k] = v
+		}
+		rw.WriteHeader(inv.StatusCode)
+		rw.Write(inv.body)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	if !finished {
+		rw.WriteHeader(http.StatusAccepted)
+	}
+	json.NewEncoder(rw).Encode(inv)
+}
+
+// newInvocationToken returns a random token for an async invocation.
+func newInvocationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashInvocationToken returns the hash of token kept with the invocation,
+// the token itself isn't stored.
+func hashInvocationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// httpRequest returns the request to invoke the function with, which isn't
+// canceled with the request that queued it.
+func (r *asyncRequest) httpRequest() *http.Request {
+	req, err := http.NewRequest(r.Method, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		// the URL was parsed by router when the request was queued
+		req, _ = http.NewRequest(r.Method, "/", bytes.NewReader(r.Body))
+	}
+	req = req.WithContext(context.Background())
+	req.RequestURI = r.URL
+	req.Host = r.Host
+	req.Header = copyHeader(r.Header)
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+	return mux.SetURLVars(req, r.PathParams)
+}
+
+// errorResult returns the result of an invocation router failed to run.
+func errorResult(code int, msg string) *asyncResponseWriter {
+	rec := &asyncResponseWriter{}
+	rec.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	rec.WriteHeader(code)
+	rec.Write([]byte(msg))
+	return rec
+}
+
+func (w *asyncResponseWriter) Header() http.Header {
+	if w.header == nil {
+		w.header = make(http.Header)
+	}
+	return w.header
+}
+
+func (w *asyncResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *asyncResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.body.Len()+len(p) > maxAsyncBodyBytes {
+		w.truncated = true
+		w.body.Write(p[:maxAsyncBodyBytes-w.body.Len()])
+		return len(p), nil
+	}
+	return w.body.Write(p)
+}
+
+func (w *asyncResponseWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
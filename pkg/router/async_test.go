@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestAsyncInvocation(t *testing.T) {
+	unblock := make(chan struct{})
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+		// the credentials of client aren't kept, the auth info is
+		assert.Empty(t, r.Header.Get(defaultAPIKeyHeader))
+		assert.Empty(t, r.Header.Get("Cookie"))
+		assert.Equal(t, "team-a", r.Header.Get(HEADERS_FISSION_AUTH_PREFIX+"Subject"))
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello " + string(body) + " " + r.Header.Get(HEADERS_FISSION_PARAMS_PREFIX+"Name")))
+	}))
+	defer backend.Close()
+	backendURL, err := url.Parse(backend.URL)
+	assert.Nil(t, err)
+
+	type callback struct {
+		header http.Header
+		body   string
+	}
+	callbacks := make(chan callback, 1)
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		callbacks <- callback{r.Header, string(body)}
+	}))
+	defer callbackServer.Close()
+
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+
+	fnMeta := metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault, UID: "1"}
+
+	ai := makeAsyncInvoker(logger, makeMemoryInvocationStore(time.Minute), 1, 10)
+	// the callback server listens on loopback
+	ai.callbackIPAllowed = func(net.IP) bool { return true }
+
+	trigger := &fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault},
+		Spec: fv1.HTTPTriggerSpec{
+			RelativeURL:        "/foo/{name}",
+			AsyncCallbackHosts: []string{"127.0.0.1"},
+			RateLimit:          &fv1.RateLimit{MaxInFlight: 1},
+		},
+	}
+	fh := makeTestFunctionHandler(logger, fnMeta, backendURL)
+	fh.httpTrigger = trigger
+	fh.rateLimiter = newTriggerRateLimiter(*trigger.Spec.RateLimit)
+	fh.auth = makeTestAuthenticator(fv1.TriggerAuth{Type: fv1.TriggerAuthTypeAPIKey, SecretName: "keys"},
+		map[string][]byte{"team-a": []byte("secret-a"), "team-b": []byte("secret-b")})
+	fh.async = ai
+	fh.asyncKey = asyncHandlerKey("trigger", &trigger.ObjectMeta)
+	ai.setHandlers(map[string]functionHandler{fh.asyncKey: *fh})
+
+	muxRouter := mux.NewRouter()
+	muxRouter.HandleFunc("/foo/{name}", fh.handler)
+	muxRouter.HandleFunc(asyncInvocationPrefix+"{id}", ai.statusHandler)
+	muxRouter.HandleFunc(asyncInvocationPrefix+"{id}/result", ai.statusHandler)
+
+	serve := func(method, target, apiKey string, body io.Reader, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, body)
+		if len(apiKey) > 0 {
+			req.Header.Set(defaultAPIKeyHeader, apiKey)
+		}
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rr := httptest.NewRecorder()
+		muxRouter.ServeHTTP(rr, req)
+		return rr
+	}
+	async := map[string]string{HEADER_FISSION_ASYNC: "true"}
+
+	rr := serve(http.MethodPost, "/foo/bar", "secret-a", strings.NewReader("world"), map[string]string{
+		HEADER_FISSION_ASYNC:        "true",
+		HEADER_FISSION_CALLBACK_URL: callbackServer.URL,
+		"Cookie":                    "session=abcd",
+	})
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+
+	var accepted map[string]string
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &accepted))
+	assert.Equal(t, accepted["id"], rr.Header().Get(HEADER_FISSION_INVOCATION_ID))
+	assert.Equal(t, asyncInvocationPrefix+accepted["id"], rr.Header().Get("Location"))
+	assert.Equal(t, accepted["token"], rr.Header().Get(HEADER_FISSION_INVOCATION_TOKEN))
+	token := map[string]string{HEADER_FISSION_INVOCATION_TOKEN: accepted["token"]}
+
+	// the invocation keeps its MaxInFlight slot until the function responded
+	rr = serve(http.MethodPost, "/foo/bar", "secret-a", nil, async)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+
+	// only the subject that queued the invocation can see it, with its token
+	rr = serve(http.MethodGet, accepted["statusUrl"], "secret-a", nil, nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serve(http.MethodGet, accepted["statusUrl"], "secret-a", nil,
+		map[string]string{HEADER_FISSION_INVOCATION_TOKEN: "abcd"})
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serve(http.MethodGet, accepted["statusUrl"], "", nil, token)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	rr = serve(http.MethodGet, accepted["resultUrl"], "secret-b", nil, token)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	rr = serve(http.MethodGet, accepted["statusUrl"], "secret-a", nil, token)
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+
+	close(unblock)
+	select {
+	case cb := <-callbacks:
+		assert.Equal(t, "hello world bar", cb.body)
+		assert.Equal(t, accepted["id"], cb.header.Get(HEADER_FISSION_INVOCATION_ID))
+		assert.Equal(t, string(invocationSucceeded), cb.header.Get(HEADER_FISSION_INVOCATION_STATUS))
+		assert.Equal(t, "201", cb.header.Get("X-Fission-Function-Status-Code"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("no callback for async invocation")
+	}
+
+	rr = serve(http.MethodGet, accepted["statusUrl"], "secret-a", nil, token)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var inv invocation
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &inv))
+	assert.Equal(t, invocationSucceeded, inv.Status)
+	assert.Equal(t, http.StatusCreated, inv.StatusCode)
+	assert.Equal(t, "foo", inv.Function)
+
+	rr = serve(http.MethodGet, accepted["resultUrl"], "secret-a", nil, token)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "hello world bar", rr.Body.String())
+	assert.Equal(t, "text/plain", rr.Header().Get("Content-Type"))
+
+	rr = serve(http.MethodGet, asyncInvocationPrefix+"unknown", "secret-a", nil, nil)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	for _, callbackURL := range []string{"ftp://127.0.0.1", "http://example.com/hook"} {
+		rr = serve(http.MethodPost, "/foo/bar", "secret-a", nil, map[string]string{
+			HEADER_FISSION_ASYNC:        "true",
+			HEADER_FISSION_CALLBACK_URL: callbackURL,
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code, callbackURL)
+	}
+}
+
+func TestAsyncCallbackGuard(t *testing.T) {
+	for _, test := range []struct {
+		ip     string
+		public bool
+	}{
+		{"8.8.8.8", true},
+		{"2001:4860:4860::8888", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.20.0.1", false},
+		{"192.168.1.1", false},
+		{"100.64.0.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+	} {
+		assert.Equal(t, test.public, isPublicIP(net.ParseIP(test.ip)), test.ip)
+	}
+
+	allowed := []string{"hooks.example.com", "*.example.org"}
+	assert.True(t, callbackHostAllowed(allowed, "hooks.example.com"))
+	assert.True(t, callbackHostAllowed(allowed, "HOOKS.example.com."))
+	assert.True(t, callbackHostAllowed(allowed, "a.b.example.org"))
+	assert.False(t, callbackHostAllowed(allowed, "example.org"))
+	assert.False(t, callbackHostAllowed(allowed, "evilexample.org"))
+	assert.False(t, callbackHostAllowed(allowed, "example.com"))
+	assert.False(t, callbackHostAllowed(nil, "hooks.example.com"))
+
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+	ai := makeAsyncInvoker(logger, makeMemoryInvocationStore(time.Minute), 0, 1)
+
+	fh := functionHandler{httpTrigger: &fv1.HTTPTrigger{
+		Spec: fv1.HTTPTriggerSpec{AsyncCallbackHosts: []string{"127.0.0.1", "localhost"}},
+	}}
+	assert.NotNil(t, ai.checkCallbackURL(fh, "http://127.0.0.1/hook"))
+	assert.Nil(t, ai.checkCallbackURL(fh, "http://localhost/hook"))
+	assert.NotNil(t, ai.checkCallbackURL(functionHandler{}, "http://localhost/hook"))
+
+	// a host name resolving to a private address is rejected on connect
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	_, err = ai.client.Get(server.URL)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	asyncSecretPrefix = "fission-async-"
+
+	asyncInvocationLabel = "fission.io/async-invocation"
+	// asyncOwnerLabel is the name of router pod running the invocation.
+	asyncOwnerLabel = "fission.io/async-owner"
+	// asyncStatusLabel is the status of invocation, the unfinished
+	// invocations of routers that are gone are found by it.
+	asyncStatusLabel = "fission.io/async-status"
+	// asyncExpiresAnnotation is the unix time the invocation expires at.
+	asyncExpiresAnnotation = "fission.io/async-expires-at"
+
+	asyncSecretInvocationKey = "invocation"
+	asyncSecretRequestKey    = "request"
+	asyncSecretResultKey     = "result"
+
+	asyncListPageSize = 100
+)
+
+type (
+	// secretInvocationStore keeps each invocation in a secret in the namespace
+	// of router, so that it's visible to all router replicas and survives
+	// router restarts. Secrets are used since requests and results may carry
+	// sensitive data, though the credentials of client aren't kept.
+	//
+	// Each invocation is owned by the router that runs it. A router takes
+	// over the unfinished invocations of the routers whose pods are gone,
+	// runs the queued ones and fails the running ones, so an invocation
+	// runs at most once.
+	secretInvocationStore struct {
+		logger    *zap.Logger
+		client    kubernetes.Interface
+		namespace string
+		owner     string
+		ttl       time.Duration
+
+		// the invocations of owner itself are only orphans when it starts
+		recoveredOwnLock sync.Mutex
+		recoveredOwn     bool
+	}
+
+	// storedInvocation is the invocation kept in secret
+	storedInvocation struct {
+		invocation
+		Handler      string      `json:"handler,omitempty"`
+		Subject      string      `json:"subject,omitempty"`
+		TokenHash    string      `json:"tokenHash,omitempty"`
+		CallbackURL  string      `json:"callbackUrl,omitempty"`
+		ResultHeader http.Header `json:"resultHeader,omitempty"`
+	}
+)
+
+func makeSecretInvocationStore(logger *zap.Logger, client kubernetes.Interface, namespace string, owner string, ttl time.Duration) *secretInvocationStore {
+	return &secretInvocationStore{
+		logger:    logger.Named("async_invocation_store"),
+		client:    client,
+		namespace: namespace,
+		owner:     owner,
+		ttl:       ttl,
+	}
+}
+
+func (s *secretInvocationStore) secretName(id string) string {
+	return asyncSecretPrefix + id
+}
+
+func (s *secretInvocationStore) put(inv *invocation) error {
+	secret, err := s.toSecret(inv)
+	if err != nil {
+		return err
+	}
+
+	secrets := s.client.CoreV1().Secrets(s.namespace)
+	_, err = secrets.Create(secret)
+	if !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := secrets.Get(secret.ObjectMeta.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		secret.ObjectMeta.ResourceVersion = current.ObjectMeta.ResourceVersion
+		_, err = secrets.Update(secret)
+		return err
+	})
+}
+
+func (s *secretInvocationStore) get(id string) (*invocation, bool) {
+	secret, err := s.client.CoreV1().Secrets(s.namespace).Get(s.secretName(id), metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			s.logger.Error("error getting async invocation", zap.Error(err), zap.String("invocation", id))
+		}
+		return nil, false
+	}
+	if s.expired(secret, time.Now()) {
+		return nil, false
+	}
+	inv, err := s.fromSecret(secret)
+	if err != nil {
+		s.logger.Error("error decoding async invocation", zap.Error(err), zap.String("invocation", id))
+		return nil, false
+	}
+	return inv, true
+}
+
+// claimOrphans takes over the unfinished invocations whose owners are
+// gone, the claim fails if another router updated the invocation first.
+func (s *secretInvocationStore) claimOrphans() []*invocation {
+	s.recoveredOwnLock.Lock()
+	recoverOwn := !s.recoveredOwn
+	s.recoveredOwn = true
+	s.recoveredOwnLock.Unlock()
+
+	selector := fmt.Sprintf("%v=true,%v in (%v,%v)", asyncInvocationLabel, asyncStatusLabel, invocationQueued, invocationRunning)
+	list, err := s.client.CoreV1().Secrets(s.namespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		s.logger.Error("error listing unfinished async invocations", zap.Error(err))
+		return nil
+	}
+
+	alive := map[string]bool{s.owner: !recoverOwn}
+	var orphans []*invocation
+	for i := range list.Items {
+		secret := &list.Items[i]
+		owner := secret.ObjectMeta.Labels[asyncOwnerLabel]
+		ownerAlive, ok := alive[owner]
+		if !ok {
+			ownerAlive, err = s.podExists(owner)
+			if err != nil {
+				s.logger.Error("error checking router of async invocations", zap.Error(err), zap.String("router", owner))
+				continue
+			}
+			alive[owner] = ownerAlive
+		}
+		if ownerAlive {
+			continue
+		}
+
+		inv, err := s.fromSecret(secret)
+		if err != nil {
+			s.logger.Error("error decoding async invocation", zap.Error(err), zap.String("secret", secret.ObjectMeta.Name))
+			continue
+		}
+		// the resource version of listed secret makes the update
+		// fail if another router claimed the invocation
+		secret.ObjectMeta.Labels[asyncOwnerLabel] = s.owner
+		_, err = s.client.CoreV1().Secrets(s.namespace).Update(secret)
+		if err != nil {
+			if !k8serrors.IsConflict(err) {
+				s.logger.Error("error claiming async invocation", zap.Error(err), zap.String("invocation", inv.ID))
+			}
+			continue
+		}
+		orphans = append(orphans, inv)
+	}
+	return orphans
+}
+
+// unclaim clears the owner of an invocation claimed by router, which makes
+// it an orphan again.
+func (s *secretInvocationStore) unclaim(inv *invocation) error {
+	secrets := s.client.CoreV1().Secrets(s.namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := secrets.Get(s.secretName(inv.ID), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if secret.ObjectMeta.Labels[asyncOwnerLabel] != s.owner {
+			return nil
+		}
+		secret.ObjectMeta.Labels[asyncOwnerLabel] = ""
+		_, err = secrets.Update(secret)
+		return err
+	})
+}
+
+// expire deletes the invocations whose results expired.
+func (s *secretInvocationStore) expire() {
+	secrets := s.client.CoreV1().Secrets(s.namespace)
+	now := time.Now()
+	opts := metav1.ListOptions{LabelSelector: asyncInvocationLabel + "=true", Limit: asyncListPageSize}
+	for {
+		list, err := secrets.List(opts)
+		if err != nil {
+			s.logger.Error("error listing async invocations", zap.Error(err))
+			return
+		}
+		for i := range list.Items {
+			secret := &list.Items[i]
+			if !s.expired(secret, now) {
+				continue
+			}
+			err := secrets.Delete(secret.ObjectMeta.Name, &metav1.DeleteOptions{})
+			if err != nil && !k8serrors.IsNotFound(err) {
+				s.logger.Error("error deleting expired async invocation", zap.Error(err),
+					zap.String("secret", secret.ObjectMeta.Name))
+			}
+		}
+		if len(list.ListMeta.Continue) == 0 {
+			return
+		}
+		opts.Continue = list.ListMeta.Continue
+	}
+}
+
+func (s *secretInvocationStore) podExists(name string) (bool, error) {
+	if len(name) == 0 {
+		return false, nil
+	}
+	_, err := s.client.CoreV1().Pods(s.namespace).Get(name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *secretInvocationStore) expired(secret *apiv1.Secret, now time.Time) bool {
+	expiresAt, err := strconv.ParseInt(secret.ObjectMeta.Annotations[asyncExpiresAnnotation], 10, 64)
+	return err == nil && now.Unix() > expiresAt
+}
+
+func (s *secretInvocationStore) toSecret(inv *invocation) (*apiv1.Secret, error) {
+	stored := storedInvocation{
+		invocation:   *inv,
+		Handler:      inv.handler,
+		Subject:      inv.subject,
+		TokenHash:    inv.tokenHash,
+		CallbackURL:  inv.callbackURL,
+		ResultHeader: inv.header,
+	}
+	data := make(map[string][]byte)
+	var err error
+	data[asyncSecretInvocationKey], err = json.Marshal(stored)
+	if err != nil {
+		return nil, errors.Wrap(err, "error encoding invocation")
+	}
+	if inv.request != nil {
+		data[asyncSecretRequestKey], err = json.Marshal(inv.request)
+		if err != nil {
+			return nil, errors.Wrap(err, "error encoding request of invocation")
+		}
+	}
+	if inv.body != nil {
+		data[asyncSecretResultKey] = inv.body
+	}
+
+	return &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.secretName(inv.ID),
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				asyncInvocationLabel: "true",
+				asyncOwnerLabel:      s.owner,
+				asyncStatusLabel:     string(inv.Status),
+			},
+			Annotations: map[string]string{
+				asyncExpiresAnnotation: strconv.FormatInt(time.Now().Add(s.ttl).Unix(), 10),
+			},
+		},
+		Data: data,
+	}, nil
+}
+
+func (s *secretInvocationStore) fromSecret(secret *apiv1.Secret) (*invocation, error) {
+	var stored storedInvocation
+	err := json.Unmarshal(secret.Data[asyncSecretInvocationKey], &stored)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding invocation")
+	}
+	inv := stored.invocation
+	inv.handler, inv.subject, inv.callbackURL = stored.Handler, stored.Subject, stored.CallbackURL
+	inv.tokenHash = stored.TokenHash
+	inv.header = stored.ResultHeader
+	inv.body = secret.Data[asyncSecretResultKey]
+	if req, ok := secret.Data[asyncSecretRequestKey]; ok {
+		inv.request = &asyncRequest{}
+		err = json.Unmarshal(req, inv.request)
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding request of invocation")
+		}
+	}
+	return &inv, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSecretInvocationStore(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+
+	ns := "fission"
+	client := fake.NewSimpleClientset(&apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "router-a", Namespace: ns}})
+	routerA := makeSecretInvocationStore(logger, client, ns, "router-a", time.Minute)
+	routerB := makeSecretInvocationStore(logger, client, ns, "router-b", time.Minute)
+
+	inv := &invocation{
+		ID:          "1",
+		Namespace:   "default",
+		Function:    "foo",
+		Status:      invocationQueued,
+		CreatedAt:   time.Now(),
+		handler:     "trigger/default/foo",
+		subject:     "team-a",
+		tokenHash:   hashInvocationToken("abcd"),
+		callbackURL: "https://hooks.example.com",
+		request: &asyncRequest{
+			Method:     http.MethodPost,
+			URL:        "/foo/bar?x=1",
+			Header:     http.Header{"Content-Type": {"text/plain"}},
+			PathParams: map[string]string{"name": "bar"},
+			Body:       []byte("world"),
+		},
+	}
+	assert.Nil(t, routerA.put(inv))
+
+	// the other router sees the invocation
+	got, ok := routerB.get("1")
+	assert.True(t, ok)
+	assert.Equal(t, invocationQueued, got.Status)
+	assert.Equal(t, "trigger/default/foo", got.handler)
+	assert.Equal(t, "team-a", got.subject)
+	assert.Equal(t, inv.tokenHash, got.tokenHash)
+	assert.Equal(t, "https://hooks.example.com", got.callbackURL)
+	assert.Equal(t, inv.request, got.request)
+
+	req := got.request.httpRequest()
+	assert.Equal(t, "/foo/bar", req.URL.Path)
+	assert.Equal(t, "1", req.URL.Query().Get("x"))
+
+	// router-a is alive, and only takes over its own invocations when it starts
+	assert.Empty(t, routerB.claimOrphans())
+	orphans := routerA.claimOrphans()
+	assert.Len(t, orphans, 1)
+	assert.Empty(t, routerA.claimOrphans())
+
+	// router-a is gone
+	assert.Nil(t, client.CoreV1().Pods(ns).Delete("router-a", &metav1.DeleteOptions{}))
+	orphans = routerB.claimOrphans()
+	assert.Len(t, orphans, 1)
+	assert.Equal(t, "1", orphans[0].ID)
+	secret, err := client.CoreV1().Secrets(ns).Get(asyncSecretPrefix+"1", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "router-b", secret.ObjectMeta.Labels[asyncOwnerLabel])
+
+	// an invocation router can't queue is left for a later claim
+	ai := makeAsyncInvoker(logger, makeMemoryInvocationStore(time.Minute), 0, 0)
+	ai.setHandlers(map[string]functionHandler{"trigger/default/foo": {}})
+	ai.recover(routerB, orphans[0])
+	orphans = routerB.claimOrphans()
+	assert.Len(t, orphans, 1)
+	assert.Equal(t, "1", orphans[0].ID)
+
+	// a finished invocation keeps its result but not the request
+	finished := time.Now()
+	inv.Status, inv.StatusCode, inv.FinishedAt = invocationSucceeded, http.StatusOK, &finished
+	inv.header, inv.body, inv.request = http.Header{"Content-Type": {"text/plain"}}, []byte("hello"), nil
+	assert.Nil(t, routerB.put(inv))
+	got, ok = routerA.get("1")
+	assert.True(t, ok)
+	assert.Equal(t, invocationSucceeded, got.Status)
+	assert.Equal(t, "hello", string(got.body))
+	assert.Equal(t, "text/plain", got.header.Get("Content-Type"))
+	assert.Nil(t, got.request)
+	assert.Empty(t, routerA.claimOrphans())
+
+	// expired invocations are deleted
+	routerB.expire()
+	_, ok = routerA.get("1")
+	assert.True(t, ok)
+	expired := makeSecretInvocationStore(logger, client, ns, "router-b", -time.Minute)
+	assert.Nil(t, expired.put(inv))
+	_, ok = routerA.get("1")
+	assert.False(t, ok)
+	routerA.expire()
+	_, err = client.CoreV1().Secrets(ns).Get(asyncSecretPrefix+"1", metav1.GetOptions{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,278 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+const (
+	defaultAPIKeyHeader = "X-Api-Key"
+	defaultHMACHeader   = "X-Signature"
+
+	// hmacTimestampHeader carries the unix time the HMAC signature was
+	// made at, it's signed along with the body to prevent replays.
+	hmacTimestampHeader = "X-Signature-Timestamp"
+	// hmacTimestampTolerance is how far the signature timestamp can be
+	// from the router clock.
+	hmacTimestampTolerance = 5 * time.Minute
+	// hmacMaxBodySize is the largest body router reads to check its HMAC
+	// signature, a trigger with a lower body size limit is bound by it.
+	hmacMaxBodySize = 10 << 20
+
+	hmacSecretKey = "key"
+	jwksSecretKey = "jwks.json"
+
+	rejectReasonUnauthorized = "unauthorized"
+)
+
+type (
+	// secretGetter returns the Secret with the given namespace and name.
+	secretGetter func(namespace, name string) (*apiv1.Secret, error)
+
+	// errUnauthorized is returned when the request has no or invalid credentials,
+	// other errors mean router failed to check the credentials.
+	errUnauthorized struct {
+		error
+	}
+
+	// authenticator authenticates the requests of an HTTP trigger with the
+	// credentials in the Secret referenced by the trigger.
+	authenticator struct {
+		spec      fv1.TriggerAuth
+		namespace string
+		getSecret secretGetter
+
+		// parsed JWKS of the Secret at the resource version
+		lock            sync.Mutex
+		resourceVersion string
+		keys            []verificationKey
+	}
+)
+
+func unauthorized(format string, args ...interface{}) error {
+	return errUnauthorized{fmt.Errorf(format, args...)}
+}
+
+func makeAuthenticator(trigger *fv1.HTTPTrigger, getSecret secretGetter) *authenticator {
+	if trigger.Spec.Auth == nil {
+		return nil
+	}
+	return &authenticator{
+		spec:      *trigger.Spec.Auth,
+		namespace: trigger.ObjectMeta.Namespace,
+		getSecret: getSecret,
+	}
+}
+
+// authenticate checks the credentials of request, and returns the subject and
+// the claims to forward to the function if the request is authenticated.
+func (a *authenticator) authenticate(req *http.Request) (subject string, claims map[string]interface{}, err error) {
+	secret, err := a.getSecret(a.namespace, a.spec.SecretName)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "error getting auth secret %v/%v", a.namespace, a.spec.SecretName)
+	}
+
+	switch a.spec.Type {
+	case fv1.TriggerAuthTypeAPIKey:
+		subject, err = a.checkAPIKey(req, secret)
+	case fv1.TriggerAuthTypeHMAC:
+		err = a.checkHMAC(req, secret)
+	case fv1.TriggerAuthTypeBasic:
+		subject, err = a.checkBasicAuth(req, secret)
+	case fv1.TriggerAuthTypeJWT:
+		claims, err = a.checkJWT(req, secret)
+		if sub, ok := claims["sub"].(string); ok {
+			subject = sub
+		}
+	default:
+		err = errors.Errorf("unsupported auth type %q", a.spec.Type)
+	}
+	return subject, claims, err
+}
+
+func (a *authenticator) header(defaultHeader string) string {
+	if len(a.spec.Header) > 0 {
+		return a.spec.Header
+	}
+	return defaultHeader
+}
+
+func (a *authenticator) checkAPIKey(req *http.Request, secret *apiv1.Secret) (string, error) {
+	key := req.Header.Get(a.header(defaultAPIKeyHeader))
+	if len(key) == 0 {
+		return "", unauthorized("missing API key")
+	}
+	for name, value := range secret.Data {
+		if subtle.ConstantTimeCompare(bytes.TrimSpace(value), []byte(key)) == 1 {
+			return name, nil
+		}
+	}
+	return "", unauthorized("invalid API key")
+}
+
+func (a *authenticator) checkHMAC(req *http.Request, secret *apiv1.Secret) error {
+	key, ok := secret.Data[hmacSecretKey]
+	if !ok {
+		return errors.Errorf("key %q not found in secret", hmacSecretKey)
+	}
+
+	signature := strings.TrimPrefix(req.Header.Get(a.header(defaultHMACHeader)), "sha256=")
+	if len(signature) == 0 {
+		return unauthorized("missing signature")
+	}
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return unauthorized("malformed signature")
+	}
+
+	timestamp := req.Header.Get(hmacTimestampHeader)
+	if len(timestamp) == 0 {
+		return unauthorized("missing signature timestamp")
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return unauthorized("malformed signature timestamp")
+	}
+	if skew := time.Since(time.Unix(unix, 0)); skew > hmacTimestampTolerance || skew < -hmacTimestampTolerance {
+		return unauthorized("signature timestamp is out of tolerance")
+	}
+
+	// read the body to compute its signature, and put it back for the function
+	var body []byte
+	if req.Body != nil {
+		body, err = ioutil.ReadAll(io.LimitReader(req.Body, hmacMaxBodySize+1))
+		req.Body.Close()
+		if err != nil {
+			return errors.Wrap(err, "error reading request body")
+		}
+		if len(body) > hmacMaxBodySize {
+			return errRequestBodyTooLarge
+		}
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	// the signed content is "<timestamp>.<body>"
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), sig) {
+		return unauthorized("invalid signature")
+	}
+	return nil
+}
+
+func (a *authenticator) checkBasicAuth(req *http.Request, secret *apiv1.Secret) (string, error) {
+	username, password, ok := req.BasicAuth()
+	if !ok {
+		return "", unauthorized("missing basic auth credentials")
+	}
+	expected, ok := secret.Data[username]
+	if !ok || subtle.ConstantTimeCompare(expected, []byte(password)) != 1 {
+		return "", unauthorized("invalid username or password")
+	}
+	return username, nil
+}
+
+// jwks returns the parsed JWKS in the Secret, it's parsed again
+// only when the Secret changes.
+func (a *authenticator) jwks(secret *apiv1.Secret) ([]verificationKey, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.keys != nil && a.resourceVersion == secret.ObjectMeta.ResourceVersion {
+		return a.keys, nil
+	}
+
+	data, ok := secret.Data[jwksSecretKey]
+	if !ok {
+		return nil, errors.Errorf("key %q not found in secret", jwksSecretKey)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, err
+	}
+	a.keys, a.resourceVersion = keys, secret.ObjectMeta.ResourceVersion
+	return keys, nil
+}
+
+func (a *authenticator) checkJWT(req *http.Request, secret *apiv1.Secret) (map[string]interface{}, error) {
+	keys, err := a.jwks(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	authz := req.Header.Get("Authorization")
+	if len(authz) < 7 || !strings.EqualFold(authz[:7], "Bearer ") {
+		return nil, unauthorized("missing bearer token")
+	}
+
+	claims, err := verifyJWT(strings.TrimSpace(authz[7:]), keys, time.Now(), !a.spec.AllowNoExpiry)
+	if err != nil {
+		return nil, unauthorized("%v", err)
+	}
+	if len(a.spec.Issuer) > 0 && claims["iss"] != a.spec.Issuer {
+		return nil, unauthorized("unexpected token issuer")
+	}
+	if len(a.spec.Audience) > 0 && !hasAudience(claims, a.spec.Audience) {
+		return nil, unauthorized("unexpected token audience")
+	}
+	return claims, nil
+}
+
+// setAuthInfoToHeader sets the authenticated subject and the forwarded claims to request header.
+func (a *authenticator) setAuthInfoToHeader(req *http.Request, subject string, claims map[string]interface{}) {
+	req.Header.Set(fmt.Sprintf("%vType", HEADERS_FISSION_AUTH_PREFIX), string(a.spec.Type))
+	if len(subject) > 0 {
+		req.Header.Set(fmt.Sprintf("%vSubject", HEADERS_FISSION_AUTH_PREFIX), subject)
+	}
+	for _, name := range a.spec.ForwardClaims {
+		v, ok := claims[name]
+		if !ok {
+			continue
+		}
+		var value string
+		if s, ok := v.(string); ok {
+			value = s
+		} else {
+			b, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			value = string(b)
+		}
+		req.Header.Set(fmt.Sprintf("%vClaim-%v", HEADERS_FISSION_AUTH_PREFIX, name), value)
+	}
+}
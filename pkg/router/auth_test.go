@@ -0,0 +1,271 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func makeTestAuthenticator(spec fv1.TriggerAuth, data map[string][]byte) *authenticator {
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: spec.SecretName, Namespace: metav1.NamespaceDefault, ResourceVersion: "1"},
+		Data:       data,
+	}
+	return makeAuthenticator(&fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault},
+		Spec:       fv1.HTTPTriggerSpec{Auth: &spec},
+	}, func(namespace, name string) (*apiv1.Secret, error) {
+		if namespace != secret.Namespace || name != secret.Name {
+			return nil, fmt.Errorf("secret %v/%v not found", namespace, name)
+		}
+		return secret, nil
+	})
+}
+
+func assertUnauthorized(t *testing.T, err error) {
+	_, ok := err.(errUnauthorized)
+	assert.True(t, ok, "expected unauthorized error, got %v", err)
+}
+
+func TestAuthAPIKey(t *testing.T) {
+	a := makeTestAuthenticator(fv1.TriggerAuth{Type: fv1.TriggerAuthTypeAPIKey, SecretName: "keys"},
+		map[string][]byte{"team-a": []byte("secret-a\n")})
+
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	req.Header.Set(defaultAPIKeyHeader, "secret-a")
+	subject, _, err := a.authenticate(req)
+	assert.Nil(t, err)
+	assert.Equal(t, "team-a", subject)
+
+	req.Header.Set(defaultAPIKeyHeader, "secret-b")
+	_, _, err = a.authenticate(req)
+	assertUnauthorized(t, err)
+}
+
+func TestAuthBasic(t *testing.T) {
+	a := makeTestAuthenticator(fv1.TriggerAuth{Type: fv1.TriggerAuthTypeBasic, SecretName: "users"},
+		map[string][]byte{"alice": []byte("pa55")})
+
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	req.SetBasicAuth("alice", "pa55")
+	subject, _, err := a.authenticate(req)
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", subject)
+
+	req.SetBasicAuth("alice", "wrong")
+	_, _, err = a.authenticate(req)
+	assertUnauthorized(t, err)
+}
+
+func TestAuthHMAC(t *testing.T) {
+	key := []byte("shared")
+	a := makeTestAuthenticator(fv1.TriggerAuth{Type: fv1.TriggerAuthTypeHMAC, SecretName: "hmac"},
+		map[string][]byte{hmacSecretKey: key})
+
+	sign := func(timestamp, body string) string {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(timestamp + "." + body))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	body := `{"event":"push"}`
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader(body))
+	req.Header.Set(defaultHMACHeader, "sha256="+sign(now, body))
+	req.Header.Set(hmacTimestampHeader, now)
+	_, _, err := a.authenticate(req)
+	assert.Nil(t, err)
+
+	// body must still be readable by the function
+	b, err := ioutil.ReadAll(req.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, body, string(b))
+
+	req = httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader("tampered"))
+	req.Header.Set(defaultHMACHeader, sign(now, body))
+	req.Header.Set(hmacTimestampHeader, now)
+	_, _, err = a.authenticate(req)
+	assertUnauthorized(t, err)
+
+	// a replayed request has to keep its signed timestamp
+	stale := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	req = httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader(body))
+	req.Header.Set(defaultHMACHeader, sign(stale, body))
+	req.Header.Set(hmacTimestampHeader, stale)
+	_, _, err = a.authenticate(req)
+	assertUnauthorized(t, err)
+	req.Header.Set(hmacTimestampHeader, now)
+	_, _, err = a.authenticate(req)
+	assertUnauthorized(t, err)
+
+	req.Header.Del(hmacTimestampHeader)
+	_, _, err = a.authenticate(req)
+	assertUnauthorized(t, err)
+
+	large := strings.Repeat("x", hmacMaxBodySize+1)
+	req = httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader(large))
+	req.Header.Set(defaultHMACHeader, sign(now, large))
+	req.Header.Set(hmacTimestampHeader, now)
+	_, _, err = a.authenticate(req)
+	assert.Equal(t, errRequestBodyTooLarge, err)
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	assert.Nil(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestAuthJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+
+	jwks, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+
+	a := makeTestAuthenticator(fv1.TriggerAuth{
+		Type:          fv1.TriggerAuthTypeJWT,
+		SecretName:    "jwks",
+		Issuer:        "https://issuer.example.com",
+		Audience:      "fission",
+		ForwardClaims: []string{"email", "groups"},
+	}, map[string][]byte{jwksSecretKey: jwks})
+
+	claims := map[string]interface{}{
+		"sub":    "user-1",
+		"iss":    "https://issuer.example.com",
+		"aud":    []string{"fission", "other"},
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"email":  "user@example.com",
+		"groups": []string{"dev"},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	req.Header.Set("Authorization", "Bearer "+signRS256(t, key, "k1", claims))
+	subject, c, err := a.authenticate(req)
+	assert.Nil(t, err)
+	assert.Equal(t, "user-1", subject)
+
+	a.setAuthInfoToHeader(req, subject, c)
+	assert.Equal(t, "user-1", req.Header.Get("X-Fission-Auth-Subject"))
+	assert.Equal(t, "user@example.com", req.Header.Get("X-Fission-Auth-Claim-email"))
+	assert.Equal(t, `["dev"]`, req.Header.Get("X-Fission-Auth-Claim-groups"))
+
+	claims["exp"] = time.Now().Add(-time.Hour).Unix()
+	req.Header.Set("Authorization", "Bearer "+signRS256(t, key, "k1", claims))
+	_, _, err = a.authenticate(req)
+	assertUnauthorized(t, err)
+
+	delete(claims, "exp")
+	req.Header.Set("Authorization", "Bearer "+signRS256(t, key, "k1", claims))
+	_, _, err = a.authenticate(req)
+	assertUnauthorized(t, err)
+	a.spec.AllowNoExpiry = true
+	_, _, err = a.authenticate(req)
+	assert.Nil(t, err)
+
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+	claims["aud"] = "other"
+	req.Header.Set("Authorization", "Bearer "+signRS256(t, key, "k1", claims))
+	_, _, err = a.authenticate(req)
+	assertUnauthorized(t, err)
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	claims["aud"] = "fission"
+	req.Header.Set("Authorization", "Bearer "+signRS256(t, other, "k1", claims))
+	_, _, err = a.authenticate(req)
+	assertUnauthorized(t, err)
+}
+
+func TestVerifyECDSACurve(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.Nil(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES384, jwt.MapClaims{"sub": "alice"}).SignedString(key)
+	assert.Nil(t, err)
+	claims, err := verifyJWT(token, []verificationKey{{key: &key.PublicKey}}, time.Now(), false)
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", claims["sub"])
+
+	// a P-384 key must not verify ES256 signatures
+	_, err = keyForAlgorithm("ES256", &key.PublicKey)
+	assert.NotNil(t, err)
+}
+
+func TestFunctionHandlerUnauthorized(t *testing.T) {
+	a := makeTestAuthenticator(fv1.TriggerAuth{Type: fv1.TriggerAuthTypeBasic, SecretName: "users"},
+		map[string][]byte{"alice": []byte("pa55")})
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+
+	fh := &functionHandler{
+		logger: logger,
+		httpTrigger: &fv1.HTTPTrigger{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault},
+		},
+		auth: a,
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	req.Header.Set("X-Fission-Auth-Subject", "admin")
+	rr := httptest.NewRecorder()
+	assert.False(t, fh.authenticateRequest(rr, req))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Equal(t, `Basic realm="fission"`, rr.Header().Get("WWW-Authenticate"))
+
+	req.SetBasicAuth("alice", "pa55")
+	assert.True(t, fh.authenticateRequest(httptest.NewRecorder(), req))
+	assert.Equal(t, "alice", req.Header.Get("X-Fission-Auth-Subject"))
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"hash/fnv"
+	"net/http"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+const defaultCanaryCookiePrefix = "fission-canary-"
+
+// chooseCanaryBackend picks the function of a trigger referencing functions by
+// weight. The requests matching a canary rule are pinned to the function of
+// the rule, the clients with a sticky cookie stay on the function in cookie,
+// other requests are routed by weight.
+func (fh functionHandler) chooseCanaryBackend(rw http.ResponseWriter, req *http.Request) *fv1.Function {
+	if fh.httpTrigger == nil {
+		// internal route of a function alias
+		return getCanaryBackend(fh.functionMap, fh.fnWeightDistributionList)
+	}
+	ref := &fh.httpTrigger.Spec.FunctionReference
+
+	for i := range ref.CanaryRules {
+		rule := &ref.CanaryRules[i]
+		if !matchCanaryRule(req, rule) {
+			continue
+		}
+		if fn, ok := fh.functionMap[rule.Function]; ok {
+			return fn
+		}
+	}
+
+	sticky := ref.StickySession
+	if sticky == nil {
+		return getCanaryBackend(fh.functionMap, fh.fnWeightDistributionList)
+	}
+
+	cookieName := sticky.CookieName
+	if len(cookieName) == 0 {
+		cookieName = defaultCanaryCookiePrefix + fh.httpTrigger.ObjectMeta.Name
+	}
+
+	// a function whose weight dropped to 0, e.g. rolled back by
+	// canary config, doesn't keep its clients.
+	if c, err := req.Cookie(cookieName); err == nil && ref.FunctionWeights[c.Value] > 0 {
+		if fn, ok := fh.functionMap[c.Value]; ok {
+			return fn
+		}
+	}
+
+	fn := getCanaryBackend(fh.functionMap, fh.fnWeightDistributionList)
+	if fn != nil {
+		http.SetCookie(rw, &http.Cookie{
+			Name:     cookieName,
+			Value:    fn.ObjectMeta.Name,
+			Path:     "/",
+			MaxAge:   sticky.MaxAge,
+			HttpOnly: true,
+		})
+	}
+	return fn
+}
+
+// matchCanaryRule returns true if the request matches all conditions of the rule.
+func matchCanaryRule(req *http.Request, rule *fv1.CanaryRule) bool {
+	if rule.Header != nil {
+		values, ok := req.Header[http.CanonicalHeaderKey(rule.Header.Name)]
+		if !ok || !matchValue(values, rule.Header.Value) {
+			return false
+		}
+	}
+
+	if rule.Cookie != nil {
+		c, err := req.Cookie(rule.Cookie.Name)
+		if err != nil || !matchValue([]string{c.Value}, rule.Cookie.Value) {
+			return false
+		}
+	}
+
+	if b := rule.HashBucket; b != nil {
+		var key string
+		if len(b.Header) > 0 {
+			key = req.Header.Get(b.Header)
+		} else if c, err := req.Cookie(b.Cookie); err == nil {
+			key = c.Value
+		}
+		if len(key) == 0 || hashBucket(key) >= b.Percent {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchValue returns true if any of values equals to value,
+// an empty value matches anything.
+func matchValue(values []string, value string) bool {
+	if len(value) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// hashBucket maps the key to one of 100 buckets, the same key is
+// always in the same bucket across routers.
+func hashBucket(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % 100)
+}
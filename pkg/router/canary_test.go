@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func makeCanaryTestHandler(ref fv1.FunctionReference) functionHandler {
+	fnMap := make(map[string]*fv1.Function)
+	var distList []FunctionWeightDistribution
+	sum := 0
+	for _, name := range []string{"v1", "v2"} {
+		fnMap[name] = &fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		sum += ref.FunctionWeights[name]
+		distList = append(distList, FunctionWeightDistribution{name: name, weight: ref.FunctionWeights[name], sumPrefix: sum})
+	}
+	ref.Type = fv1.FunctionReferenceTypeFunctionWeights
+	return functionHandler{
+		httpTrigger: &fv1.HTTPTrigger{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault},
+			Spec:       fv1.HTTPTriggerSpec{FunctionReference: ref},
+		},
+		functionMap:              fnMap,
+		fnWeightDistributionList: distList,
+	}
+}
+
+func TestCanaryRules(t *testing.T) {
+	fh := makeCanaryTestHandler(fv1.FunctionReference{
+		FunctionWeights: map[string]int{"v1": 100, "v2": 0},
+		CanaryRules: []fv1.CanaryRule{
+			{Function: "v2", Header: &fv1.CanaryMatch{Name: "X-Tester", Value: "true"}},
+			{Function: "v2", Cookie: &fv1.CanaryMatch{Name: "beta"}},
+			{Function: "v2", HashBucket: &fv1.CanaryHashBucket{Header: "X-User-Id", Percent: 50}},
+		},
+	})
+
+	choose := func(req *http.Request) string {
+		return fh.chooseCanaryBackend(httptest.NewRecorder(), req).ObjectMeta.Name
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	assert.Equal(t, "v1", choose(req))
+
+	req.Header.Set("X-Tester", "false")
+	assert.Equal(t, "v1", choose(req))
+	req.Header.Set("X-Tester", "true")
+	assert.Equal(t, "v2", choose(req))
+
+	req = httptest.NewRequest(http.MethodGet, "/foo", nil)
+	req.AddCookie(&http.Cookie{Name: "beta", Value: "1"})
+	assert.Equal(t, "v2", choose(req))
+
+	// about half of users are in the buckets, and always the same ones
+	rule := &fh.httpTrigger.Spec.FunctionReference.CanaryRules[2]
+	inBucket := 0
+	for i := 0; i < 1000; i++ {
+		req = httptest.NewRequest(http.MethodGet, "/foo", nil)
+		req.Header.Set("X-User-Id", fmt.Sprintf("user-%v", i))
+		matched := matchCanaryRule(req, rule)
+		assert.Equal(t, matched, matchCanaryRule(req, rule))
+		if matched {
+			assert.Equal(t, "v2", choose(req))
+			inBucket++
+		}
+	}
+	assert.InDelta(t, 500, inBucket, 100)
+}
+
+func TestCanaryStickySession(t *testing.T) {
+	fh := makeCanaryTestHandler(fv1.FunctionReference{
+		FunctionWeights: map[string]int{"v1": 50, "v2": 50},
+		StickySession:   &fv1.StickySession{MaxAge: 3600},
+	})
+
+	rr := httptest.NewRecorder()
+	fn := fh.chooseCanaryBackend(rr, httptest.NewRequest(http.MethodGet, "/foo", nil))
+	cookies := (&http.Response{Header: rr.Header()}).Cookies()
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, defaultCanaryCookiePrefix+"foo", cookies[0].Name)
+	assert.Equal(t, fn.ObjectMeta.Name, cookies[0].Value)
+
+	for i := 0; i < 20; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+		req.AddCookie(cookies[0])
+		rr = httptest.NewRecorder()
+		assert.Equal(t, fn.ObjectMeta.Name, fh.chooseCanaryBackend(rr, req).ObjectMeta.Name)
+		assert.Empty(t, rr.Header().Get("Set-Cookie"))
+	}
+
+	// clients of a function with weight 0 are moved
+	fh.httpTrigger.Spec.FunctionReference.FunctionWeights = map[string]int{"v1": 100, "v2": 0}
+	fh.fnWeightDistributionList = []FunctionWeightDistribution{{name: "v1", weight: 100, sumPrefix: 100}, {name: "v2", sumPrefix: 100}}
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	req.AddCookie(&http.Cookie{Name: defaultCanaryCookiePrefix + "foo", Value: "v2"})
+	assert.Equal(t, "v1", fh.chooseCanaryBackend(httptest.NewRecorder(), req).ObjectMeta.Name)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	executorClient "github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/throttler"
+)
+
+func TestColdStartServerTiming(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hi"))
+	}))
+	defer backend.Close()
+
+	// executor specializes a new pod for the first request only
+	specialized := false
+	executor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !specialized {
+			specialized = true
+			executorClient.SetSpecializationHeaders(w.Header(), executorClient.SpecializationTimings{
+				PodSelection: 5 * time.Millisecond,
+				Fetch:        120 * time.Millisecond,
+				Load:         30500 * time.Microsecond,
+				Total:        160 * time.Millisecond,
+			})
+		}
+		w.Write([]byte(strings.TrimPrefix(backend.URL, "http://")))
+	}))
+	defer executor.Close()
+
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+	fnMeta := metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault, UID: "1"}
+
+	fh := makeTestFunctionHandler(logger, fnMeta, nil)
+	fh.executor = executorClient.MakeClient(logger, executor.URL)
+	fh.svcAddrUpdateThrottler = throttler.MakeThrottler(30 * time.Second)
+	fh.serverTiming = true
+	server := httptest.NewServer(http.HandlerFunc(fh.handler))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	timing := resp.Header.Get("Server-Timing")
+	assert.Contains(t, timing, "fetch;dur=120, function;dur=")
+	assert.Contains(t, timing, "load;dur=30.5, podselection;dur=5, specialize;dur=160")
+
+	// the service address is cached by router for the following requests
+	resp, err = http.Get(server.URL)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	timing = resp.Header.Get("Server-Timing")
+	assert.True(t, strings.HasPrefix(timing, "function;dur="))
+	assert.NotContains(t, timing, "specialize")
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// scaleOutBackoff is the time router waits before asking executor for
+// another function service again after it failed to get one.
+const scaleOutBackoff = 5 * time.Second
+
+type (
+	// instanceTracker tracks the in-flight requests to the function services
+	// of the functions with a concurrency limit per instance. Requests fill
+	// the function services in the order they were added, so that the last
+	// ones become idle and get released by executor once the load drops.
+	// The in-flight requests are counted by each router replica apart, the
+	// limit isn't shared by replicas. A function service is removed once
+	// executor reports it gone to a tap, or router can't connect to it.
+	instanceTracker struct {
+		mu        sync.Mutex
+		expiry    time.Duration
+		functions map[metadataKey]*functionInstances
+	}
+
+	functionInstances struct {
+		instances []*functionInstance
+		// scaling is true while a request is asking executor for a function service
+		scaling      bool
+		backoffUntil time.Time
+		// released is closed and replaced once there may be a free function service
+		released chan struct{}
+		atime    time.Time
+	}
+
+	functionInstance struct {
+		url      *url.URL
+		inflight int
+	}
+
+	// scaleOutFunc gets a function service other than the ones at the
+	// excluded addresses from executor.
+	scaleOutFunc func(exclude []string) (*url.URL, error)
+)
+
+func makeInstanceTracker(expiry time.Duration) *instanceTracker {
+	return &instanceTracker{
+		expiry:    expiry,
+		functions: make(map[metadataKey]*functionInstances),
+	}
+}
+
+// acquire returns the url of a function service serving less than limit
+// requests, asking executor for another one when they are all saturated and
+// waiting for a free one meanwhile. The returned release must be called once
+// the request is served; fromCache is false if the function service was
+// newly returned by executor.
+func (t *instanceTracker) acquire(ctx context.Context, m *metav1.ObjectMeta, limit int,
+	scaleOut scaleOutFunc) (*url.URL, func(), bool, error) {
+
+	key := *keyFromMetadata(m)
+	// the function service executor returned for this request
+	var added *url.URL
+	var err error
+
+	t.mu.Lock()
+	for {
+		fi := t.get(key)
+		fi.atime = time.Now()
+
+		for _, inst := range fi.instances {
+			if inst.inflight < limit {
+				inst.inflight++
+				t.mu.Unlock()
+				return inst.url, t.releaseFunc(fi, inst), !newlyAdded(inst, added), nil
+			}
+		}
+
+		if !fi.scaling && (len(fi.instances) == 0 || time.Now().After(fi.backoffUntil)) {
+			fi.scaling = true
+			exclude := make([]string, 0, len(fi.instances))
+			for _, inst := range fi.instances {
+				exclude = append(exclude, inst.url.Host)
+			}
+			t.mu.Unlock()
+
+			newUrl, scaleErr := scaleOut(exclude)
+
+			t.mu.Lock()
+			fi.scaling = false
+			if scaleErr != nil {
+				fi.backoffUntil = time.Now().Add(scaleOutBackoff)
+				t.broadcast(fi)
+				if len(fi.instances) == 0 {
+					t.mu.Unlock()
+					return nil, nil, false, scaleErr
+				}
+				// queue for the existing ones
+				continue
+			}
+			added = newUrl
+			if !fi.has(added) {
+				fi.instances = append(fi.instances, &functionInstance{url: added})
+			}
+			t.broadcast(fi)
+			continue
+		}
+
+		// wait for a free function service or the one being added
+		released := fi.released
+		var retry <-chan time.Time
+		var timer *time.Timer
+		if !fi.scaling {
+			// ask executor again once the backoff is over
+			timer = time.NewTimer(time.Until(fi.backoffUntil))
+			retry = timer.C
+		}
+		t.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-retry:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err != nil {
+			return nil, nil, false, err
+		}
+		t.mu.Lock()
+	}
+}
+
+// remove removes the function service at an address no longer valid, e.g.
+// the pod was released by executor. The requests in flight to it keep their
+// slots, which are freed with the removed instance.
+func (t *instanceTracker) remove(m *metav1.ObjectMeta, u *url.URL) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fi, ok := t.functions[*keyFromMetadata(m)]
+	if !ok {
+		return
+	}
+	for i, inst := range fi.instances {
+		if inst.url.Host == u.Host {
+			fi.instances = append(fi.instances[:i], fi.instances[i+1:]...)
+			break
+		}
+	}
+	t.broadcast(fi)
+}
+
+// get returns the function services of function, it's called with t.mu held.
+func (t *instanceTracker) get(key metadataKey) *functionInstances {
+	fi, ok := t.functions[key]
+	if ok {
+		return fi
+	}
+
+	// drop the functions not used anymore, e.g. the old versions of updated ones
+	for k, old := range t.functions {
+		if time.Since(old.atime) > t.expiry && !old.busy() {
+			delete(t.functions, k)
+		}
+	}
+
+	fi = &functionInstances{
+		released: make(chan struct{}),
+	}
+	t.functions[key] = fi
+	return fi
+}
+
+func (t *instanceTracker) releaseFunc(fi *functionInstances, inst *functionInstance) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.mu.Lock()
+			inst.inflight--
+			t.broadcast(fi)
+			t.mu.Unlock()
+		})
+	}
+}
+
+// broadcast wakes up the requests waiting for a function service, it's called with t.mu held.
+func (t *instanceTracker) broadcast(fi *functionInstances) {
+	close(fi.released)
+	fi.released = make(chan struct{})
+}
+
+func (fi *functionInstances) has(u *url.URL) bool {
+	for _, inst := range fi.instances {
+		if inst.url.Host == u.Host {
+			return true
+		}
+	}
+	return false
+}
+
+func (fi *functionInstances) busy() bool {
+	if fi.scaling {
+		return true
+	}
+	for _, inst := range fi.instances {
+		if inst.inflight > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// newlyAdded returns true if inst is the function service added for the request.
+func newlyAdded(inst *functionInstance, added *url.URL) bool {
+	return added != nil && inst.url.Host == added.Host
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInstanceTrackerScaleOut(t *testing.T) {
+	tracker := makeInstanceTracker(time.Minute)
+	m := &metav1.ObjectMeta{Name: "foo", Namespace: "default", ResourceVersion: "1"}
+
+	var excluded [][]string
+	scaleOut := func(exclude []string) (*url.URL, error) {
+		excluded = append(excluded, exclude)
+		return &url.URL{Scheme: "http", Host: fmt.Sprintf("10.0.0.%v:8888", len(excluded))}, nil
+	}
+
+	acquire := func() (string, func(), bool) {
+		u, release, fromCache, err := tracker.acquire(context.Background(), m, 2, scaleOut)
+		if err != nil {
+			t.Fatalf("error acquiring function service: %v", err)
+		}
+		return u.Host, release, fromCache
+	}
+
+	// the first function service serves two requests
+	host, release1, fromCache := acquire()
+	if host != "10.0.0.1:8888" || fromCache {
+		t.Fatalf("expected new function service 10.0.0.1:8888, got %v (from cache %v)", host, fromCache)
+	}
+	host, _, fromCache = acquire()
+	if host != "10.0.0.1:8888" || !fromCache {
+		t.Fatalf("expected cached function service 10.0.0.1:8888, got %v (from cache %v)", host, fromCache)
+	}
+
+	// and another one is added once it's saturated
+	host, _, _ = acquire()
+	if host != "10.0.0.2:8888" {
+		t.Fatalf("expected new function service 10.0.0.2:8888, got %v", host)
+	}
+	if len(excluded) != 2 || len(excluded[1]) != 1 || excluded[1][0] != "10.0.0.1:8888" {
+		t.Fatalf("expected saturated function service to be excluded, got %v", excluded)
+	}
+
+	// requests fill the first function service again once it's free
+	release1()
+	release1()
+	host, _, _ = acquire()
+	if host != "10.0.0.1:8888" {
+		t.Fatalf("expected freed function service 10.0.0.1:8888, got %v", host)
+	}
+
+	tracker.remove(m, &url.URL{Host: "10.0.0.1:8888"})
+	host, _, _ = acquire()
+	if host != "10.0.0.2:8888" {
+		t.Fatalf("expected function service 10.0.0.2:8888 after removing the first, got %v", host)
+	}
+
+	// executor reports the pod of function service gone to a tap
+	fmap := makeFunctionServiceMap(zap.NewNop(), time.Minute)
+	fmap.instances = tracker
+	fmap.removeGone(m, "http://10.0.0.2:8888")
+	host, _, _ = acquire()
+	if host != "10.0.0.3:8888" {
+		t.Fatalf("expected new function service 10.0.0.3:8888 after the others are gone, got %v", host)
+	}
+}
+
+func TestInstanceTrackerQueue(t *testing.T) {
+	tracker := makeInstanceTracker(time.Minute)
+	m := &metav1.ObjectMeta{Name: "foo", Namespace: "default", ResourceVersion: "1"}
+
+	scaled := false
+	scaleOut := func(exclude []string) (*url.URL, error) {
+		if scaled {
+			return nil, errors.New("no ready pod in pool")
+		}
+		scaled = true
+		return &url.URL{Scheme: "http", Host: "10.0.0.1:8888"}, nil
+	}
+
+	_, release, _, err := tracker.acquire(context.Background(), m, 1, scaleOut)
+	if err != nil {
+		t.Fatalf("error acquiring function service: %v", err)
+	}
+
+	// executor can't scale out, the request waits for the saturated function service
+	acquired := make(chan error)
+	go func() {
+		_, _, _, err := tracker.acquire(context.Background(), m, 1, scaleOut)
+		acquired <- err
+	}()
+
+	select {
+	case err := <-acquired:
+		t.Fatalf("expected request to be queued, got %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	release()
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Fatalf("error acquiring function service after release: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("queued request was not woken up by release")
+	}
+
+	// queued requests give up once canceled
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _, _, err = tracker.acquire(ctx, m, 1, scaleOut)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected deadline exceeded for queued request, got %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+const (
+	corsAllowOrigin      = "Access-Control-Allow-Origin"
+	corsAllowMethods     = "Access-Control-Allow-Methods"
+	corsAllowHeaders     = "Access-Control-Allow-Headers"
+	corsAllowCredentials = "Access-Control-Allow-Credentials"
+	corsExposeHeaders    = "Access-Control-Expose-Headers"
+	corsMaxAge           = "Access-Control-Max-Age"
+	corsRequestMethod    = "Access-Control-Request-Method"
+	corsRequestHeaders   = "Access-Control-Request-Headers"
+)
+
+// corsHandler answers preflight requests and adds CORS headers to the
+// responses of an HTTP trigger.
+type corsHandler struct {
+	policy  fv1.CORSPolicy
+	methods []string
+}
+
+func makeCORSHandler(trigger *fv1.HTTPTrigger) *corsHandler {
+	if trigger.Spec.CORS == nil {
+		return nil
+	}
+	methods := trigger.Spec.CORS.AllowedMethods
+	if len(methods) == 0 {
+		methods = trigger.Spec.GetMethods()
+	}
+	return &corsHandler{
+		policy:  *trigger.Spec.CORS,
+		methods: methods,
+	}
+}
+
+// isOriginAllowed checks the origin against the allowed origins, a wildcard
+// subdomain like "https://*.example.com" matches "https://a.example.com".
+func (c *corsHandler) isOriginAllowed(origin string) bool {
+	if len(origin) == 0 {
+		return false
+	}
+	for _, o := range c.policy.AllowedOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+		if i := strings.Index(o, "*"); i >= 0 {
+			prefix, suffix := o[:i], o[i+1:]
+			if len(origin) > len(prefix)+len(suffix) &&
+				strings.HasPrefix(strings.ToLower(origin), strings.ToLower(prefix)) &&
+				strings.HasSuffix(strings.ToLower(origin), strings.ToLower(suffix)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *corsHandler) isMethodAllowed(method string) bool {
+	for _, m := range c.methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *corsHandler) areHeadersAllowed(requestHeaders string) bool {
+	for _, h := range strings.Split(requestHeaders, ",") {
+		h = strings.TrimSpace(h)
+		if len(h) == 0 {
+			continue
+		}
+		allowed := false
+		for _, a := range c.policy.AllowedHeaders {
+			if a == "*" || strings.EqualFold(a, h) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// setAllowOrigin sets the allowed origin of the response. Browsers reject
+// "*" for requests with credentials, so the request origin is used instead.
+func (c *corsHandler) setAllowOrigin(header http.Header, origin string) {
+	header.Add("Vary", "Origin")
+	if !c.isOriginAllowed(origin) {
+		return
+	}
+	if c.policy.AllowCredentials {
+		header.Set(corsAllowOrigin, origin)
+		header.Set(corsAllowCredentials, "true")
+	} else if len(c.policy.AllowedOrigins) == 1 && c.policy.AllowedOrigins[0] == "*" {
+		header.Set(corsAllowOrigin, "*")
+	} else {
+		header.Set(corsAllowOrigin, origin)
+	}
+}
+
+// corsPreflight answers the preflight requests of all the triggers with the
+// same host and relative URL, which share a single OPTIONS route. The triggers
+// may accept different methods with different CORS policies, the preflight
+// is allowed if any of them allows the origin, method and headers of it.
+type corsPreflight struct {
+	handlers []*corsHandler
+}
+
+func (p *corsPreflight) add(c *corsHandler) {
+	p.handlers = append(p.handlers, c)
+}
+
+// corsPreflightKey returns the key of the OPTIONS route of trigger.
+func corsPreflightKey(trigger *fv1.HTTPTrigger) string {
+	return trigger.Spec.Host + trigger.Spec.RelativeURL
+}
+
+// handler answers the preflight request without invoking the function.
+func (p *corsPreflight) handler(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	requestMethod := r.Header.Get(corsRequestMethod)
+	requestHeaders := r.Header.Get(corsRequestHeaders)
+
+	// the policy of the trigger serving the requested method is used to
+	// answer, the allowed methods are merged from all the triggers that
+	// allow the origin and headers.
+	var policy *corsHandler
+	var methods []string
+	seen := make(map[string]bool)
+	for _, c := range p.handlers {
+		if !c.isOriginAllowed(origin) || !c.areHeadersAllowed(requestHeaders) {
+			continue
+		}
+		if policy == nil && c.isMethodAllowed(requestMethod) {
+			policy = c
+		}
+		for _, m := range c.methods {
+			if !seen[m] {
+				seen[m] = true
+				methods = append(methods, m)
+			}
+		}
+	}
+	if policy == nil {
+		w.Header().Add("Vary", "Origin")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	header := w.Header()
+	policy.setAllowOrigin(header, origin)
+	header.Set(corsAllowMethods, strings.Join(methods, ", "))
+	if len(requestHeaders) > 0 {
+		// the headers have been checked, reflect them so that "*"
+		// works for requests with credentials too.
+		header.Set(corsAllowHeaders, requestHeaders)
+	}
+	if policy.policy.MaxAge > 0 {
+		header.Set(corsMaxAge, strconv.Itoa(policy.policy.MaxAge))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decorate adds CORS headers to the response of an actual request.
+func (c *corsHandler) decorate(header http.Header, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if len(origin) == 0 {
+		return
+	}
+	c.setAllowOrigin(header, origin)
+	if len(c.policy.ExposedHeaders) > 0 && len(header.Get(corsAllowOrigin)) > 0 {
+		header.Set(corsExposeHeaders, strings.Join(c.policy.ExposedHeaders, ", "))
+	}
+}
+
+// removeCORSHeaders removes the CORS headers set by the function, so that
+// they don't conflict with the ones set by router.
+func removeCORSHeaders(header http.Header) {
+	for _, h := range []string{corsAllowOrigin, corsAllowMethods, corsAllowHeaders,
+		corsAllowCredentials, corsExposeHeaders, corsMaxAge} {
+		header.Del(h)
+	}
+}
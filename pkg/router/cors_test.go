@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestCORSOriginAllowed(t *testing.T) {
+	c := makeCORSHandler(&fv1.HTTPTrigger{
+		Spec: fv1.HTTPTriggerSpec{
+			Method: http.MethodPost,
+			CORS: &fv1.CORSPolicy{
+				AllowedOrigins: []string{"https://example.com", "https://*.fission.io"},
+			},
+		},
+	})
+
+	assert.True(t, c.isOriginAllowed("https://example.com"))
+	assert.True(t, c.isOriginAllowed("https://docs.fission.io"))
+	assert.False(t, c.isOriginAllowed("https://fission.io"))
+	assert.False(t, c.isOriginAllowed("http://example.com"))
+	assert.False(t, c.isOriginAllowed(""))
+}
+
+func TestCORSPreflight(t *testing.T) {
+	trigger := &fv1.HTTPTrigger{
+		Spec: fv1.HTTPTriggerSpec{
+			RelativeURL: "/foo",
+			Method:      http.MethodPost,
+			CORS: &fv1.CORSPolicy{
+				AllowedOrigins:   []string{"https://example.com"},
+				AllowedHeaders:   []string{"Content-Type"},
+				AllowCredentials: true,
+				MaxAge:           600,
+			},
+		},
+	}
+	pf := &corsPreflight{}
+	pf.add(makeCORSHandler(trigger))
+
+	preflight := func(method, headers string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/foo", nil)
+		req.Header.Set("Origin", "https://example.com")
+		req.Header.Set(corsRequestMethod, method)
+		if len(headers) > 0 {
+			req.Header.Set(corsRequestHeaders, headers)
+		}
+		rr := httptest.NewRecorder()
+		pf.handler(rr, req)
+		return rr
+	}
+
+	rr := preflight(http.MethodPost, "content-type")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "https://example.com", rr.Header().Get(corsAllowOrigin))
+	assert.Equal(t, "true", rr.Header().Get(corsAllowCredentials))
+	assert.Equal(t, http.MethodPost, rr.Header().Get(corsAllowMethods))
+	assert.Equal(t, "content-type", rr.Header().Get(corsAllowHeaders))
+	assert.Equal(t, "600", rr.Header().Get(corsMaxAge))
+
+	rr = preflight(http.MethodDelete, "")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	rr = preflight(http.MethodPost, "X-Custom")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestCORSPreflightMerged(t *testing.T) {
+	// two triggers of the same url with different methods and policies
+	pf := &corsPreflight{}
+	pf.add(makeCORSHandler(&fv1.HTTPTrigger{
+		Spec: fv1.HTTPTriggerSpec{
+			RelativeURL: "/foo",
+			Method:      http.MethodGet,
+			CORS:        &fv1.CORSPolicy{AllowedOrigins: []string{"*"}},
+		},
+	}))
+	pf.add(makeCORSHandler(&fv1.HTTPTrigger{
+		Spec: fv1.HTTPTriggerSpec{
+			RelativeURL: "/foo",
+			Methods:     []string{http.MethodPost, http.MethodDelete},
+			CORS: &fv1.CORSPolicy{
+				AllowedOrigins: []string{"https://example.com"},
+				AllowedHeaders: []string{"Content-Type"},
+				MaxAge:         600,
+			},
+		},
+	}))
+
+	preflight := func(origin, method, headers string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/foo", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set(corsRequestMethod, method)
+		if len(headers) > 0 {
+			req.Header.Set(corsRequestHeaders, headers)
+		}
+		rr := httptest.NewRecorder()
+		pf.handler(rr, req)
+		return rr
+	}
+
+	rr := preflight("https://example.com", http.MethodPost, "Content-Type")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "https://example.com", rr.Header().Get(corsAllowOrigin))
+	assert.Equal(t, "POST, DELETE", rr.Header().Get(corsAllowMethods))
+	assert.Equal(t, "600", rr.Header().Get(corsMaxAge))
+
+	rr = preflight("https://example.com", http.MethodGet, "")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "GET, POST, DELETE", rr.Header().Get(corsAllowMethods))
+	assert.Equal(t, "*", rr.Header().Get(corsAllowOrigin))
+
+	// only the GET trigger allows other origins
+	rr = preflight("https://other.com", http.MethodGet, "")
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, http.MethodGet, rr.Header().Get(corsAllowMethods))
+	rr = preflight("https://other.com", http.MethodPost, "")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestCORSDecorate(t *testing.T) {
+	c := makeCORSHandler(&fv1.HTTPTrigger{
+		Spec: fv1.HTTPTriggerSpec{
+			Method: http.MethodGet,
+			CORS: &fv1.CORSPolicy{
+				AllowedOrigins: []string{"*"},
+				ExposedHeaders: []string{"X-Request-Id"},
+			},
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	req.Header.Set("Origin", "https://example.com")
+	header := http.Header{}
+	c.decorate(header, req)
+	assert.Equal(t, "*", header.Get(corsAllowOrigin))
+	assert.Equal(t, "X-Request-Id", header.Get(corsExposeHeaders))
+
+	// non-CORS requests are left alone
+	header = http.Header{}
+	c.decorate(header, httptest.NewRequest(http.MethodGet, "/foo", nil))
+	assert.Empty(t, header)
+}
@@ -39,6 +39,7 @@ import (
 	ferror "github.com/fission/fission/pkg/error"
 	"github.com/fission/fission/pkg/error/network"
 	executorClient "github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/publisher"
 	"github.com/fission/fission/pkg/throttler"
 )
 
@@ -55,11 +56,23 @@ type (
 		function                 *fv1.Function
 		httpTrigger              *fv1.HTTPTrigger
 		functionMap              map[string]*fv1.Function
+		balancer                 *functionBalancer
 		fnWeightDistributionList []FunctionWeightDistribution
 		tsRoundTripperParams     *tsRoundTripperParams
 		isDebugEnv               bool
 		svcAddrUpdateThrottler   *throttler.Throttler
 		functionTimeoutMap       map[k8stypes.UID]int
+		rateLimiter              *triggerRateLimiter
+		cors                     *corsHandler
+		auth                     *authenticator
+		responseCache            *triggerResponseCache
+		mirror                   *functionHandler
+		mirrorSlots              chan struct{}
+		async                    *asyncInvoker
+		// asyncKey identifies the route of handler for the async invocations, see asyncHandlerKey.
+		asyncKey     string
+		accessLog    *accessLogger
+		serverTiming bool
 	}
 
 	tsRoundTripperParams struct {
@@ -90,7 +103,14 @@ type (
 		closeContextFunc *context.CancelFunc
 		serviceUrl       *url.URL
 		urlFromCache     bool
+		urlFromExecutor  bool
+		coldStart        bool
+		specialization   executorClient.SpecializationTimings
 		totalRetry       int
+		stream           *streamMonitor
+		// releaseInstance frees the function service for the other requests
+		// if the function has a concurrency limit per instance.
+		releaseInstance func()
 	}
 
 	// To keep the request body open during retries, we create an interface with Close operation being a no-op.
@@ -102,6 +122,13 @@ type (
 	svcEntryRecord struct {
 		svcUrl    *url.URL
 		fromCache bool
+		// fromExecutor is true if the address was not in the
+		// cache and had to be requested from the executor.
+		fromExecutor bool
+		// coldStart is true if executor specialized a new function
+		// service for this request, with the timings in specialization.
+		coldStart      bool
+		specialization executorClient.SpecializationTimings
 	}
 )
 
@@ -155,6 +182,13 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 	transport := roundTripper.getDefaultTransport()
 	ocRoundTripper := &ochttp.Transport{Base: transport}
 
+	// gRPC calls go to the function over h2c and need the "te" header
+	// that ReverseProxy strips as a hop-by-hop header.
+	isGRPC := roundTripper.funcHandler.isGRPC()
+	if isGRPC {
+		req.Header.Set("Te", "trailers")
+	}
+
 	executingTimeout := roundTripper.funcHandler.tsRoundTripperParams.timeout
 
 	// wrap the req.Body with another ReadCloser interface.
@@ -190,8 +224,18 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 		// trying to get new service url from cache/executor.
 		if retryCounter == 0 {
 			// get function service url from cache or executor
-			roundTripper.serviceUrl, roundTripper.urlFromCache, err = roundTripper.funcHandler.getServiceEntry()
+			var entry svcEntryRecord
+			entry, err = roundTripper.getServiceEntry(req.Context())
+			roundTripper.serviceUrl, roundTripper.urlFromCache = entry.svcUrl, entry.fromCache
+			roundTripper.urlFromExecutor = roundTripper.urlFromExecutor || entry.fromExecutor
+			if entry.coldStart {
+				roundTripper.coldStart, roundTripper.specialization = true, entry.specialization
+			}
 			if err != nil {
+				if err == context.DeadlineExceeded || err == context.Canceled {
+					// relay the context error to the proxy error handler
+					return nil, err
+				}
 				// We might want a specific error code or header for fission failures as opposed to
 				// user function bugs.
 				statusCode, errMsg := ferror.GetHTTPError(err)
@@ -228,7 +272,10 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 			// multiple functions per container, we could use the
 			// function metadata here.
 			// leave the query string intact (req.URL.RawQuery)
-			req.URL.Path = "/"
+			// gRPC calls keep the path, it names the service and method.
+			if !isGRPC {
+				req.URL.Path = "/"
+			}
 
 			// Overwrite request host with internal host,
 			// or request will be blocked in some situations
@@ -241,6 +288,9 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 			Timeout:   executingTimeout,
 			KeepAlive: roundTripper.funcHandler.tsRoundTripperParams.keepAliveTime,
 		}).DialContext
+		if isGRPC {
+			ocRoundTripper.Base = roundTripper.getGRPCTransport(executingTimeout)
+		}
 
 		// Do NOT assign returned request to "req"
 		// because the request used in the last round
@@ -274,7 +324,9 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 			isNetTimeoutErr = netErr.IsTimeoutError()
 		}
 
-		// if transport.RoundTrip returns a non-network dial error (e.g. "context canceled"), then relay it back to user
+		// if transport.RoundTrip returns a non-network dial error (e.g. "context canceled"), then relay it back to user.
+		// Only dial errors are retried, nothing of the request was sent then, so that a
+		// non-idempotent request, e.g. a gRPC stream, is never replayed to the function.
 		if !isNetDialErr {
 			return resp, err
 		}
@@ -288,7 +340,9 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 		// If it's not a timeout error or retryCounter exceeded pre-defined threshold,
 		// we assume the entry in router cache is stale, invalidate it.
 		if !isNetTimeoutErr || retryCounter >= roundTripper.funcHandler.tsRoundTripperParams.svcAddrRetryCount {
-			if roundTripper.urlFromCache {
+			// the function services of a function with a concurrency limit per
+			// instance are all kept in router's cache, even the new ones.
+			if roundTripper.urlFromCache || roundTripper.releaseInstance != nil {
 				// if transport.RoundTrip returns a network dial error and serviceUrl was from cache,
 				// it means, the entry in router cache is stale, so invalidate it.
 				roundTripper.logger.Debug("request errored out - removing function from router's cache and requesting a new service for function",
@@ -296,7 +350,7 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 					zap.String("function_name", fnMeta.Name),
 					zap.Error(err))
 
-				roundTripper.funcHandler.fmap.remove(fnMeta)
+				roundTripper.funcHandler.removeServiceEntry(roundTripper.serviceUrl)
 			}
 			retryCounter = 0
 		} else {
@@ -317,6 +371,21 @@ func (roundTripper *RetryingRoundTripper) RoundTrip(req *http.Request) (*http.Re
 	return nil, e
 }
 
+// serverTiming returns the Server-Timing metrics of the request, the time
+// until the function responded and the specialization steps of a cold start.
+func (roundTripper *RetryingRoundTripper) serverTiming(start time.Time) string {
+	timings := map[string]time.Duration{
+		"function": time.Since(start),
+	}
+	if roundTripper.coldStart {
+		timings[executorClient.TimingPodSelection] = roundTripper.specialization.PodSelection
+		timings[executorClient.TimingFetch] = roundTripper.specialization.Fetch
+		timings[executorClient.TimingLoad] = roundTripper.specialization.Load
+		timings[executorClient.TimingSpecialize] = roundTripper.specialization.Total
+	}
+	return executorClient.FormatServerTiming(timings)
+}
+
 // getDefaultTransport returns a pointer to new copy of http.Transport object to prevent
 // the value of http.DefaultTransport from being changed by goroutines.
 func (roundTripper RetryingRoundTripper) getDefaultTransport() *http.Transport {
@@ -336,6 +405,72 @@ func (roundTripper RetryingRoundTripper) getDefaultTransport() *http.Transport {
 	}
 }
 
+// getServiceEntry gets the service url of function. If the request has a
+// deadline or is a stream, it stops waiting once the request is canceled, while
+// the executor keeps specializing the pod in background for the following requests.
+func (roundTripper *RetryingRoundTripper) getServiceEntry(ctx context.Context) (svcEntryRecord, error) {
+	if roundTripper.funcHandler.concurrencyPerInstance() > 0 {
+		return roundTripper.getServiceInstanceEntry(ctx)
+	}
+
+	if _, ok := ctx.Deadline(); !ok && roundTripper.stream == nil {
+		return roundTripper.funcHandler.getServiceEntry()
+	}
+
+	type serviceEntry struct {
+		record svcEntryRecord
+		err    error
+	}
+	ch := make(chan serviceEntry, 1)
+	go func() {
+		record, err := roundTripper.funcHandler.getServiceEntry()
+		ch <- serviceEntry{record, err}
+	}()
+
+	select {
+	case e := <-ch:
+		return e.record, e.err
+	case <-ctx.Done():
+		// the address is being requested from executor
+		return svcEntryRecord{fromExecutor: true}, ctx.Err()
+	}
+}
+
+// getServiceInstanceEntry gets the url of a function service serving less
+// requests than the concurrency limit of function, waiting for one until the
+// request is canceled.
+func (roundTripper *RetryingRoundTripper) getServiceInstanceEntry(ctx context.Context) (svcEntryRecord, error) {
+	// free the one of previous try
+	roundTripper.closeInstance()
+
+	fh := roundTripper.funcHandler
+	record := svcEntryRecord{}
+	u, release, fromCache, err := fh.fmap.instances.acquire(ctx, &fh.function.ObjectMeta, fh.concurrencyPerInstance(),
+		func(exclude []string) (*url.URL, error) {
+			record.fromExecutor = true
+			u, svc, err := fh.getServiceInstanceFromExecutor(ctx, exclude)
+			if err != nil {
+				return nil, err
+			}
+			record.coldStart, record.specialization = svc.ColdStart, svc.Timings
+			return u, nil
+		})
+	if err != nil {
+		return record, err
+	}
+	roundTripper.releaseInstance = release
+	record.svcUrl, record.fromCache = u, fromCache
+	return record, nil
+}
+
+// closeInstance frees the function service of request for the other requests.
+func (roundTripper *RetryingRoundTripper) closeInstance() {
+	if roundTripper.releaseInstance != nil {
+		roundTripper.releaseInstance()
+		roundTripper.releaseInstance = nil
+	}
+}
+
 // setContext returns a shallow copy of request with a new timeout context.
 func (roundTripper *RetryingRoundTripper) setContext(req *http.Request) *http.Request {
 	if roundTripper.closeContextFunc != nil {
@@ -345,7 +480,14 @@ func (roundTripper *RetryingRoundTripper) setContext(req *http.Request) *http.Re
 	// that user aborts connection before timeout. Otherwise,
 	// the request won't be canceled until the deadline exceeded
 	// which may be a potential security issue.
-	ctx, closeCtx := context.WithTimeout(req.Context(), roundTripper.funcTimeout)
+	var ctx context.Context
+	var closeCtx context.CancelFunc
+	if roundTripper.stream != nil {
+		// a stream is limited by its idle timeout instead
+		ctx, closeCtx = context.WithCancel(req.Context())
+	} else {
+		ctx, closeCtx = context.WithTimeout(req.Context(), roundTripper.funcTimeout)
+	}
 	roundTripper.closeContextFunc = &closeCtx
 
 	return req.WithContext(ctx)
@@ -366,9 +508,107 @@ func (fh *functionHandler) tapService(fn *fv1.Function, serviceUrl *url.URL) {
 }
 
 func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *http.Request) {
-	if fh.httpTrigger != nil && fh.httpTrigger.Spec.FunctionReference.Type == fv1.FunctionReferenceTypeFunctionWeights {
-		// canary deployment. need to determine the function to send request to now
-		fn := getCanaryBackend(fh.functionMap, fh.fnWeightDistributionList)
+	if fh.accessLog != nil {
+		rec, rw, req := fh.accessLog.start(responseWriter, request, &fh)
+		if rec != nil {
+			defer fh.accessLog.finish(rec, rw)
+			responseWriter, request = rw, req
+		}
+	}
+
+	// only the non-http triggers, which route into the internal
+	// routes of functions, ask not to wait for a job to finish.
+	if fh.httpTrigger != nil {
+		request.Header.Del(publisher.HEADER_FISSION_JOB_DETACH)
+	}
+
+	// set CORS headers first so that client can read
+	// the error responses written by router too.
+	if fh.cors != nil {
+		fh.cors.decorate(responseWriter.Header(), request)
+	}
+
+	// reject the request with a body larger than the trigger allows,
+	// the body without Content-Length is checked while it's read.
+	if fh.httpTrigger != nil && fh.httpTrigger.Spec.MaxRequestBodyBytes > 0 {
+		if request.ContentLength > fh.httpTrigger.Spec.MaxRequestBodyBytes {
+			fh.recordRejection(request, rejectReasonBodyTooLarge)
+			fh.writeBodyTooLarge(responseWriter)
+			return
+		}
+		if request.Body != nil {
+			request.Body = newLimitedBody(request.Body, fh.httpTrigger.Spec.MaxRequestBodyBytes)
+		}
+	}
+
+	if fh.httpTrigger != nil && fh.httpTrigger.Spec.RequestTimeout > 0 {
+		ctx, cancel := context.WithTimeout(request.Context(), time.Duration(fh.httpTrigger.Spec.RequestTimeout)*time.Second)
+		defer cancel()
+		request = request.WithContext(ctx)
+	}
+
+	if !fh.authenticateRequest(responseWriter, request) {
+		return
+	}
+
+	release := func() {}
+	// reject the request before it reaches the executor if the trigger is
+	// already over its rate limit. Only authenticated requests take tokens,
+	// so that unauthenticated clients can't use up the limit.
+	if fh.rateLimiter != nil {
+		var reason string
+		var retryAfter time.Duration
+		release, reason, retryAfter = fh.rateLimiter.acquire(request)
+		if release == nil {
+			fh.rejectRequest(responseWriter, request, reason, retryAfter)
+			return
+		}
+	}
+
+	// queue the request and reply at once if client asks for an async invocation,
+	// it takes its MaxInFlight slot until the function responded.
+	if fh.async != nil && !fh.isGRPC() && isAsyncRequest(request) {
+		fh.async.enqueue(responseWriter, request, fh, release)
+		return
+	}
+	defer release()
+
+	fh.invoke(responseWriter, request)
+}
+
+// invokeAsync invokes the function for a queued async invocation, which is
+// logged apart from the request that queued it.
+func (fh functionHandler) invokeAsync(responseWriter http.ResponseWriter, request *http.Request, invocationID string) {
+	if fh.accessLog != nil {
+		rec, rw, req := fh.accessLog.start(responseWriter, request, &fh)
+		if rec != nil {
+			rec.InvocationID = invocationID
+			defer fh.accessLog.finish(rec, rw)
+			responseWriter, request = rw, req
+		}
+	}
+	fh.invoke(responseWriter, request)
+}
+
+// invoke sends the request to the function of handler and relays the response,
+// the request has been admitted by the limits and auth of trigger.
+func (fh functionHandler) invoke(responseWriter http.ResponseWriter, request *http.Request) {
+	accessLog := getAccessLogRecord(request)
+
+	if fh.balancer != nil {
+		// the trigger references functions by selector
+		fn, done := fh.balancer.pick()
+		defer done()
+		fh.function = fn
+		if accessLog != nil {
+			accessLog.Namespace, accessLog.Function = fn.ObjectMeta.Namespace, fn.ObjectMeta.Name
+		}
+	}
+
+	if len(fh.fnWeightDistributionList) > 0 {
+		// canary deployment, by the trigger or a function alias with weights.
+		// need to determine the function to send request to now
+		fn := fh.chooseCanaryBackend(responseWriter, request)
 		if fn == nil {
 			fh.logger.Error("could not get canary backend",
 				zap.Any("fnMap", fh.functionMap),
@@ -378,6 +618,30 @@ func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *h
 		}
 		fh.function = fn
 		fh.logger.Debug("chosen function backend's metadata", zap.Any("metadata", fh.function))
+		if accessLog != nil {
+			accessLog.Namespace, accessLog.Function = fn.ObjectMeta.Namespace, fn.ObjectMeta.Name
+			accessLog.CanaryBackend = fn.ObjectMeta.Name
+		}
+	}
+
+	// a job function runs a job to completion for every invocation
+	if isJob(fh.function) {
+		fh.runJob(responseWriter, request)
+		return
+	}
+
+	// serve repeated GET requests from the response cache
+	var cacheKey string
+	if fh.responseCache != nil {
+		if key, ok := fh.responseCache.key(request, fh.function); ok {
+			cached := fh.responseCache.lookup(request, key)
+			go responseCacheLookup(fh.httpTrigger.ObjectMeta.Namespace, fh.httpTrigger.ObjectMeta.Name, cached != nil)
+			if cached != nil {
+				cached.serve(responseWriter)
+				return
+			}
+			cacheKey = key
+		}
 	}
 
 	// url path
@@ -386,7 +650,25 @@ func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *h
 	// system params
 	setFunctionMetadataToHeader(&fh.function.ObjectMeta, request)
 
+	// duplicate a sample of requests to the mirror function
+	var mirrored <-chan mirrorResult
+	if fh.mirror != nil {
+		mirrored = fh.mirrorRequest(request)
+	}
+	var primary *statusRecorder
+	if mirrored != nil {
+		primary = &statusRecorder{ResponseWriter: responseWriter}
+		responseWriter = primary
+	}
+
+	var transform *fv1.HTTPTransform
+	if fh.httpTrigger != nil {
+		transform = fh.httpTrigger.Spec.Transform
+	}
+
 	director := func(req *http.Request) {
+		transformRequest(transform, req)
+
 		if _, ok := req.Header["User-Agent"]; !ok {
 			// explicitly disable User-Agent so it's not set to default value
 			req.Header.Set("User-Agent", "")
@@ -404,6 +686,29 @@ func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *h
 		funcTimeout: time.Duration(fnTimeout) * time.Second,
 	}
 
+	// keep streams, e.g. WebSocket or Server-Sent Events, open
+	// as long as data flows instead of the function timeout.
+	var streamCtx context.Context
+	if fh.httpTrigger != nil && fh.httpTrigger.Spec.Streaming != nil {
+		idleTimeout := defaultStreamIdleTimeout
+		if fh.httpTrigger.Spec.Streaming.IdleTimeout > 0 {
+			idleTimeout = time.Duration(fh.httpTrigger.Spec.Streaming.IdleTimeout) * time.Second
+		}
+		var cancel context.CancelFunc
+		streamCtx, cancel = context.WithCancel(request.Context())
+		defer cancel()
+		request = request.WithContext(streamCtx)
+		rrt.stream = fh.monitorStream(cancel, idleTimeout)
+		defer rrt.stream.stop()
+		rrt.stream.wrapRequest(request)
+	}
+
+	inflightDone := functionCallInflight(&functionLabels{
+		namespace: fh.function.ObjectMeta.Namespace,
+		name:      fh.function.ObjectMeta.Name,
+	})
+	defer inflightDone()
+
 	start := time.Now()
 
 	proxy := &httputil.ReverseProxy{
@@ -412,9 +717,28 @@ func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *h
 		ErrorHandler: fh.getProxyErrorHandler(start, rrt),
 		ModifyResponse: func(resp *http.Response) error {
 			go fh.collectFunctionMetric(start, rrt, request, resp)
+			if rrt.stream != nil {
+				rrt.stream.setService(rrt.serviceUrl)
+				rrt.stream.wrapResponse(streamCtx, resp)
+			}
+			transformResponse(transform, resp)
+			if fh.cors != nil {
+				removeCORSHeaders(resp.Header)
+			}
+			if len(cacheKey) > 0 {
+				fh.responseCache.capture(request, cacheKey, resp)
+				resp.Header.Set(HEADER_FISSION_CACHE, "MISS")
+			}
+			if fh.serverTiming {
+				resp.Header.Add("Server-Timing", rrt.serverTiming(start))
+			}
 			return nil
 		},
 	}
+	if rrt.stream != nil || fh.isGRPC() {
+		// flush every write to client
+		proxy.FlushInterval = -1
+	}
 
 	defer func() {
 		// If the context is closed when RoundTrip returns, client may receive
@@ -427,9 +751,17 @@ func (fh functionHandler) handler(responseWriter http.ResponseWriter, request *h
 		//
 		// ref: https://github.com/golang/go/issues/28239
 		rrt.closeContext()
+		rrt.closeInstance()
 	}()
 
 	proxy.ServeHTTP(responseWriter, request)
+
+	if accessLog != nil {
+		accessLog.recordRoundTrip(rrt)
+	}
+	if mirrored != nil {
+		go fh.recordMirrorResult(mirrorResult{status: primary.status, duration: time.Since(start)}, mirrored)
+	}
 }
 
 // findCeil picks a function from the functionWeightDistribution list based on the
@@ -509,13 +841,13 @@ func (roundTripper RetryingRoundTripper) addForwardedHostHeader(req *http.Reques
 }
 
 // getServiceEntry is a short-hand for developers to get service url entry that may returns from executor or cache
-func (fh *functionHandler) getServiceEntry() (serviceUrl *url.URL, serviceUrlFromCache bool, err error) {
+func (fh *functionHandler) getServiceEntry() (svcEntryRecord, error) {
 	// try to find service url from cache first
-	serviceUrl, err = fh.getServiceEntryFromCache()
+	serviceUrl, err := fh.getServiceEntryFromCache()
 	if err == nil && serviceUrl != nil {
-		return serviceUrl, true, nil
+		return svcEntryRecord{svcUrl: serviceUrl, fromCache: true}, nil
 	} else if err != nil {
-		return nil, false, err
+		return svcEntryRecord{}, err
 	}
 
 	// cache miss or nil entry in cache
@@ -530,11 +862,12 @@ func (fh *functionHandler) getServiceEntry() (serviceUrl *url.URL, serviceUrlFro
 		crd.CacheKey(fnMeta),
 		func(firstToTheLock bool) (interface{}, error) {
 			var u *url.URL
+			var svc *executorClient.FunctionService
 			// Get service entry from executor and update cache if its the first goroutine
 			if firstToTheLock { // first to the service url
 				fh.logger.Debug("calling getServiceForFunction",
 					zap.String("function_name", fnMeta.Name))
-				u, err = fh.getServiceEntryFromExecutor(ctx)
+				u, svc, err = fh.getServiceEntryFromExecutor(ctx)
 				if err != nil {
 					fh.logger.Error("error getting service url from executor",
 						zap.Error(err),
@@ -553,10 +886,15 @@ func (fh *functionHandler) getServiceEntry() (serviceUrl *url.URL, serviceUrlFro
 				}
 			}
 
-			return svcEntryRecord{
-				svcUrl:    u,
-				fromCache: firstToTheLock,
-			}, err
+			record := svcEntryRecord{
+				svcUrl:       u,
+				fromCache:    firstToTheLock,
+				fromExecutor: true,
+			}
+			if svc != nil {
+				record.coldStart, record.specialization = svc.ColdStart, svc.Timings
+			}
+			return record, err
 		},
 	)
 	if err != nil {
@@ -565,15 +903,37 @@ func (fh *functionHandler) getServiceEntry() (serviceUrl *url.URL, serviceUrlFro
 			zap.Error(err),
 			zap.String("function_name", fnMeta.Name),
 			zap.String("function_namespace", fnMeta.Namespace))
-		return nil, false, errors.Wrapf(err, "%s %s_%s", e, fnMeta.Name, fnMeta.Namespace)
+		return svcEntryRecord{fromExecutor: true}, errors.Wrapf(err, "%s %s_%s", e, fnMeta.Name, fnMeta.Namespace)
 	}
 
 	record, ok := recordObj.(svcEntryRecord)
 	if !ok {
-		return nil, false, errors.Errorf("Received unknown service record type")
+		return svcEntryRecord{fromExecutor: true}, errors.Errorf("Received unknown service record type")
+	}
+
+	return record, nil
+}
+
+// concurrencyPerInstance returns the maximum concurrent requests a function
+// service of function serves, or zero if there's no limit.
+func (fh *functionHandler) concurrencyPerInstance() int {
+	if fh.function == nil || fh.executor == nil {
+		return 0
+	}
+	es := fh.function.Spec.InvokeStrategy.ExecutionStrategy
+	if es.ExecutorType != fv1.ExecutorTypePoolmgr {
+		return 0
 	}
+	return es.ConcurrencyPerInstance
+}
 
-	return record.svcUrl, record.fromCache, nil
+// removeServiceEntry removes the invalid service url of function from router's cache.
+func (fh *functionHandler) removeServiceEntry(serviceUrl *url.URL) {
+	if fh.concurrencyPerInstance() > 0 {
+		fh.fmap.instances.remove(&fh.function.ObjectMeta, serviceUrl)
+		return
+	}
+	fh.fmap.remove(&fh.function.ObjectMeta)
 }
 
 // getServiceEntryFromCache returns service url entry returns from cache
@@ -599,8 +959,9 @@ func (fh functionHandler) getServiceEntryFromCache() (serviceUrl *url.URL, err e
 	return serviceUrl, nil
 }
 
-// getServiceEntryFromExecutor returns service url entry returns from executor
-func (fh functionHandler) getServiceEntryFromExecutor(ctx context.Context) (*url.URL, error) {
+// getServiceEntryFromExecutor returns service url entry returns from executor,
+// and the function service telling whether it was specialized for the request.
+func (fh functionHandler) getServiceEntryFromExecutor(ctx context.Context) (*url.URL, *executorClient.FunctionService, error) {
 	// send a request to executor to specialize a new pod
 	service, err := fh.executor.GetServiceForFunction(ctx, &fh.function.ObjectMeta)
 	if err != nil {
@@ -610,19 +971,53 @@ func (fh functionHandler) getServiceEntryFromExecutor(ctx context.Context) (*url
 			zap.String("error_message", errMsg),
 			zap.Any("function", fh.function),
 			zap.Int("status_code", statusCode))
-		return nil, err
+		return nil, nil, err
 	}
 
 	// parse the address into url
-	serviceUrl, err := url.Parse(fmt.Sprintf("http://%v", service))
+	serviceUrl, err := url.Parse(fmt.Sprintf("http://%v", service.Address))
 	if err != nil {
 		fh.logger.Error("error parsing service url",
 			zap.Error(err),
 			zap.String("service_url", serviceUrl.String()))
-		return nil, err
+		return nil, nil, err
 	}
 
-	return serviceUrl, nil
+	return serviceUrl, service, nil
+}
+
+// getServiceInstanceFromExecutor returns the url of a function service of
+// function other than the saturated ones at the excluded addresses. The first
+// one is the function service returned for the functions without limit.
+func (fh functionHandler) getServiceInstanceFromExecutor(ctx context.Context, exclude []string) (*url.URL, *executorClient.FunctionService, error) {
+	if len(exclude) == 0 {
+		return fh.getServiceEntryFromExecutor(ctx)
+	}
+
+	service, err := fh.executor.GetServiceInstanceForFunction(ctx, &fh.function.ObjectMeta, exclude)
+	if err != nil {
+		statusCode, errMsg := ferror.GetHTTPError(err)
+		fh.logger.Error("error from GetServiceInstanceForFunction",
+			zap.Error(err),
+			zap.String("error_message", errMsg),
+			zap.Any("function", fh.function),
+			zap.Int("status_code", statusCode))
+		return nil, nil, err
+	}
+
+	serviceUrl, err := url.Parse(fmt.Sprintf("http://%v", service.Address))
+	if err != nil {
+		fh.logger.Error("error parsing service url",
+			zap.Error(err),
+			zap.String("service_address", service.Address))
+		return nil, nil, err
+	}
+
+	fh.logger.Info("assigning additional service url for function",
+		zap.String("url", serviceUrl.String()),
+		zap.String("function_name", fh.function.ObjectMeta.Name),
+		zap.Strings("saturated", exclude))
+	return serviceUrl, service, nil
 }
 
 // getProxyErrorHandler returns a reverse proxy error handler
@@ -631,6 +1026,45 @@ func (fh functionHandler) getProxyErrorHandler(start time.Time, rrt *RetryingRou
 		var status int
 		var msg string
 
+		// gRPC clients only understand errors in grpc-status
+		if fh.isGRPC() {
+			code, status, msg := grpcErrorStatus(req, err)
+			if status == http.StatusGatewayTimeout && req.Context().Err() == context.DeadlineExceeded {
+				fh.recordRejection(req, rejectReasonTimeout)
+			}
+			fh.logger.Debug("error proxying gRPC call", zap.Error(err), zap.Int("grpc_status", code), zap.Any("function", fh.function))
+			go fh.collectFunctionMetric(start, rrt, req, &http.Response{
+				StatusCode: status,
+			})
+			writeGRPCError(rw, code, msg)
+			return
+		}
+
+		// errors caused by the limits of trigger are replied with a JSON body
+		if bodyLimitExceeded(req.Body) {
+			go fh.collectFunctionMetric(start, rrt, req, &http.Response{
+				StatusCode: http.StatusRequestEntityTooLarge,
+			})
+			fh.writeBodyTooLarge(rw)
+			return
+		}
+		if rrt.stream != nil && rrt.stream.idled() {
+			go fh.collectFunctionMetric(start, rrt, req, &http.Response{
+				StatusCode: http.StatusGatewayTimeout,
+			})
+			writeErrorResponse(rw, http.StatusGatewayTimeout, "function did not respond within the stream idle timeout", fh.httpTrigger.ObjectMeta.Name)
+			return
+		}
+		if req.Context().Err() == context.DeadlineExceeded {
+			go fh.collectFunctionMetric(start, rrt, req, &http.Response{
+				StatusCode: http.StatusGatewayTimeout,
+			})
+			trigger := fh.recordRejection(req, rejectReasonTimeout)
+			fh.logger.Error("trigger request timeout exceeded", zap.Any("function", fh.function), zap.String("trigger", trigger))
+			writeErrorResponse(rw, http.StatusGatewayTimeout, "function did not respond within the trigger request timeout", trigger)
+			return
+		}
+
 		switch err {
 		case context.Canceled:
 			// 499 CLIENT CLOSED REQUEST
@@ -661,6 +1095,87 @@ func (fh functionHandler) getProxyErrorHandler(start time.Time, rrt *RetryingRou
 	}
 }
 
+// writeBodyTooLarge replies 413 Request Entity Too Large to the request.
+func (fh functionHandler) writeBodyTooLarge(rw http.ResponseWriter) {
+	writeErrorResponse(rw, http.StatusRequestEntityTooLarge,
+		fmt.Sprintf("request body exceeds the limit of %v bytes", fh.httpTrigger.Spec.MaxRequestBodyBytes),
+		fh.httpTrigger.ObjectMeta.Name)
+}
+
+// rejectRequest replies to the request with 429 Too Many Requests
+// and records the rejection.
+func (fh functionHandler) rejectRequest(rw http.ResponseWriter, req *http.Request, reason string, retryAfter time.Duration) {
+	msg := fmt.Sprintf("too many requests: %v exceeded", reason)
+
+	name := fh.recordRejection(req, reason)
+	fh.logger.Debug("request rejected", zap.String("reason", reason),
+		zap.String("trigger", name), zap.Duration("retry_after", retryAfter))
+
+	rw.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+	rw.WriteHeader(http.StatusTooManyRequests)
+	rw.Write([]byte(msg))
+}
+
+// recordRejection records the metric of a request rejected by router,
+// and returns the name of trigger.
+func (fh functionHandler) recordRejection(req *http.Request, reason string) string {
+	httpMetricLabels := &httpLabels{
+		method: req.Method,
+	}
+	var namespace, name string
+	if fh.httpTrigger != nil {
+		namespace, name = fh.httpTrigger.ObjectMeta.Namespace, fh.httpTrigger.ObjectMeta.Name
+		httpMetricLabels.host = fh.httpTrigger.Spec.Host
+		httpMetricLabels.path = fh.httpTrigger.Spec.RelativeURL
+	}
+	go triggerRequestRejected(namespace, name, httpMetricLabels, reason)
+	return name
+}
+
+// authenticateRequest authenticates the request if the trigger has auth
+// configured, and sets the auth info to request header for the function.
+// It returns false if the request is rejected.
+func (fh functionHandler) authenticateRequest(rw http.ResponseWriter, req *http.Request) bool {
+	// a function only sees the auth info set by router
+	removeAuthInfoFromHeader(req)
+
+	if fh.auth == nil {
+		return true
+	}
+
+	subject, claims, err := fh.auth.authenticate(req)
+	if err == nil {
+		fh.auth.setAuthInfoToHeader(req, subject, claims)
+		return true
+	}
+
+	if errors.Cause(err) == errRequestBodyTooLarge {
+		fh.recordRejection(req, rejectReasonBodyTooLarge)
+		fh.writeBodyTooLarge(rw)
+		return false
+	}
+
+	if _, ok := err.(errUnauthorized); !ok {
+		fh.logger.Error("error authenticating request", zap.Error(err),
+			zap.String("auth_secret", fh.auth.spec.SecretName))
+		http.Error(rw, "error authenticating request", http.StatusInternalServerError)
+		return false
+	}
+
+	name := fh.recordRejection(req, rejectReasonUnauthorized)
+	fh.logger.Debug("request rejected", zap.String("reason", rejectReasonUnauthorized),
+		zap.String("trigger", name), zap.Error(err))
+
+	switch fh.auth.spec.Type {
+	case fv1.TriggerAuthTypeBasic:
+		rw.Header().Set("WWW-Authenticate", `Basic realm="fission"`)
+	case fv1.TriggerAuthTypeJWT:
+		rw.Header().Set("WWW-Authenticate", `Bearer realm="fission"`)
+	}
+	http.Error(rw, fmt.Sprintf("unauthorized: %v", err), http.StatusUnauthorized)
+	return false
+}
+
 func (fh functionHandler) collectFunctionMetric(start time.Time, rrt *RetryingRoundTripper, req *http.Request, resp *http.Response) {
 	duration := time.Since(start)
 
@@ -684,6 +1199,12 @@ func (fh functionHandler) collectFunctionMetric(start time.Time, rrt *RetryingRo
 	functionCallCompleted(funcMetricLabels, httpMetricLabels,
 		duration, duration, resp.ContentLength)
 
+	executorType := string(fh.function.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType)
+	functionCallStarted(funcMetricLabels, executorType, rrt.coldStart, duration)
+	if rrt.coldStart {
+		functionSpecialized(funcMetricLabels, executorType, rrt.specialization)
+	}
+
 	// tapService before invoking roundTrip for the serviceUrl
 	if rrt.urlFromCache {
 		fh.tapService(fh.function, rrt.serviceUrl)
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sCache "k8s.io/client-go/tools/cache"
 
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
@@ -33,8 +34,9 @@ type (
 	// reference into a resolveResult
 	functionReferenceResolver struct {
 		// FunctionReference -> function metadata
-		refCache *cache.Cache
-		store    k8sCache.Store
+		refCache   *cache.Cache
+		store      k8sCache.Store
+		aliasStore k8sCache.Store
 	}
 
 	resolveResultType int
@@ -46,12 +48,16 @@ type (
 	}
 
 	// resolveResult is the result of resolving a function reference;
-	// it could be the metadata of one function or
-	// a distribution of requests across two functions.
+	// it could be the metadata of one function,
+	// a distribution of requests across two functions or
+	// the functions matching a selector.
 	resolveResult struct {
 		resolveResultType
 		functionMap                map[string]*fv1.Function
 		functionWtDistributionList []FunctionWeightDistribution
+		// alias is the name of function alias the reference is resolved
+		// through, if any.
+		alias string
 	}
 
 	// namespacedTriggerReference is just a trigger reference plus a
@@ -66,12 +72,14 @@ type (
 const (
 	resolveResultSingleFunction = iota
 	resolveResultMultipleFunctions
+	resolveResultSelectedFunctions
 )
 
-func makeFunctionReferenceResolver(store k8sCache.Store) *functionReferenceResolver {
+func makeFunctionReferenceResolver(store k8sCache.Store, aliasStore k8sCache.Store) *functionReferenceResolver {
 	frr := &functionReferenceResolver{
-		refCache: cache.MakeCache(time.Minute, 0),
-		store:    store,
+		refCache:   cache.MakeCache(time.Minute, 0),
+		store:      store,
+		aliasStore: aliasStore,
 	}
 	return frr
 }
@@ -107,6 +115,18 @@ func (frr *functionReferenceResolver) resolve(trigger fv1.HTTPTrigger) (*resolve
 			return nil, err
 		}
 
+	case fv1.FunctionReferenceTypeFunctionAlias:
+		rr, err = frr.resolveByAlias(nfr.namespace, trigger.Spec.FunctionReference.Name)
+		if err != nil {
+			return nil, err
+		}
+
+	case fv1.FunctionReferenceTypeFunctionSelector:
+		rr, err = frr.resolveBySelector(nfr.namespace, trigger.Spec.FunctionReference.Selector)
+		if err != nil {
+			return nil, err
+		}
+
 	default:
 		return nil, errors.Errorf("unrecognized function reference type %v", trigger.Spec.FunctionReference.Type)
 	}
@@ -186,6 +206,71 @@ func (frr *functionReferenceResolver) resolveByFunctionWeights(namespace string,
 	return &rr, nil
 }
 
+// resolveBySelector looks up the functions matching selector in a namespace.
+func (frr *functionReferenceResolver) resolveBySelector(namespace string, selector map[string]string) (*resolveResult, error) {
+	if frr.store == nil {
+		return nil, errors.Errorf("no function matches selector %v", selector)
+	}
+
+	s := labels.SelectorFromSet(selector)
+	functionMap := make(map[string]*fv1.Function)
+	for _, obj := range frr.store.List() {
+		f := obj.(*fv1.Function)
+		if f.ObjectMeta.Namespace == namespace && s.Matches(labels.Set(f.ObjectMeta.Labels)) {
+			functionMap[f.ObjectMeta.Name] = f
+		}
+	}
+	if len(functionMap) == 0 {
+		return nil, errors.Errorf("no function matches selector %v", selector)
+	}
+
+	rr := resolveResult{
+		resolveResultType: resolveResultSelectedFunctions,
+		functionMap:       functionMap,
+	}
+
+	return &rr, nil
+}
+
+// resolveByAlias looks up function alias by name in a namespace and resolves
+// the function, or the functions by weight, it points to.
+func (frr *functionReferenceResolver) resolveByAlias(namespace, name string) (*resolveResult, error) {
+	if frr.aliasStore == nil {
+		return nil, errors.Errorf("function alias %v does not exist", name)
+	}
+
+	// get function alias from cache
+	obj, isExist, err := frr.aliasStore.Get(&fv1.FunctionAlias{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !isExist {
+		return nil, errors.Errorf("function alias %v does not exist", name)
+	}
+
+	alias := obj.(*fv1.FunctionAlias)
+
+	var rr *resolveResult
+	if len(alias.Spec.FunctionWeights) > 0 {
+		rr, err = frr.resolveByFunctionWeights(namespace, &fv1.FunctionReference{
+			FunctionWeights: alias.Spec.FunctionWeights,
+		})
+	} else {
+		rr, err = frr.resolveByName(namespace, alias.Spec.FunctionName)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error resolving function alias %v", name)
+	}
+
+	rr.alias = alias.ObjectMeta.Name
+	return rr, nil
+}
+
 func (frr *functionReferenceResolver) delete(namespace string, triggerName, triggerRV string) error {
 	nfr := namespacedTriggerReference{
 		namespace:              namespace,
@@ -204,3 +289,31 @@ func (frr *functionReferenceResolver) copy() map[namespacedTriggerReference]reso
 	}
 	return cache
 }
+
+// deleteByAlias invalidates the results resolved through the function alias,
+// so that the triggers referencing it follow the alias when it's moved.
+func (frr *functionReferenceResolver) deleteByAlias(namespace, alias string) error {
+	var err error
+	for key, rr := range frr.copy() {
+		if key.namespace == namespace && rr.alias == alias {
+			if e := frr.refCache.Delete(key); e != nil {
+				err = e
+			}
+		}
+	}
+	return err
+}
+
+// deleteBySelector invalidates the results of selectors in namespace, so that
+// the set of matching functions is recomputed once a function is changed.
+func (frr *functionReferenceResolver) deleteBySelector(namespace string) error {
+	var err error
+	for key, rr := range frr.copy() {
+		if key.namespace == namespace && rr.resolveResultType == resolveResultSelectedFunctions {
+			if e := frr.refCache.Delete(key); e != nil {
+				err = e
+			}
+		}
+	}
+	return err
+}
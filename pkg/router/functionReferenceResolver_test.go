@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestResolveFunctionAlias(t *testing.T) {
+	fnStore := k8sCache.NewStore(k8sCache.MetaNamespaceKeyFunc)
+	for _, name := range []string{"foo-v7", "foo-v8"} {
+		assert.Nil(t, fnStore.Add(&fv1.Function{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+		}))
+	}
+	alias := &fv1.FunctionAlias{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: metav1.NamespaceDefault},
+		Spec:       fv1.FunctionAliasSpec{FunctionName: "foo-v7"},
+	}
+	aliasStore := k8sCache.NewStore(k8sCache.MetaNamespaceKeyFunc)
+	assert.Nil(t, aliasStore.Add(alias))
+
+	frr := makeFunctionReferenceResolver(fnStore, aliasStore)
+	trigger := fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "xxx", Namespace: metav1.NamespaceDefault, ResourceVersion: "1"},
+		Spec: fv1.HTTPTriggerSpec{
+			FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionAlias, Name: "prod"},
+		},
+	}
+
+	rr, err := frr.resolve(trigger)
+	assert.Nil(t, err)
+	assert.Equal(t, resolveResultType(resolveResultSingleFunction), rr.resolveResultType)
+	assert.Equal(t, "prod", rr.alias)
+	assert.NotNil(t, rr.functionMap["foo-v7"])
+
+	// the trigger follows the alias once its cache is invalidated
+	alias = alias.DeepCopy()
+	alias.Spec = fv1.FunctionAliasSpec{FunctionWeights: map[string]int{"foo-v7": 80, "foo-v8": 20}}
+	assert.Nil(t, aliasStore.Update(alias))
+
+	rr, err = frr.resolve(trigger)
+	assert.Nil(t, err)
+	assert.Equal(t, resolveResultType(resolveResultSingleFunction), rr.resolveResultType)
+
+	assert.Nil(t, frr.deleteByAlias(metav1.NamespaceDefault, "prod"))
+	rr, err = frr.resolve(trigger)
+	assert.Nil(t, err)
+	assert.Equal(t, resolveResultType(resolveResultMultipleFunctions), rr.resolveResultType)
+	assert.Len(t, rr.functionMap, 2)
+	assert.Len(t, rr.functionWtDistributionList, 2)
+
+	// a missing alias or function isn't resolvable
+	alias = alias.DeepCopy()
+	alias.Spec = fv1.FunctionAliasSpec{FunctionName: "foo-v9"}
+	assert.Nil(t, aliasStore.Update(alias))
+	_, err = frr.resolveByAlias(metav1.NamespaceDefault, "prod")
+	assert.NotNil(t, err)
+	_, err = frr.resolveByAlias(metav1.NamespaceDefault, "staging")
+	assert.NotNil(t, err)
+}
+
+func TestResolveFunctionSelector(t *testing.T) {
+	fnStore := k8sCache.NewStore(k8sCache.MetaNamespaceKeyFunc)
+	for name, app := range map[string]string{"foo-1": "foo", "foo-2": "foo", "bar": "bar"} {
+		assert.Nil(t, fnStore.Add(&fv1.Function{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault, Labels: map[string]string{"app": app}},
+		}))
+	}
+	// functions in other namespaces don't match
+	assert.Nil(t, fnStore.Add(&fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-3", Namespace: "other", Labels: map[string]string{"app": "foo"}},
+	}))
+
+	frr := makeFunctionReferenceResolver(fnStore, nil)
+	trigger := fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "xxx", Namespace: metav1.NamespaceDefault, ResourceVersion: "1"},
+		Spec: fv1.HTTPTriggerSpec{
+			FunctionReference: fv1.FunctionReference{
+				Type:     fv1.FunctionReferenceTypeFunctionSelector,
+				Selector: map[string]string{"app": "foo"},
+			},
+		},
+	}
+
+	rr, err := frr.resolve(trigger)
+	assert.Nil(t, err)
+	assert.Equal(t, resolveResultType(resolveResultSelectedFunctions), rr.resolveResultType)
+	assert.Len(t, rr.functionMap, 2)
+
+	// a new matching function is picked up once the cache is invalidated
+	assert.Nil(t, fnStore.Add(&fv1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-4", Namespace: metav1.NamespaceDefault, Labels: map[string]string{"app": "foo"}},
+	}))
+	assert.Nil(t, frr.deleteBySelector(metav1.NamespaceDefault))
+	rr, err = frr.resolve(trigger)
+	assert.Nil(t, err)
+	assert.Len(t, rr.functionMap, 3)
+	assert.NotNil(t, rr.functionMap["foo-4"])
+
+	trigger.ObjectMeta.ResourceVersion = "2"
+	trigger.Spec.FunctionReference.Selector = map[string]string{"app": "baz"}
+	_, err = frr.resolve(trigger)
+	assert.NotNil(t, err)
+}
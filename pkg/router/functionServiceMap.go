@@ -30,6 +30,10 @@ type (
 	functionServiceMap struct {
 		logger *zap.Logger
 		cache  *cache.Cache // map[metadataKey]*url.URL
+
+		// instances tracks the function services of the functions
+		// with a concurrency limit per instance instead.
+		instances *instanceTracker
 	}
 
 	// metav1.ObjectMeta is not hashable, so we make a hashable copy
@@ -43,8 +47,9 @@ type (
 
 func makeFunctionServiceMap(logger *zap.Logger, expiry time.Duration) *functionServiceMap {
 	return &functionServiceMap{
-		logger: logger.Named("function_service_map"),
-		cache:  cache.MakeCache(expiry, 0),
+		logger:    logger.Named("function_service_map"),
+		cache:     cache.MakeCache(expiry, 0),
+		instances: makeInstanceTracker(expiry),
 	}
 }
 
@@ -82,3 +87,16 @@ func (fmap *functionServiceMap) remove(f *metav1.ObjectMeta) error {
 	mk := keyFromMetadata(f)
 	return fmap.cache.Delete(*mk)
 }
+
+// removeGone removes the function service executor doesn't know anymore,
+// e.g. its pod was released as idle, so that requests don't go to it.
+func (fmap *functionServiceMap) removeGone(f *metav1.ObjectMeta, serviceUrl string) {
+	u, err := url.Parse(serviceUrl)
+	if err != nil {
+		return
+	}
+	fmap.instances.remove(f, u)
+	if cached, err := fmap.lookup(f); err == nil && cached.Host == u.Host {
+		fmap.remove(f)
+	}
+}
@@ -52,3 +52,25 @@ func TestFunctionServiceMap(t *testing.T) {
 		t.Errorf("No error on missing entry")
 	}
 }
+
+func TestFunctionServiceMapRemoveGone(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	panicIf(err)
+
+	m := makeFunctionServiceMap(logger, 0)
+	fn := &metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault}
+	u, err := url.Parse("http://10.0.0.1:8888")
+	panicIf(err)
+	m.assign(fn, u)
+
+	// another function service of the function being gone keeps the cached one
+	m.removeGone(fn, "http://10.0.0.2:8888")
+	if _, err := m.lookup(fn); err != nil {
+		t.Errorf("expected function service to remain, got %v", err)
+	}
+
+	m.removeGone(fn, "http://10.0.0.1:8888")
+	if _, err := m.lookup(fn); err == nil {
+		t.Errorf("expected gone function service to be removed")
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+const (
+	grpcContentType = "application/grpc"
+
+	// status codes of gRPC, see https://github.com/grpc/grpc/blob/master/doc/statuscodes.md
+	grpcStatusCanceled          = 1
+	grpcStatusDeadlineExceeded  = 4
+	grpcStatusResourceExhausted = 8
+	grpcStatusUnavailable       = 14
+)
+
+// grpcTransports keeps the HTTP/2 cleartext (h2c) transports to function pods
+// by dial timeout, so that a gRPC call reuses the connection of previous calls
+// instead of a new one per call like the HTTP/1 transport of RoundTrip.
+var (
+	grpcTransports     = make(map[time.Duration]*http2.Transport)
+	grpcTransportsLock sync.Mutex
+)
+
+// isGRPC returns true if the handler proxies gRPC calls.
+func (fh functionHandler) isGRPC() bool {
+	return fh.httpTrigger != nil && fh.httpTrigger.Spec.IsGRPC()
+}
+
+// getGRPCTransport returns the h2c transport dialing function pods within timeout.
+func (roundTripper *RetryingRoundTripper) getGRPCTransport(timeout time.Duration) http.RoundTripper {
+	grpcTransportsLock.Lock()
+	defer grpcTransportsLock.Unlock()
+
+	if t, ok := grpcTransports[timeout]; ok {
+		return t
+	}
+	t := &http2.Transport{
+		// function pods serve gRPC without TLS
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{
+				Timeout:   timeout,
+				KeepAlive: roundTripper.funcHandler.tsRoundTripperParams.keepAliveTime,
+			}).Dial(network, addr)
+		},
+	}
+	grpcTransports[timeout] = t
+	return t
+}
+
+// writeGRPCError replies a gRPC error to the call as a trailers-only response,
+// gRPC clients expect HTTP 200 with the status in grpc-status header.
+func writeGRPCError(rw http.ResponseWriter, code int, msg string) {
+	rw.Header().Set("Content-Type", grpcContentType)
+	rw.Header().Set("Grpc-Status", strconv.Itoa(code))
+	rw.Header().Set("Grpc-Message", encodeGRPCMessage(msg))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// encodeGRPCMessage percent-encodes the message for the Grpc-Message header,
+// see https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-HTTP2.md#responses
+func encodeGRPCMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c < ' ' || c > '~' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// grpcErrorStatus maps the error of proxying a gRPC call to its gRPC status
+// code and the equivalent HTTP status for metrics.
+func grpcErrorStatus(req *http.Request, err error) (code int, status int, msg string) {
+	switch {
+	case bodyLimitExceeded(req.Body):
+		return grpcStatusResourceExhausted, http.StatusRequestEntityTooLarge, "request exceeds the size limit of trigger"
+	case req.Context().Err() == context.DeadlineExceeded, err == context.DeadlineExceeded:
+		return grpcStatusDeadlineExceeded, http.StatusGatewayTimeout, "function did not respond before the timeout"
+	case err == context.Canceled:
+		return grpcStatusCanceled, 499, "client closes the connection"
+	default:
+		return grpcStatusUnavailable, http.StatusBadGateway, "error sending request to function"
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"bytes"
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func makeGRPCTestHandler(t *testing.T, backend string) *functionHandler {
+	backendURL, err := url.Parse(backend)
+	assert.Nil(t, err)
+
+	fnMeta := metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault, UID: "1"}
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+
+	fh := makeTestFunctionHandler(logger, fnMeta, backendURL)
+	fh.tsRoundTripperParams.maxRetries = 1
+	fh.httpTrigger = &fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "xxx", Namespace: metav1.NamespaceDefault},
+		Spec: fv1.HTTPTriggerSpec{
+			RelativeURL:       "/helloworld.Greeter/",
+			FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName},
+			Protocol:          fv1.HTTPTriggerProtocolGRPC,
+		},
+	}
+	return fh
+}
+
+func grpcTestClient() *http.Client {
+	return &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		},
+	}
+}
+
+func TestGRPCProxy(t *testing.T) {
+	// a unary call answered with the status in trailers
+	backend := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 || r.URL.Path != "/helloworld.Greeter/SayHello" || r.Header.Get("Te") != "trailers" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", grpcContentType)
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.Write(body)
+		w.Header().Set("Grpc-Status", "0")
+	}), &http2.Server{}))
+	defer backend.Close()
+
+	fh := makeGRPCTestHandler(t, backend.URL)
+	server := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(fh.handler), &http2.Server{}))
+	defer server.Close()
+
+	client := grpcTestClient()
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/helloworld.Greeter/SayHello", bytes.NewBufferString("\x00\x00\x00\x00\x02hi"))
+	assert.Nil(t, err)
+	req.Header.Set("Content-Type", grpcContentType)
+	req.Header.Set("Te", "trailers")
+
+	resp, err := client.Do(req)
+	assert.Nil(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "\x00\x00\x00\x00\x02hi", string(body))
+	assert.Equal(t, "0", resp.Trailer.Get("Grpc-Status"))
+
+	// the call fails with UNAVAILABLE instead of a HTTP error if function is unreachable
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	l.Close()
+	fh = makeGRPCTestHandler(t, "http://"+l.Addr().String())
+	server = httptest.NewServer(h2c.NewHandler(http.HandlerFunc(fh.handler), &http2.Server{}))
+	defer server.Close()
+
+	req, err = http.NewRequest(http.MethodPost, server.URL+"/helloworld.Greeter/SayHello", bytes.NewBufferString("\x00\x00\x00\x00\x02hi"))
+	assert.Nil(t, err)
+	req.Header.Set("Content-Type", grpcContentType)
+
+	resp, err = client.Do(req)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, grpcContentType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, "14", resp.Header.Get("Grpc-Status"))
+	assert.Equal(t, "error sending request to function", resp.Header.Get("Grpc-Message"))
+}
+
+func TestEncodeGRPCMessage(t *testing.T) {
+	assert.Equal(t, "function did not respond", encodeGRPCMessage("function did not respond"))
+	assert.Equal(t, "100%25 done", encodeGRPCMessage("100% done"))
+	assert.Equal(t, "line%0Abreak", encodeGRPCMessage("line\nbreak"))
+	assert.Equal(t, "caf%C3%A9", encodeGRPCMessage("café"))
+}
@@ -22,7 +22,9 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
@@ -53,10 +55,20 @@ type HTTPTriggerSet struct {
 	functions                  []fv1.Function
 	funcStore                  k8sCache.Store
 	funcController             k8sCache.Controller
+	aliases                    []fv1.FunctionAlias
+	aliasStore                 k8sCache.Store
+	aliasController            k8sCache.Controller
+	secrets                    *secretWatcher
+	mirrorSlots                chan struct{}
 	updateRouterRequestChannel chan struct{}
 	tsRoundTripperParams       *tsRoundTripperParams
 	isDebugEnv                 bool
 	svcAddrUpdateThrottler     *throttler.Throttler
+	rateLimiters               *rateLimiterSet
+	responseCaches             *responseCacheSet
+	asyncInvoker               *asyncInvoker
+	accessLogger               *accessLogger
+	serverTiming               bool
 }
 
 func makeHTTPTriggerSet(logger *zap.Logger, fmap *functionServiceMap, fissionClient *crd.FissionClient,
@@ -74,6 +86,9 @@ func makeHTTPTriggerSet(logger *zap.Logger, fmap *functionServiceMap, fissionCli
 		tsRoundTripperParams:       params,
 		isDebugEnv:                 isDebugEnv,
 		svcAddrUpdateThrottler:     actionThrottler,
+		rateLimiters:               makeRateLimiterSet(),
+		responseCaches:             makeResponseCacheSet(),
+		mirrorSlots:                make(chan struct{}, maxConcurrentMirrorRequests),
 	}
 	var tStore, fnStore k8sCache.Store
 	var tController, fnController k8sCache.Controller
@@ -85,6 +100,10 @@ func makeHTTPTriggerSet(logger *zap.Logger, fmap *functionServiceMap, fissionCli
 		fnStore, fnController = httpTriggerSet.initFunctionController()
 		httpTriggerSet.funcStore = fnStore
 		httpTriggerSet.funcController = fnController
+		httpTriggerSet.aliasStore, httpTriggerSet.aliasController = httpTriggerSet.initFunctionAliasController()
+	}
+	if httpTriggerSet.kubeClient != nil {
+		httpTriggerSet.secrets = makeSecretWatcher(httpTriggerSet.logger, httpTriggerSet.kubeClient)
 	}
 	return httpTriggerSet, tStore, fnStore
 }
@@ -103,6 +122,7 @@ func (ts *HTTPTriggerSet) subscribeRouter(ctx context.Context, mr *mutableRouter
 	go ts.syncTriggers()
 	go ts.runWatcher(ctx, ts.funcController)
 	go ts.runWatcher(ctx, ts.triggerController)
+	go ts.runWatcher(ctx, ts.aliasController)
 }
 
 func defaultHomeHandler(w http.ResponseWriter, r *http.Request) {
@@ -116,11 +136,30 @@ func routerHealthHandler(w http.ResponseWriter, r *http.Request) {
 func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) *mux.Router {
 	muxRouter := mux.NewRouter()
 
+	// drop the rate limiters and response caches of deleted triggers
+	ts.rateLimiters.retain(ts.triggers)
+	ts.responseCaches.retain(ts.triggers)
+	if ts.secrets != nil {
+		ts.secrets.retain(ts.triggers)
+	}
+
 	// HTTP triggers setup by the user
 	homeHandled := false
+	preflights := make(map[string]*corsPreflight)
+	asyncHandlers := make(map[string]functionHandler)
 	for i := range ts.triggers {
 		trigger := ts.triggers[i]
 
+		// gorilla/mux panics on some invalid route templates, skip
+		// triggers created without going through the validation.
+		if _, _, err := fv1.ParseRouteTemplate(trigger.Spec.RelativeURL); err != nil {
+			ts.logger.Error("invalid relative url of trigger, skipping it",
+				zap.Error(err),
+				zap.String("trigger", trigger.ObjectMeta.Name),
+				zap.String("url", trigger.Spec.RelativeURL))
+			continue
+		}
+
 		// resolve function reference
 		rr, err := ts.resolver.resolve(trigger)
 		if err != nil {
@@ -132,7 +171,8 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) *mux.Router
 			continue
 		}
 
-		if rr.resolveResultType != resolveResultSingleFunction && rr.resolveResultType != resolveResultMultipleFunctions {
+		if rr.resolveResultType != resolveResultSingleFunction && rr.resolveResultType != resolveResultMultipleFunctions &&
+			rr.resolveResultType != resolveResultSelectedFunctions {
 			// not implemented yet
 			ts.logger.Panic("resolve result type not implemented", zap.Any("type", rr.resolveResultType))
 		}
@@ -148,14 +188,23 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) *mux.Router
 			isDebugEnv:               ts.isDebugEnv,
 			svcAddrUpdateThrottler:   ts.svcAddrUpdateThrottler,
 			functionTimeoutMap:       fnTimeoutMap,
+			rateLimiter:              ts.rateLimiters.get(&trigger),
+			cors:                     makeCORSHandler(&trigger),
+			auth:                     makeAuthenticator(&trigger, ts.getSecret),
+			responseCache:            ts.responseCaches.get(&trigger),
+			async:                    ts.asyncInvoker,
+			asyncKey:                 asyncHandlerKey("trigger", &trigger.ObjectMeta),
+			accessLog:                ts.accessLogger,
+			serverTiming:             ts.serverTiming,
 		}
 
 		// The functionHandler for HTTP trigger with fn reference type "FunctionReferenceTypeFunctionName",
 		// it's function metadata is set here.
 
 		// The functionHandler For HTTP trigger with fn reference type "FunctionReferenceTypeFunctionWeights",
-		// it's function metadata is decided dynamically before proxying the request in order to support canary
-		// deployment. For more details, please check "handler" function of functionHandler.
+		// or a function alias with weights, it's function metadata is decided dynamically before proxying the
+		// request in order to support canary deployment. For more details, please check "invoke" function of
+		// functionHandler.
 
 		if rr.resolveResultType == resolveResultSingleFunction {
 			for _, fn := range fh.functionMap {
@@ -163,12 +212,52 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) *mux.Router
 			}
 		}
 
-		ht := muxRouter.HandleFunc(trigger.Spec.RelativeURL, fh.handler)
-		ht.Methods(trigger.Spec.Method)
+		// The functionHandler for HTTP trigger with fn reference type "FunctionReferenceTypeFunctionSelector"
+		// picks one of the matching functions per request by its load balancing strategy.
+		if rr.resolveResultType == resolveResultSelectedFunctions {
+			fh.balancer = makeFunctionBalancer(trigger.Spec.FunctionReference.LoadBalancing, rr.functionMap)
+		}
+
+		if trigger.Spec.Mirror != nil {
+			fh.mirror = ts.getMirrorHandler(&trigger, fnTimeoutMap)
+			fh.mirrorSlots = ts.mirrorSlots
+		}
+
+		if fh.cors != nil {
+			// answer preflight requests in router, it has to be registered
+			// before the trigger in case the trigger accepts OPTIONS too.
+			// Triggers with the same host and url share the route, since
+			// mux only ever matches the first one registered.
+			key := corsPreflightKey(&trigger)
+			if pf, ok := preflights[key]; ok {
+				pf.add(fh.cors)
+			} else {
+				pf = &corsPreflight{}
+				pf.add(fh.cors)
+				preflights[key] = pf
+				route := muxRouter.HandleFunc(trigger.Spec.RelativeURL, pf.handler)
+				route.Methods(http.MethodOptions).Headers(corsRequestMethod, "")
+				if trigger.Spec.Host != "" {
+					route.Host(trigger.Spec.Host)
+				}
+			}
+		}
+
+		asyncHandlers[fh.asyncKey] = *fh
+
+		var ht *mux.Route
+		if trigger.Spec.IsGRPC() {
+			// a gRPC trigger serves all the methods under its path, e.g. "/helloworld.Greeter/"
+			ht = muxRouter.PathPrefix(trigger.Spec.RelativeURL).HandlerFunc(fh.handler)
+			ht.Methods(http.MethodPost).HeadersRegexp("Content-Type", "^"+grpcContentType)
+		} else {
+			ht = muxRouter.HandleFunc(trigger.Spec.RelativeURL, fh.handler)
+			ht.Methods(trigger.Spec.GetMethods()...)
+		}
 		if trigger.Spec.Host != "" {
 			ht.Host(trigger.Spec.Host)
 		}
-		if trigger.Spec.RelativeURL == "/" && trigger.Spec.Method == "GET" {
+		if trigger.Spec.RelativeURL == "/" && !trigger.Spec.IsGRPC() && trigger.Spec.HasMethod(http.MethodGet) {
 			homeHandled = true
 		}
 	}
@@ -196,16 +285,92 @@ func (ts *HTTPTriggerSet) getRouter(fnTimeoutMap map[types.UID]int) *mux.Router
 			isDebugEnv:             ts.isDebugEnv,
 			svcAddrUpdateThrottler: ts.svcAddrUpdateThrottler,
 			functionTimeoutMap:     fnTimeoutMap,
+			async:                  ts.asyncInvoker,
+			asyncKey:               asyncHandlerKey("function", &fn.ObjectMeta),
+			accessLog:              ts.accessLogger,
+			serverTiming:           ts.serverTiming,
 		}
+		asyncHandlers[fh.asyncKey] = *fh
 		muxRouter.HandleFunc(utils.UrlForFunction(fn.ObjectMeta.Name, fn.ObjectMeta.Namespace), fh.handler)
 	}
 
+	// Internal triggers for each function alias by name. Non-http
+	// triggers referencing an alias route into these.
+	for i := range ts.aliases {
+		alias := ts.aliases[i]
+		rr, err := ts.resolver.resolveByAlias(alias.ObjectMeta.Namespace, alias.ObjectMeta.Name)
+		if err != nil {
+			ts.logger.Error("error resolving function alias, skipping it",
+				zap.Error(err),
+				zap.String("alias", alias.ObjectMeta.Name),
+				zap.String("namespace", alias.ObjectMeta.Namespace))
+			continue
+		}
+		fh := &functionHandler{
+			logger:                   ts.logger.Named(alias.ObjectMeta.Name),
+			fmap:                     ts.functionServiceMap,
+			executor:                 ts.executor,
+			functionMap:              rr.functionMap,
+			fnWeightDistributionList: rr.functionWtDistributionList,
+			tsRoundTripperParams:     ts.tsRoundTripperParams,
+			isDebugEnv:               ts.isDebugEnv,
+			svcAddrUpdateThrottler:   ts.svcAddrUpdateThrottler,
+			functionTimeoutMap:       fnTimeoutMap,
+			async:                    ts.asyncInvoker,
+			asyncKey:                 asyncHandlerKey("alias", &alias.ObjectMeta),
+			accessLog:                ts.accessLogger,
+			serverTiming:             ts.serverTiming,
+		}
+		if rr.resolveResultType == resolveResultSingleFunction {
+			for _, fn := range fh.functionMap {
+				fh.function = fn
+			}
+		}
+		asyncHandlers[fh.asyncKey] = *fh
+		muxRouter.HandleFunc(utils.UrlForFunctionAlias(alias.ObjectMeta.Name, alias.ObjectMeta.Namespace), fh.handler)
+	}
+
+	// Status and result of async invocations.
+	if ts.asyncInvoker != nil {
+		ts.asyncInvoker.setHandlers(asyncHandlers)
+		muxRouter.HandleFunc(asyncInvocationPrefix+"{id}", ts.asyncInvoker.statusHandler).Methods("GET")
+		muxRouter.HandleFunc(asyncInvocationPrefix+"{id}/result", ts.asyncInvoker.statusHandler).Methods("GET")
+	}
+
 	// Healthz endpoint for the router.
 	muxRouter.HandleFunc("/router-healthz", routerHealthHandler).Methods("GET")
 
 	return muxRouter
 }
 
+// getMirrorHandler returns the handler of the mirror function of trigger,
+// or nil if the function doesn't exist.
+func (ts *HTTPTriggerSet) getMirrorHandler(trigger *fv1.HTTPTrigger, fnTimeoutMap map[types.UID]int) *functionHandler {
+	for i := range ts.functions {
+		fn := ts.functions[i]
+		if fn.ObjectMeta.Namespace != trigger.ObjectMeta.Namespace || fn.ObjectMeta.Name != trigger.Spec.Mirror.FunctionName {
+			continue
+		}
+		// the mirror handler doesn't have the trigger so that the limits
+		// of trigger aren't applied to the mirrored requests twice.
+		return &functionHandler{
+			logger:                 ts.logger.Named(trigger.ObjectMeta.Name).Named("mirror"),
+			fmap:                   ts.functionServiceMap,
+			function:               &fn,
+			executor:               ts.executor,
+			tsRoundTripperParams:   ts.tsRoundTripperParams,
+			isDebugEnv:             ts.isDebugEnv,
+			svcAddrUpdateThrottler: ts.svcAddrUpdateThrottler,
+			functionTimeoutMap:     fnTimeoutMap,
+		}
+	}
+	ts.logger.Error("mirror function of trigger not found, requests are not mirrored",
+		zap.String("trigger", trigger.ObjectMeta.Name),
+		zap.String("namespace", trigger.ObjectMeta.Namespace),
+		zap.String("function", trigger.Spec.Mirror.FunctionName))
+	return nil
+}
+
 func (ts *HTTPTriggerSet) updateTriggerStatusFailed(ht *fv1.HTTPTrigger, err error) {
 	// TODO
 }
@@ -246,9 +411,17 @@ func (ts *HTTPTriggerSet) initFunctionController() (k8sCache.Store, k8sCache.Con
 	store, controller := k8sCache.NewInformer(listWatch, &fv1.Function{}, resyncPeriod,
 		k8sCache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
+				fn := obj.(*fv1.Function)
+				ts.invalidateSelectors(fn.ObjectMeta.Namespace)
 				ts.syncTriggers()
 			},
 			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(k8sCache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				if fn, ok := obj.(*fv1.Function); ok {
+					ts.invalidateSelectors(fn.ObjectMeta.Namespace)
+				}
 				ts.syncTriggers()
 			},
 			UpdateFunc: func(oldObj interface{}, newObj interface{}) {
@@ -259,6 +432,9 @@ func (ts *HTTPTriggerSet) initFunctionController() (k8sCache.Store, k8sCache.Con
 					return
 				}
 
+				// the labels of function may be changed
+				ts.invalidateSelectors(fn.ObjectMeta.Namespace)
+
 				// update resolver function reference cache
 				for key, rr := range ts.resolver.copy() {
 					if key.namespace == fn.ObjectMeta.Namespace &&
@@ -279,6 +455,70 @@ func (ts *HTTPTriggerSet) initFunctionController() (k8sCache.Store, k8sCache.Con
 	return store, controller
 }
 
+// invalidateSelectors drops the resolver cache of the triggers referencing
+// functions by selector in namespace.
+func (ts *HTTPTriggerSet) invalidateSelectors(namespace string) {
+	err := ts.resolver.deleteBySelector(namespace)
+	if err != nil {
+		ts.logger.Error("error deleting functionReferenceResolver cache", zap.Error(err))
+	}
+}
+
+// initFunctionAliasController watches FunctionAliases, the triggers referencing
+// an alias are routed to the functions it points to once it's changed.
+func (ts *HTTPTriggerSet) initFunctionAliasController() (k8sCache.Store, k8sCache.Controller) {
+	resyncPeriod := 30 * time.Second
+	listWatch := k8sCache.NewListWatchFromClient(ts.crdClient, "functionaliases", metav1.NamespaceAll, fields.Everything())
+	store, controller := k8sCache.NewInformer(listWatch, &fv1.FunctionAlias{}, resyncPeriod,
+		k8sCache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				ts.syncTriggers()
+			},
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(k8sCache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				if alias, ok := obj.(*fv1.FunctionAlias); ok {
+					ts.invalidateAlias(alias)
+				}
+				ts.syncTriggers()
+			},
+			UpdateFunc: func(oldObj interface{}, newObj interface{}) {
+				oldAlias := oldObj.(*fv1.FunctionAlias)
+				alias := newObj.(*fv1.FunctionAlias)
+
+				if oldAlias.ObjectMeta.ResourceVersion == alias.ObjectMeta.ResourceVersion {
+					return
+				}
+
+				ts.invalidateAlias(alias)
+				ts.syncTriggers()
+			},
+		})
+	return store, controller
+}
+
+// invalidateAlias drops the resolver cache of the triggers referencing the alias.
+func (ts *HTTPTriggerSet) invalidateAlias(alias *fv1.FunctionAlias) {
+	ts.logger.Debug("invalidating resolver cache of function alias",
+		zap.String("alias", alias.ObjectMeta.Name),
+		zap.String("namespace", alias.ObjectMeta.Namespace))
+	err := ts.resolver.deleteByAlias(alias.ObjectMeta.Namespace, alias.ObjectMeta.Name)
+	if err != nil {
+		ts.logger.Error("error deleting functionReferenceResolver cache", zap.Error(err))
+	}
+}
+
+// getSecret returns a Secret referenced by the auth of a trigger. Secrets are
+// read at request time from the secret watcher, so a change of credentials
+// doesn't rebuild the router.
+func (ts *HTTPTriggerSet) getSecret(namespace, name string) (*apiv1.Secret, error) {
+	if ts.secrets == nil {
+		return nil, errors.New("secret watcher is not running")
+	}
+	return ts.secrets.get(namespace, name)
+}
+
 func (ts *HTTPTriggerSet) runWatcher(ctx context.Context, controller k8sCache.Controller) {
 	go func() {
 		controller.Run(ctx.Done())
@@ -310,6 +550,14 @@ func (ts *HTTPTriggerSet) updateRouter() {
 		}
 		ts.functions = functions
 
+		// get function aliases
+		latestAliases := ts.aliasStore.List()
+		aliases := make([]fv1.FunctionAlias, 0, len(latestAliases))
+		for _, a := range latestAliases {
+			aliases = append(aliases, *a.(*fv1.FunctionAlias))
+		}
+		ts.aliases = aliases
+
 		// make a new router and use it
 		ts.mutableRouter.updateRouter(ts.getRouter(functionTimeout))
 	}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	ferror "github.com/fission/fission/pkg/error"
+	executorClient "github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/publisher"
+)
+
+// jobRunBuffer is how long router waits for a job beyond the function
+// timeout, which is the deadline of job, for executor to create the job
+// and read its output.
+const jobRunBuffer = 30 * time.Second
+
+// isJob returns true if every invocation of function runs as a job.
+func isJob(fn *fv1.Function) bool {
+	return fn.Spec.InvokeStrategy.ExecutionStrategy.ExecutorType == fv1.ExecutorTypeJob
+}
+
+// runJob asks executor to run a job of function for the request, and replies
+// with the output of job once it finishes. The status code is 200 if the job
+// succeeded, 504 if it ran past the function timeout and 500 otherwise, the
+// name and exit code of job are in the response headers.
+//
+// The requests of triggers not reading the result, i.e. time and Kubernetes
+// watch triggers, set the X-Fission-Job-Detach header on the internal route
+// of function, and are replied with 202 and the name of job once it's
+// started. The header is dropped from the requests of HTTP triggers. The result of job is kept in
+// the job history of function.
+func (fh functionHandler) runJob(responseWriter http.ResponseWriter, request *http.Request) {
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(request.Body)
+		if err != nil {
+			if errors.Cause(err) == errRequestBodyTooLarge {
+				fh.recordRejection(request, rejectReasonBodyTooLarge)
+				fh.writeBodyTooLarge(responseWriter)
+				return
+			}
+			http.Error(responseWriter, "error reading request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	fnTimeout := fh.functionTimeoutMap[fh.function.ObjectMeta.GetUID()]
+	if fnTimeout == 0 {
+		fnTimeout = fv1.DEFAULT_FUNCTION_TIMEOUT
+	}
+	ctx, cancel := context.WithTimeout(request.Context(), time.Duration(fnTimeout)*time.Second+jobRunBuffer)
+	defer cancel()
+
+	funcMetricLabels := &functionLabels{
+		namespace: fh.function.ObjectMeta.Namespace,
+		name:      fh.function.ObjectMeta.Name,
+	}
+	inflightDone := functionCallInflight(funcMetricLabels)
+	defer inflightDone()
+
+	detach, _ := strconv.ParseBool(request.Header.Get(publisher.HEADER_FISSION_JOB_DETACH))
+
+	start := time.Now()
+	result, err := fh.executor.RunJob(ctx, &fh.function.ObjectMeta, request.Method, body, detach)
+	if err != nil {
+		status, msg := ferror.GetHTTPError(err)
+		if ctx.Err() == context.DeadlineExceeded {
+			status = http.StatusGatewayTimeout
+		}
+		fh.logger.Error("error running job for function",
+			zap.Error(err),
+			zap.String("function_name", fh.function.ObjectMeta.Name),
+			zap.String("function_namespace", fh.function.ObjectMeta.Namespace))
+		fh.collectJobMetric(start, funcMetricLabels, request, status, 0)
+		http.Error(responseWriter, msg, status)
+		return
+	}
+
+	if detach {
+		responseWriter.Header().Set(executorClient.HEADER_FISSION_JOB_NAME, result.Name)
+		responseWriter.WriteHeader(http.StatusAccepted)
+		responseWriter.Write([]byte(result.Name))
+		fh.collectJobMetric(start, funcMetricLabels, request, http.StatusAccepted, int64(len(result.Name)))
+		return
+	}
+
+	status := http.StatusOK
+	if !result.Succeeded {
+		status = http.StatusInternalServerError
+		if result.Reason == "DeadlineExceeded" {
+			status = http.StatusGatewayTimeout
+		}
+	}
+
+	executorClient.SetJobResultHeaders(responseWriter.Header(), executorClient.JobResult{
+		Name:      result.Name,
+		Succeeded: result.Succeeded,
+		ExitCode:  result.ExitCode,
+		Reason:    result.Reason,
+	})
+	responseWriter.WriteHeader(status)
+	responseWriter.Write(result.Output)
+
+	fh.collectJobMetric(start, funcMetricLabels, request, status, int64(len(result.Output)))
+}
+
+func (fh functionHandler) collectJobMetric(start time.Time, funcMetricLabels *functionLabels, req *http.Request, status int, respSize int64) {
+	duration := time.Since(start)
+
+	httpMetricLabels := &httpLabels{
+		method: req.Method,
+		code:   status,
+	}
+	if fh.httpTrigger != nil {
+		httpMetricLabels.host = fh.httpTrigger.Spec.Host
+		httpMetricLabels.path = fh.httpTrigger.Spec.RelativeURL
+	}
+
+	// every job is a cold start
+	go functionCallCompleted(funcMetricLabels, httpMetricLabels, duration, duration, respSize)
+	go functionCallStarted(funcMetricLabels, string(fv1.ExecutorTypeJob), true, duration)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	executorClient "github.com/fission/fission/pkg/executor/client"
+	"github.com/fission/fission/pkg/publisher"
+)
+
+func TestRunJob(t *testing.T) {
+	// executor runs the job with the request body, which
+	// fails with the exit code of the body if it's not 0.
+	executor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/runJob", r.URL.Path)
+		req := executorClient.JobRunRequest{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "report", req.FnMetadata.Name)
+
+		if req.Detach {
+			w.Header().Set(executorClient.HEADER_FISSION_JOB_NAME, "report-efgh5678")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		exitCode := int32(0)
+		if string(req.Body) != "0" {
+			exitCode = 3
+		}
+		executorClient.SetJobResultHeaders(w.Header(), executorClient.JobResult{
+			Name:      "report-abcd1234",
+			Succeeded: exitCode == 0,
+			ExitCode:  &exitCode,
+		})
+		w.Write([]byte(req.Method + " " + string(req.Body)))
+	}))
+	defer executor.Close()
+
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+	fnMeta := metav1.ObjectMeta{Name: "report", Namespace: metav1.NamespaceDefault, UID: "1"}
+
+	fh := &functionHandler{
+		logger:   logger,
+		executor: executorClient.MakeClient(logger, executor.URL),
+		function: &fv1.Function{
+			ObjectMeta: fnMeta,
+			Spec: fv1.FunctionSpec{
+				InvokeStrategy: fv1.InvokeStrategy{
+					ExecutionStrategy: fv1.ExecutionStrategy{ExecutorType: fv1.ExecutorTypeJob},
+				},
+			},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(fh.handler))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL, "text/plain", strings.NewReader("0"))
+	assert.Nil(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "POST 0", string(body))
+	assert.Equal(t, "report-abcd1234", resp.Header.Get(executorClient.HEADER_FISSION_JOB_NAME))
+	assert.Equal(t, "0", resp.Header.Get(executorClient.HEADER_FISSION_JOB_EXIT_CODE))
+
+	resp, err = http.Post(server.URL, "text/plain", strings.NewReader("1"))
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, executorClient.JobStatusFailed, resp.Header.Get(executorClient.HEADER_FISSION_JOB_STATUS))
+	assert.Equal(t, "3", resp.Header.Get(executorClient.HEADER_FISSION_JOB_EXIT_CODE))
+
+	// a trigger not reading the result gets the name of job once it's started
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	assert.Nil(t, err)
+	req.Header.Set(publisher.HEADER_FISSION_JOB_DETACH, "true")
+	resp, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	body, err = ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "report-efgh5678", string(body))
+	assert.Equal(t, "report-efgh5678", resp.Header.Get(executorClient.HEADER_FISSION_JOB_NAME))
+
+	// a client of HTTP trigger can't ask not to wait for the job
+	fh.httpTrigger = &fv1.HTTPTrigger{ObjectMeta: metav1.ObjectMeta{Name: "report"}}
+	triggerServer := httptest.NewServer(http.HandlerFunc(fh.handler))
+	defer triggerServer.Close()
+	req, err = http.NewRequest(http.MethodPost, triggerServer.URL, strings.NewReader("0"))
+	assert.Nil(t, err)
+	req.Header.Set(publisher.HEADER_FISSION_JOB_DETACH, "true")
+	resp, err = http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	body, err = ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "POST 0", string(body))
+}
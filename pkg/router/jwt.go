@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+)
+
+// jwtClockSkew is the leeway allowed when checking the time based claims.
+const jwtClockSkew = time.Minute
+
+type (
+	// jsonWebKey is a key in JWKS, see RFC 7517.
+	jsonWebKey struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Alg string `json:"alg"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+		K   string `json:"k"`
+	}
+
+	// verificationKey is a parsed key used to verify JWT signatures.
+	verificationKey struct {
+		kid string
+		alg string
+		// one of *rsa.PublicKey, *ecdsa.PublicKey or []byte
+		key interface{}
+	}
+)
+
+// jwtParser verifies the signature of tokens, the time based claims are
+// checked by verifyJWT with clock skew allowed.
+var jwtParser = &jwt.Parser{
+	ValidMethods: []string{
+		"RS256", "RS384", "RS512",
+		"ES256", "ES384", "ES512",
+		"HS256", "HS384", "HS512",
+	},
+	SkipClaimsValidation: true,
+}
+
+// parseJWKS parses the keys in a JSON Web Key Set. Keys of unsupported
+// types or for usages other than signature are skipped.
+func parseJWKS(data []byte) ([]verificationKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	err := json.Unmarshal(data, &set)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing JWKS")
+	}
+
+	var keys []verificationKey
+	for _, k := range set.Keys {
+		if len(k.Use) > 0 && k.Use != "sig" {
+			continue
+		}
+		vk := verificationKey{kid: k.Kid, alg: k.Alg}
+		switch k.Kty {
+		case "RSA":
+			n, err := jwt.DecodeSegment(k.N)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error decoding modulus of key %q", k.Kid)
+			}
+			e, err := jwt.DecodeSegment(k.E)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error decoding exponent of key %q", k.Kid)
+			}
+			vk.key = &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			}
+		case "EC":
+			var curve elliptic.Curve
+			switch k.Crv {
+			case "P-256":
+				curve = elliptic.P256()
+			case "P-384":
+				curve = elliptic.P384()
+			case "P-521":
+				curve = elliptic.P521()
+			default:
+				continue
+			}
+			x, err := jwt.DecodeSegment(k.X)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error decoding x coordinate of key %q", k.Kid)
+			}
+			y, err := jwt.DecodeSegment(k.Y)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error decoding y coordinate of key %q", k.Kid)
+			}
+			vk.key = &ecdsa.PublicKey{
+				Curve: curve,
+				X:     new(big.Int).SetBytes(x),
+				Y:     new(big.Int).SetBytes(y),
+			}
+		case "oct":
+			secret, err := jwt.DecodeSegment(k.K)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error decoding key %q", k.Kid)
+			}
+			vk.key = secret
+		default:
+			continue
+		}
+		keys = append(keys, vk)
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no signing key found in JWKS")
+	}
+	return keys, nil
+}
+
+// ecdsaCurve returns the curve an ECDSA algorithm is defined on, see RFC 7518 section 3.4.
+func ecdsaCurve(alg string) elliptic.Curve {
+	switch alg {
+	case "ES256":
+		return elliptic.P256()
+	case "ES384":
+		return elliptic.P384()
+	case "ES512":
+		return elliptic.P521()
+	default:
+		return nil
+	}
+}
+
+// keyForAlgorithm returns the key to verify signatures of the algorithm
+// with, if the type of key supports the algorithm.
+func keyForAlgorithm(alg string, key interface{}) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(alg, "HS"):
+		if secret, ok := key.([]byte); ok {
+			return secret, nil
+		}
+	case strings.HasPrefix(alg, "RS"):
+		if pub, ok := key.(*rsa.PublicKey); ok {
+			return pub, nil
+		}
+	case strings.HasPrefix(alg, "ES"):
+		// jwt-go doesn't check the curve of key
+		if pub, ok := key.(*ecdsa.PublicKey); ok && pub.Curve == ecdsaCurve(alg) {
+			return pub, nil
+		}
+	}
+	return nil, errors.Errorf("key doesn't support algorithm %q", alg)
+}
+
+// verifyJWT verifies the signature and the time based claims of a compact
+// serialized JWT, and returns its claims. Tokens without "exp" claim are
+// rejected if requireExp is true.
+func verifyJWT(token string, keys []verificationKey, now time.Time, requireExp bool) (map[string]interface{}, error) {
+	var claims jwt.MapClaims
+	verified := false
+	for _, k := range keys {
+		k := k
+		t, err := jwtParser.Parse(token, func(t *jwt.Token) (interface{}, error) {
+			kid, _ := t.Header["kid"].(string)
+			if len(kid) > 0 && len(k.kid) > 0 && kid != k.kid {
+				return nil, errors.New("key id mismatch")
+			}
+			// the algorithm of key takes precedence over the one in token
+			// header to prevent algorithm substitution attacks.
+			alg := t.Method.Alg()
+			if len(k.alg) > 0 && k.alg != alg {
+				return nil, errors.Errorf("key is for algorithm %q", k.alg)
+			}
+			return keyForAlgorithm(alg, k.key)
+		})
+		if verr, ok := err.(*jwt.ValidationError); ok && verr.Errors&jwt.ValidationErrorMalformed != 0 {
+			return nil, errors.Wrap(verr, "malformed token")
+		}
+		if err == nil && t.Valid {
+			claims = t.Claims.(jwt.MapClaims)
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("token signature verification failed")
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok && requireExp {
+		return nil, errors.New("token has no expiration time")
+	}
+	if ok && now.After(time.Unix(int64(exp), 0).Add(jwtClockSkew)) {
+		return nil, errors.New("token is expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(jwtClockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return nil, errors.New("token is not valid yet")
+	}
+
+	return claims, nil
+}
+
+// hasAudience checks whether the "aud" claim, a string or an array of strings, contains aud.
+func hasAudience(claims map[string]interface{}, aud string) bool {
+	switch v := claims["aud"].(type) {
+	case string:
+		return v == aud
+	case []interface{}:
+		for _, a := range v {
+			if s, ok := a.(string); ok && s == aud {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"sort"
+	"sync/atomic"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// functionBalancer spreads the requests of a trigger referencing functions by
+// selector across the matching functions. It's made with the router, so the
+// in-flight requests of a previous router aren't counted by least-inflight.
+type functionBalancer struct {
+	next      uint64 // first for 64-bit alignment of atomic ops
+	strategy  fv1.LoadBalancing
+	functions []*fv1.Function
+	inflight  []int64
+}
+
+func makeFunctionBalancer(strategy fv1.LoadBalancing, functionMap map[string]*fv1.Function) *functionBalancer {
+	functions := make([]*fv1.Function, 0, len(functionMap))
+	for _, fn := range functionMap {
+		functions = append(functions, fn)
+	}
+	// keep the order stable for round robin
+	sort.Slice(functions, func(i, j int) bool {
+		return functions[i].ObjectMeta.Name < functions[j].ObjectMeta.Name
+	})
+
+	if len(strategy) == 0 {
+		strategy = fv1.LoadBalancingRoundRobin
+	}
+
+	return &functionBalancer{
+		strategy:  strategy,
+		functions: functions,
+		inflight:  make([]int64, len(functions)),
+	}
+}
+
+// pick returns the function to send a request to, done must be called
+// once the request is served.
+func (b *functionBalancer) pick() (fn *fv1.Function, done func()) {
+	n := uint64(len(b.functions))
+	start := (atomic.AddUint64(&b.next, 1) - 1) % n
+
+	i := start
+	if b.strategy == fv1.LoadBalancingLeastInflight {
+		// start scanning from the round robin position so that
+		// idle functions share the requests equally.
+		min := atomic.LoadInt64(&b.inflight[start])
+		for k := uint64(1); k < n && min > 0; k++ {
+			j := (start + k) % n
+			if c := atomic.LoadInt64(&b.inflight[j]); c < min {
+				i, min = j, c
+			}
+		}
+	}
+
+	atomic.AddInt64(&b.inflight[i], 1)
+	return b.functions[i], func() {
+		atomic.AddInt64(&b.inflight[i], -1)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func makeBalancerTestFunctions(names ...string) map[string]*fv1.Function {
+	fnMap := make(map[string]*fv1.Function)
+	for _, name := range names {
+		fnMap[name] = &fv1.Function{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	return fnMap
+}
+
+func TestFunctionBalancerRoundRobin(t *testing.T) {
+	b := makeFunctionBalancer("", makeBalancerTestFunctions("c", "a", "b"))
+
+	var picked []string
+	for i := 0; i < 6; i++ {
+		fn, done := b.pick()
+		done()
+		picked = append(picked, fn.ObjectMeta.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "a", "b", "c"}, picked)
+}
+
+func TestFunctionBalancerLeastInflight(t *testing.T) {
+	b := makeFunctionBalancer(fv1.LoadBalancingLeastInflight, makeBalancerTestFunctions("a", "b", "c"))
+
+	// "a" and "b" are busy with long requests
+	a, doneA := b.pick()
+	bb, doneB := b.pick()
+	assert.Equal(t, "a", a.ObjectMeta.Name)
+	assert.Equal(t, "b", bb.ObjectMeta.Name)
+
+	// the following requests go to the idle "c" as long as it's idle
+	for i := 0; i < 3; i++ {
+		fn, done := b.pick()
+		assert.Equal(t, "c", fn.ObjectMeta.Name)
+		done()
+	}
+
+	// "a" is idle again
+	doneA()
+	fn, done := b.pick()
+	defer done()
+	assert.NotEqual(t, "b", fn.ObjectMeta.Name)
+	doneB()
+}
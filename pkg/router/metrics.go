@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+
+	executorClient "github.com/fission/fission/pkg/executor/client"
 )
 
 var globalFunctionCallCount uint64
@@ -90,6 +92,97 @@ var (
 		},
 		labelsStrings,
 	)
+
+	// Duration of function calls by whether executor specialized
+	// a new function service for the call
+	// executor_type: executor type of function
+	// start: cold | warm
+	functionCallStartDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "fission_function_call_duration_by_start_seconds",
+			Help:    "Duration of the function calls, by cold starts and warm calls",
+			Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"namespace", "name", "executor_type", "start"},
+	)
+
+	// In-flight requests of function, newdeploy functions may scale on it
+	functionInflightRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fission_function_inflight_requests",
+			Help: "Number of the function calls being served",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// Time spent on the steps of specialization of the cold starts
+	// executor_type: executor type of function
+	// step: podselection | fetch | load | specialize (the total)
+	functionSpecializationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "fission_function_specialization_seconds",
+			Help:    "Time spent on the steps of specializing a function service for a cold start",
+			Buckets: []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120},
+		},
+		[]string{"namespace", "name", "executor_type", "step"},
+	)
+
+	// Requests rejected by router before proxying to the function
+	// namespace: trigger namespace
+	// trigger: trigger name
+	// reason: why router rejected the request, e.g. rate-limit | max-in-flight | unauthorized | body-too-large | timeout
+	triggerRequestsRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_trigger_requests_rejected_total",
+			Help: "Count of requests rejected by the router before reaching the function",
+		},
+		[]string{"namespace", "trigger", "host", "path", "method", "reason"},
+	)
+
+	// Lookups of the trigger response cache
+	// namespace: trigger namespace
+	// trigger: trigger name
+	// result: hit | miss
+	triggerResponseCacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_trigger_response_cache_lookups_total",
+			Help: "Count of response cache lookups of HTTP triggers",
+		},
+		[]string{"namespace", "trigger", "result"},
+	)
+
+	// Requests mirrored to the mirror function of HTTP trigger
+	// namespace: trigger namespace
+	// trigger: trigger name
+	// code: status code replied by the trigger's function
+	// mirror_code: status code replied by the mirror function
+	triggerMirrorRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_trigger_mirror_requests_total",
+			Help: "Count of requests mirrored to the mirror function of HTTP triggers",
+		},
+		[]string{"namespace", "trigger", "code", "mirror_code"},
+	)
+
+	// Requests not mirrored because router runs too many mirrored requests
+	triggerMirrorDroppedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fission_trigger_mirror_dropped_total",
+			Help: "Count of requests of HTTP triggers not mirrored because router reached the limit of concurrent mirrored requests",
+		},
+		[]string{"namespace", "trigger"},
+	)
+
+	// Duration of mirrored requests
+	// target: function | mirror
+	triggerMirrorDuration = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name:       "fission_trigger_mirror_duration_seconds",
+			Help:       "Duration of the mirrored requests of HTTP triggers, by the trigger's function and the mirror function",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{"namespace", "trigger", "target"},
+	)
 )
 
 func init() {
@@ -98,6 +191,14 @@ func init() {
 	prometheus.MustRegister(functionCallDuration)
 	prometheus.MustRegister(functionCallOverhead)
 	prometheus.MustRegister(functionCallResponseSize)
+	prometheus.MustRegister(functionCallStartDuration)
+	prometheus.MustRegister(functionSpecializationDuration)
+	prometheus.MustRegister(functionInflightRequests)
+	prometheus.MustRegister(triggerRequestsRejected)
+	prometheus.MustRegister(triggerResponseCacheLookups)
+	prometheus.MustRegister(triggerMirrorRequests)
+	prometheus.MustRegister(triggerMirrorDroppedRequests)
+	prometheus.MustRegister(triggerMirrorDuration)
 }
 
 func labelsToStrings(f *functionLabels, h *httpLabels) []string {
@@ -142,3 +243,56 @@ func functionCallCompleted(f *functionLabels, h *httpLabels, overhead, duration
 		functionCallResponseSize.WithLabelValues(l...).Observe(float64(respSize))
 	}
 }
+
+func functionCallStarted(f *functionLabels, executorType string, coldStart bool, duration time.Duration) {
+	start := "warm"
+	if coldStart {
+		start = "cold"
+	}
+	functionCallStartDuration.WithLabelValues(f.namespace, f.name, executorType, start).Observe(duration.Seconds())
+}
+
+// functionCallInflight counts a function call as in flight until the returned
+// func is called.
+func functionCallInflight(f *functionLabels) func() {
+	g := functionInflightRequests.WithLabelValues(f.namespace, f.name)
+	g.Inc()
+	return g.Dec
+}
+
+func functionSpecialized(f *functionLabels, executorType string, timings executorClient.SpecializationTimings) {
+	steps := map[string]time.Duration{
+		executorClient.TimingPodSelection: timings.PodSelection,
+		executorClient.TimingFetch:        timings.Fetch,
+		executorClient.TimingLoad:         timings.Load,
+		executorClient.TimingSpecialize:   timings.Total,
+	}
+	for step, d := range steps {
+		// steps not taken by the executor type are left zero
+		if d > 0 {
+			functionSpecializationDuration.WithLabelValues(f.namespace, f.name, executorType, step).Observe(d.Seconds())
+		}
+	}
+}
+
+func triggerRequestRejected(namespace, trigger string, h *httpLabels, reason string) {
+	triggerRequestsRejected.WithLabelValues(namespace, trigger, h.host, h.path, h.method, reason).Inc()
+}
+
+func responseCacheLookup(namespace, trigger string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	triggerResponseCacheLookups.WithLabelValues(namespace, trigger, result).Inc()
+}
+
+func mirrorRequestDropped(namespace, trigger string) {
+	triggerMirrorDroppedRequests.WithLabelValues(namespace, trigger).Inc()
+}
+
+func triggerMirrorRequest(namespace, trigger string, primary, mirror mirrorResult) {
+	triggerMirrorRequests.WithLabelValues(namespace, trigger, fmt.Sprint(primary.status), fmt.Sprint(mirror.status)).Inc()
+	triggerMirrorDuration.WithLabelValues(namespace, trigger, "function").Observe(primary.duration.Seconds())
+	triggerMirrorDuration.WithLabelValues(namespace, trigger, "mirror").Observe(mirror.duration.Seconds())
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxMirrorBodyBytes is the size limit of a mirrored request body, requests
+	// with a larger body are not mirrored to avoid buffering them in router.
+	maxMirrorBodyBytes = 1 << 20
+
+	// maxConcurrentMirrorRequests is the number of mirrored requests a router
+	// runs at the same time, which bounds the goroutines and buffered bodies
+	// of mirroring. Requests are not mirrored while it's reached.
+	maxConcurrentMirrorRequests = 100
+)
+
+type (
+	// mirrorResult is the outcome of a mirrored request.
+	mirrorResult struct {
+		status   int
+		duration time.Duration
+	}
+
+	// replayBody replays the part of request body read for the mirror
+	// request, followed by the rest of the original body.
+	replayBody struct {
+		io.Reader
+		body io.ReadCloser
+	}
+
+	// statusRecorder records the status code written to the response writer.
+	statusRecorder struct {
+		http.ResponseWriter
+		status int
+	}
+
+	// detachedContext keeps the values of its parent, e.g. the URL params
+	// of mux, but is not canceled when the parent is, so that a mirrored
+	// request outlives the original one.
+	detachedContext struct {
+		context.Context
+	}
+)
+
+func (b *replayBody) Close() error {
+	return b.body.Close()
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(p)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// discardResponseWriter drops the response of mirror function.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header {
+	if w.header == nil {
+		w.header = make(http.Header)
+	}
+	return w.header
+}
+
+func (w *discardResponseWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (w *discardResponseWriter) WriteHeader(int)             {}
+
+// mirrorRequest sends a copy of a sample of requests to the mirror function
+// in background. It returns nil if the request is not mirrored, otherwise the
+// channel receiving the result once the mirror function responds.
+func (fh functionHandler) mirrorRequest(req *http.Request) <-chan mirrorResult {
+	if rand.Intn(100) >= fh.httpTrigger.Spec.Mirror.Percent {
+		return nil
+	}
+	// upgraded connections can't be duplicated
+	if len(req.Header.Get("Upgrade")) > 0 {
+		return nil
+	}
+	if req.ContentLength > maxMirrorBodyBytes {
+		return nil
+	}
+
+	// mirroring is best effort, drop it rather than wait for a slot
+	release := func() {}
+	if fh.mirrorSlots != nil {
+		select {
+		case fh.mirrorSlots <- struct{}{}:
+			release = func() { <-fh.mirrorSlots }
+		default:
+			mirrorRequestDropped(fh.httpTrigger.ObjectMeta.Namespace, fh.httpTrigger.ObjectMeta.Name)
+			return nil
+		}
+	}
+
+	var body []byte
+	if req.Body != nil && req.ContentLength != 0 {
+		var err error
+		body, err = ioutil.ReadAll(io.LimitReader(req.Body, maxMirrorBodyBytes+1))
+		req.Body = &replayBody{
+			Reader: io.MultiReader(bytes.NewReader(body), req.Body),
+			body:   req.Body,
+		}
+		if err != nil || len(body) > maxMirrorBodyBytes {
+			release()
+			return nil
+		}
+	}
+
+	mreq := req.WithContext(detachedContext{withoutAccessLog(req.Context())})
+	u := *req.URL
+	mreq.URL = &u
+	mreq.Header = copyHeader(req.Header)
+	mreq.Body = ioutil.NopCloser(bytes.NewReader(body))
+	mreq.ContentLength = int64(len(body))
+	transformRequest(fh.httpTrigger.Spec.Transform, mreq)
+
+	result := make(chan mirrorResult, 1)
+	go func() {
+		defer release()
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: &discardResponseWriter{}}
+		// the request is already admitted by the trigger, invoke the mirror
+		// function directly to keep the auth info set by router.
+		fh.mirror.invoke(rec, mreq)
+		result <- mirrorResult{status: rec.status, duration: time.Since(start)}
+	}()
+	return result
+}
+
+// recordMirrorResult waits for the mirror function to respond, and records
+// the comparison with the response of the trigger's function.
+func (fh functionHandler) recordMirrorResult(primary mirrorResult, mirrored <-chan mirrorResult) {
+	m := <-mirrored
+	namespace, name := fh.httpTrigger.ObjectMeta.Namespace, fh.httpTrigger.ObjectMeta.Name
+	triggerMirrorRequest(namespace, name, primary, m)
+	if primary.status != m.status {
+		fh.logger.Debug("mirror function responded differently",
+			zap.String("mirror", fh.httpTrigger.Spec.Mirror.FunctionName),
+			zap.Int("status", primary.status),
+			zap.Int("mirror_status", m.status))
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestFunctionHandlerMirror(t *testing.T) {
+	mirrored := make(chan string, 1)
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer primary.Close()
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mirrored <- r.Header.Get("X-Fission-Function-Name") + ":" + r.Header.Get("X-Fission-Auth-Subject") + ":" + string(body)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("mirror response"))
+	}))
+	defer mirror.Close()
+
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+	makeHandler := func(name, uid, backend string) *functionHandler {
+		fnMeta := metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault, UID: k8stypes.UID(uid)}
+		u, err := url.Parse(backend)
+		assert.Nil(t, err)
+		return makeTestFunctionHandler(logger, fnMeta, u)
+	}
+
+	fh := makeHandler("foo", "1", primary.URL)
+	fh.mirror = makeHandler("foo-v2", "2", mirror.URL)
+	fh.httpTrigger = &fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "xxx", Namespace: metav1.NamespaceDefault},
+		Spec: fv1.HTTPTriggerSpec{
+			FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName, Name: "foo"},
+			Mirror:            &fv1.TrafficMirror{FunctionName: "foo-v2", Percent: 100},
+		},
+	}
+	fh.auth = makeTestAuthenticator(fv1.TriggerAuth{Type: fv1.TriggerAuthTypeBasic, SecretName: "users"},
+		map[string][]byte{"alice": []byte("pa55")})
+	fh.mirrorSlots = make(chan struct{}, 1)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader(body))
+		req.SetBasicAuth("alice", "pa55")
+		rr := httptest.NewRecorder()
+		fh.handler(rr, req)
+		return rr
+	}
+
+	rr := post("hello")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "hello", rr.Body.String())
+
+	// the mirror function gets the auth info of the request too
+	select {
+	case got := <-mirrored:
+		assert.Equal(t, "foo-v2:alice:hello", got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not mirrored")
+	}
+
+	// requests are not mirrored while all mirror slots are taken
+	fh.mirrorSlots <- struct{}{}
+	rr = post("busy")
+	assert.Equal(t, "busy", rr.Body.String())
+	select {
+	case <-mirrored:
+		t.Fatal("request was mirrored without a free slot")
+	case <-time.After(200 * time.Millisecond):
+	}
+	<-fh.mirrorSlots
+
+	// requests with a body too large to buffer are not mirrored
+	large := strings.Repeat("a", maxMirrorBodyBytes+1)
+	req := httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader(large))
+	req.SetBasicAuth("alice", "pa55")
+	req.ContentLength = -1
+	rr = httptest.NewRecorder()
+	fh.handler(rr, req)
+	assert.Equal(t, len(large), rr.Body.Len())
+
+	select {
+	case <-mirrored:
+		t.Fatal("large request was mirrored")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
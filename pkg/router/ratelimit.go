@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"container/list"
+	"math"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+const (
+	rejectReasonRateLimit   = "rate-limit"
+	rejectReasonMaxInFlight = "max-in-flight"
+
+	// maxRateLimitBuckets is the number of token buckets a trigger keeps,
+	// the least recently used one is evicted for a new key beyond it. It
+	// prevents memory from growing unbounded when requests are keyed by
+	// client IP or header.
+	maxRateLimitBuckets = 10000
+)
+
+type (
+	// tokenBucket holds up to burst tokens and refills at rate tokens
+	// per second. Each request takes one token.
+	tokenBucket struct {
+		key      string
+		rate     float64
+		burst    float64
+		tokens   float64
+		lastTime time.Time
+	}
+
+	// triggerRateLimiter enforces the RateLimit of a single HTTP trigger.
+	triggerRateLimiter struct {
+		spec fv1.RateLimit
+		lock sync.Mutex
+		// buckets indexes the elements of lru by key, lru holds the
+		// token buckets with the most recently used one in front.
+		buckets    map[string]*list.Element
+		lru        *list.List
+		maxBuckets int
+		inFlight   int64
+	}
+
+	// rateLimiterSet keeps the rate limiters of HTTP triggers. The mux router
+	// is rebuilt whenever a trigger or function changes, so limiters are kept
+	// here to avoid resetting the token buckets on every router update.
+	rateLimiterSet struct {
+		lock     sync.Mutex
+		limiters map[types.UID]*triggerRateLimiter
+	}
+)
+
+func newTokenBucket(key string, rate, burst int, now time.Time) *tokenBucket {
+	if burst <= 0 {
+		burst = rate
+	}
+	return &tokenBucket{
+		key:      key,
+		rate:     float64(rate),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		lastTime: now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.lastTime).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+		b.lastTime = now
+	}
+}
+
+// take takes a token from the bucket. If the bucket is empty, it returns
+// false and the time to wait until the next token is available.
+func (b *tokenBucket) take(now time.Time) (bool, time.Duration) {
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	return false, wait
+}
+
+func newTriggerRateLimiter(spec fv1.RateLimit) *triggerRateLimiter {
+	return &triggerRateLimiter{
+		spec:       spec,
+		buckets:    make(map[string]*list.Element),
+		lru:        list.New(),
+		maxBuckets: maxRateLimitBuckets,
+	}
+}
+
+func makeRateLimiterSet() *rateLimiterSet {
+	return &rateLimiterSet{
+		limiters: make(map[types.UID]*triggerRateLimiter),
+	}
+}
+
+// get returns the rate limiter of the trigger, or nil if the trigger has no
+// rate limit. A new limiter is created if the rate limit of trigger changed.
+func (rs *rateLimiterSet) get(trigger *fv1.HTTPTrigger) *triggerRateLimiter {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	uid := trigger.ObjectMeta.UID
+	if trigger.Spec.RateLimit == nil {
+		delete(rs.limiters, uid)
+		return nil
+	}
+
+	l, ok := rs.limiters[uid]
+	if ok && reflect.DeepEqual(l.spec, *trigger.Spec.RateLimit) {
+		return l
+	}
+
+	l = newTriggerRateLimiter(*trigger.Spec.RateLimit)
+	rs.limiters[uid] = l
+	return l
+}
+
+// retain removes the rate limiters of triggers that no longer exist.
+func (rs *rateLimiterSet) retain(triggers []fv1.HTTPTrigger) {
+	uids := make(map[types.UID]struct{}, len(triggers))
+	for _, t := range triggers {
+		uids[t.ObjectMeta.UID] = struct{}{}
+	}
+
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	for uid := range rs.limiters {
+		if _, ok := uids[uid]; !ok {
+			delete(rs.limiters, uid)
+		}
+	}
+}
+
+// key returns the token bucket key of the request based on the key type.
+func (l *triggerRateLimiter) key(req *http.Request) string {
+	switch l.spec.KeyType {
+	case fv1.RateLimitKeyTypeClientIP:
+		host, _, err := net.SplitHostPort(req.RemoteAddr)
+		if err != nil {
+			return req.RemoteAddr
+		}
+		return host
+	case fv1.RateLimitKeyTypeHeader:
+		return req.Header.Get(l.spec.KeyHeader)
+	default:
+		return ""
+	}
+}
+
+// takeToken takes a token from the bucket of the given key.
+func (l *triggerRateLimiter) takeToken(key string, now time.Time) (bool, time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if e, ok := l.buckets[key]; ok {
+		l.lru.MoveToFront(e)
+		return e.Value.(*tokenBucket).take(now)
+	}
+
+	for l.lru.Len() >= l.maxBuckets {
+		oldest := l.lru.Back()
+		l.lru.Remove(oldest)
+		delete(l.buckets, oldest.Value.(*tokenBucket).key)
+	}
+	b := newTokenBucket(key, l.spec.RequestsPerSecond, l.spec.Burst, now)
+	l.buckets[key] = l.lru.PushFront(b)
+
+	return b.take(now)
+}
+
+// acquire checks whether the request is allowed by the rate limit. If so,
+// it returns a function that must be called once the request is done.
+// Otherwise, release is nil and the reject reason and the duration for
+// client to wait before retrying are returned.
+func (l *triggerRateLimiter) acquire(req *http.Request) (release func(), reason string, retryAfter time.Duration) {
+	if l.spec.MaxInFlight > 0 {
+		if atomic.AddInt64(&l.inFlight, 1) > int64(l.spec.MaxInFlight) {
+			atomic.AddInt64(&l.inFlight, -1)
+			return nil, rejectReasonMaxInFlight, time.Second
+		}
+	}
+
+	release = func() {
+		if l.spec.MaxInFlight > 0 {
+			atomic.AddInt64(&l.inFlight, -1)
+		}
+	}
+
+	if l.spec.RequestsPerSecond > 0 {
+		ok, wait := l.takeToken(l.key(req), time.Now())
+		if !ok {
+			release()
+			return nil, rejectReasonRateLimit, wait
+		}
+	}
+
+	return release, "", 0
+}
+
+// retryAfterSeconds converts the duration to the value of Retry-After
+// header, which is a whole number of seconds.
+func retryAfterSeconds(d time.Duration) string {
+	secs := int(math.Ceil(d.Seconds()))
+	if secs < 1 {
+		secs = 1
+	}
+	return strconv.Itoa(secs)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket("", 2, 3, now)
+
+	for i := 0; i < 3; i++ {
+		ok, _ := b.take(now)
+		assert.True(t, ok, "burst request %v should be allowed", i)
+	}
+
+	ok, wait := b.take(now)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	// one token is refilled after 1/rate seconds
+	ok, _ = b.take(now.Add(500 * time.Millisecond))
+	assert.True(t, ok)
+
+	// the bucket refills up to burst
+	b.refill(now.Add(time.Hour))
+	assert.Equal(t, 3.0, b.tokens)
+}
+
+func TestRateLimiterEvictsLeastRecentlyUsed(t *testing.T) {
+	now := time.Now()
+	l := newTriggerRateLimiter(fv1.RateLimit{RequestsPerSecond: 1})
+	l.maxBuckets = 2
+
+	l.takeToken("a", now)
+	l.takeToken("b", now)
+	// a is used again, so b is the least recently used one
+	ok, _ := l.takeToken("a", now)
+	assert.False(t, ok)
+
+	l.takeToken("c", now)
+	assert.Equal(t, 2, l.lru.Len())
+	assert.Contains(t, l.buckets, "a")
+	assert.Contains(t, l.buckets, "c")
+	assert.NotContains(t, l.buckets, "b")
+}
+
+func TestRateLimiterKey(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	req.RemoteAddr = "10.0.0.1:34567"
+	req.Header.Set("X-Api-Key", "tenant-a")
+
+	l := &triggerRateLimiter{spec: fv1.RateLimit{KeyType: fv1.RateLimitKeyTypeClientIP}}
+	assert.Equal(t, "10.0.0.1", l.key(req))
+
+	l = &triggerRateLimiter{spec: fv1.RateLimit{KeyType: fv1.RateLimitKeyTypeHeader, KeyHeader: "X-Api-Key"}}
+	assert.Equal(t, "tenant-a", l.key(req))
+
+	l = &triggerRateLimiter{spec: fv1.RateLimit{}}
+	assert.Equal(t, "", l.key(req))
+}
+
+func TestRateLimiterMaxInFlight(t *testing.T) {
+	l := newTriggerRateLimiter(fv1.RateLimit{MaxInFlight: 1})
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+
+	release, _, _ := l.acquire(req)
+	assert.NotNil(t, release)
+
+	rejected, reason, _ := l.acquire(req)
+	assert.Nil(t, rejected)
+	assert.Equal(t, rejectReasonMaxInFlight, reason)
+
+	release()
+	release, _, _ = l.acquire(req)
+	assert.NotNil(t, release)
+}
+
+func TestRateLimiterSet(t *testing.T) {
+	trigger := fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", UID: "1234"},
+		Spec: fv1.HTTPTriggerSpec{
+			RateLimit: &fv1.RateLimit{RequestsPerSecond: 1},
+		},
+	}
+
+	rs := makeRateLimiterSet()
+	l := rs.get(&trigger)
+	assert.NotNil(t, l)
+	assert.True(t, l == rs.get(&trigger), "limiter should be kept across router updates")
+
+	trigger.Spec.RateLimit = &fv1.RateLimit{RequestsPerSecond: 2}
+	assert.False(t, l == rs.get(&trigger), "limiter should be recreated when rate limit changes")
+
+	rs.retain(nil)
+	assert.Empty(t, rs.limiters)
+}
+
+func TestFunctionHandlerRateLimit(t *testing.T) {
+	backendURL := createBackendService("hi")
+
+	fnMeta := metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault}
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+
+	httpTrigger := &fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "xxx",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: fv1.HTTPTriggerSpec{
+			FunctionReference: fv1.FunctionReference{
+				Type: fv1.FunctionReferenceTypeFunctionName,
+			},
+			RateLimit: &fv1.RateLimit{
+				RequestsPerSecond: 1,
+			},
+		},
+	}
+
+	fh := makeTestFunctionHandler(logger, fnMeta, backendURL)
+	fh.httpTrigger = httpTrigger
+	fh.rateLimiter = makeRateLimiterSet().get(httpTrigger)
+
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	rr := httptest.NewRecorder()
+	fh.handler(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	fh.handler(rr, req)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+}
@@ -19,6 +19,7 @@ package router
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
 
@@ -27,6 +28,8 @@ import (
 
 const (
 	HEADERS_FISSION_FUNCTION_PREFIX = "Fission-Function"
+	HEADERS_FISSION_PARAMS_PREFIX   = "X-Fission-Params-"
+	HEADERS_FISSION_AUTH_PREFIX     = "X-Fission-Auth-"
 )
 
 // setFunctionMetadataToHeaders set function metadatas to request header
@@ -39,10 +42,28 @@ func setFunctionMetadataToHeader(meta *metav1.ObjectMeta, request *http.Request)
 
 // setPathInfoToHeaders set URL path params and full URL path to request header
 func setPathInfoToHeader(request *http.Request) {
+	// remove params headers sent by client, so that a function
+	// only sees the params extracted from the trigger's URL.
+	for k := range request.Header {
+		if strings.HasPrefix(k, HEADERS_FISSION_PARAMS_PREFIX) {
+			request.Header.Del(k)
+		}
+	}
+
 	// retrieve url params and add them to request header
 	vars := mux.Vars(request)
 	for k, v := range vars {
-		request.Header.Set(fmt.Sprintf("X-Fission-Params-%v", k), v)
+		request.Header.Set(fmt.Sprintf("%v%v", HEADERS_FISSION_PARAMS_PREFIX, k), v)
 	}
 	request.Header.Set("X-Fission-Full-Url", request.URL.String())
 }
+
+// removeAuthInfoFromHeader removes the auth headers sent by client, so that
+// they can't be mistaken for the ones set by router after authentication.
+func removeAuthInfoFromHeader(request *http.Request) {
+	for k := range request.Header {
+		if strings.HasPrefix(k, HEADERS_FISSION_AUTH_PREFIX) {
+			request.Header.Del(k)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	rejectReasonBodyTooLarge = "body-too-large"
+	rejectReasonTimeout      = "timeout"
+)
+
+var errRequestBodyTooLarge = errors.New("request body too large")
+
+type (
+	// limitedBody fails the read of request body once more than limit
+	// bytes are read, so that a request without Content-Length can't
+	// stream an unlimited body to the function.
+	limitedBody struct {
+		io.ReadCloser
+		remaining int64
+		exceeded  bool
+	}
+
+	// errorResponse is the JSON body of the errors returned by router
+	// on behalf of a trigger.
+	errorResponse struct {
+		Code    int    `json:"code"`
+		Error   string `json:"error"`
+		Message string `json:"message"`
+		Trigger string `json:"trigger,omitempty"`
+	}
+)
+
+func newLimitedBody(body io.ReadCloser, limit int64) *limitedBody {
+	return &limitedBody{
+		ReadCloser: body,
+		remaining:  limit,
+	}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, errRequestBodyTooLarge
+	}
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		b.exceeded = true
+		return int(b.remaining), errRequestBodyTooLarge
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
+
+// bodyLimitExceeded returns true if the request body is a limitedBody,
+// possibly wrapped by router, whose limit is exceeded.
+func bodyLimitExceeded(body io.ReadCloser) bool {
+	for {
+		switch b := body.(type) {
+		case *fakeCloseReadCloser:
+			body = b.ReadCloser
+		case *replayBody:
+			body = b.body
+		case *limitedBody:
+			return b.exceeded
+		default:
+			return false
+		}
+	}
+}
+
+// writeErrorResponse replies to the request with the status code and
+// a JSON body describing the error.
+func writeErrorResponse(rw http.ResponseWriter, status int, msg string, trigger string) {
+	body, _ := json.Marshal(errorResponse{
+		Code:    status,
+		Error:   http.StatusText(status),
+		Message: msg,
+		Trigger: trigger,
+	})
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Del("Content-Length")
+	rw.WriteHeader(status)
+	rw.Write(body)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestLimitedBody(t *testing.T) {
+	b := newLimitedBody(ioutil.NopCloser(strings.NewReader("12345")), 5)
+	data, err := ioutil.ReadAll(b)
+	assert.Nil(t, err)
+	assert.Equal(t, "12345", string(data))
+	assert.False(t, b.exceeded)
+
+	b = newLimitedBody(ioutil.NopCloser(strings.NewReader("123456")), 5)
+	data, err = ioutil.ReadAll(b)
+	assert.Equal(t, errRequestBodyTooLarge, err)
+	assert.Equal(t, "12345", string(data))
+	assert.True(t, b.exceeded)
+}
+
+func TestFunctionHandlerRequestLimits(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sleep") == "1" {
+			time.Sleep(2 * time.Second)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer backend.Close()
+	backendURL, err := url.Parse(backend.URL)
+	assert.Nil(t, err)
+
+	fnMeta := metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault, UID: "1"}
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+
+	fh := makeTestFunctionHandler(logger, fnMeta, backendURL)
+	fh.httpTrigger = &fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "xxx", Namespace: metav1.NamespaceDefault},
+		Spec: fv1.HTTPTriggerSpec{
+			FunctionReference:   fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName},
+			MaxRequestBodyBytes: 5,
+			RequestTimeout:      1,
+		},
+	}
+
+	assertErrorResponse := func(rr *httptest.ResponseRecorder, status int) {
+		assert.Equal(t, status, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		var resp errorResponse
+		assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, status, resp.Code)
+		assert.Equal(t, "xxx", resp.Trigger)
+	}
+
+	rr := httptest.NewRecorder()
+	fh.handler(rr, httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader("12345")))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "12345", rr.Body.String())
+
+	// rejected by Content-Length
+	rr = httptest.NewRecorder()
+	fh.handler(rr, httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader("123456")))
+	assertErrorResponse(rr, http.StatusRequestEntityTooLarge)
+
+	// rejected while the body is read
+	req := httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader("123456"))
+	req.ContentLength = -1
+	rr = httptest.NewRecorder()
+	fh.handler(rr, req)
+	assertErrorResponse(rr, http.StatusRequestEntityTooLarge)
+
+	rr = httptest.NewRecorder()
+	fh.handler(rr, httptest.NewRequest(http.MethodGet, "/foo?sleep=1", nil))
+	assertErrorResponse(rr, http.StatusGatewayTimeout)
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	"github.com/fission/fission/pkg/cache"
+)
+
+const (
+	HEADER_FISSION_CACHE = "X-Fission-Cache"
+
+	defaultResponseCacheEntries = 1000
+
+	// maxCachedResponseBytes is the size limit of a cached response body,
+	// larger responses are proxied without being cached.
+	maxCachedResponseBytes = 1 << 20
+)
+
+type (
+	cachedResponse struct {
+		statusCode int
+		header     http.Header
+		body       []byte
+		created    time.Time
+	}
+
+	// triggerResponseCache caches the GET responses of a single HTTP trigger.
+	triggerResponseCache struct {
+		spec  fv1.ResponseCache
+		cache *cache.Cache
+	}
+
+	// responseCacheSet keeps the response caches of HTTP triggers across
+	// router updates, like rateLimiterSet.
+	responseCacheSet struct {
+		lock   sync.Mutex
+		caches map[types.UID]*triggerResponseCache
+	}
+
+	// cachingBody copies the response body while it's read by the reverse
+	// proxy, and stores the response once the body is read completely.
+	cachingBody struct {
+		io.ReadCloser
+		buf      bytes.Buffer
+		overflow bool
+		done     func(body []byte)
+	}
+)
+
+func makeResponseCacheSet() *responseCacheSet {
+	return &responseCacheSet{
+		caches: make(map[types.UID]*triggerResponseCache),
+	}
+}
+
+// get returns the response cache of the trigger, or nil if the trigger has no
+// response cache. The cache is recreated if the cache config of trigger changed.
+func (rs *responseCacheSet) get(trigger *fv1.HTTPTrigger) *triggerResponseCache {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	uid := trigger.ObjectMeta.UID
+	c, ok := rs.caches[uid]
+	if trigger.Spec.ResponseCache == nil {
+		if ok {
+			c.cache.Stop()
+			delete(rs.caches, uid)
+		}
+		return nil
+	}
+	if ok && reflect.DeepEqual(c.spec, *trigger.Spec.ResponseCache) {
+		return c
+	}
+	if ok {
+		c.cache.Stop()
+	}
+
+	maxEntries := trigger.Spec.ResponseCache.MaxEntries
+	if maxEntries == 0 {
+		maxEntries = defaultResponseCacheEntries
+	}
+	c = &triggerResponseCache{
+		spec:  *trigger.Spec.ResponseCache,
+		cache: cache.MakeLRUCache(0, 0, maxEntries),
+	}
+	rs.caches[uid] = c
+	return c
+}
+
+// retain removes the response caches of triggers that no longer exist.
+func (rs *responseCacheSet) retain(triggers []fv1.HTTPTrigger) {
+	uids := make(map[types.UID]struct{}, len(triggers))
+	for _, t := range triggers {
+		uids[t.ObjectMeta.UID] = struct{}{}
+	}
+
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	for uid, c := range rs.caches {
+		if _, ok := uids[uid]; !ok {
+			c.cache.Stop()
+			delete(rs.caches, uid)
+		}
+	}
+}
+
+// parseCacheControl parses the Cache-Control header into a map of
+// directive names to their values.
+func parseCacheControl(header http.Header) map[string]string {
+	directives := make(map[string]string)
+	for _, v := range header["Cache-Control"] {
+		for _, d := range strings.Split(v, ",") {
+			d = strings.TrimSpace(d)
+			if len(d) == 0 {
+				continue
+			}
+			name, value := d, ""
+			if i := strings.Index(d, "="); i >= 0 {
+				name, value = d[:i], strings.Trim(d[i+1:], `"`)
+			}
+			directives[strings.ToLower(name)] = value
+		}
+	}
+	return directives
+}
+
+// key returns the cache key of the request for the given function. It returns
+// false if the request is not cacheable. The function version is part of the key
+// so that an updated function doesn't serve the responses of the old one.
+func (rc *triggerResponseCache) key(req *http.Request, fn *fv1.Function) (string, bool) {
+	if req.Method != http.MethodGet {
+		return "", false
+	}
+	if _, ok := parseCacheControl(req.Header)["no-store"]; ok {
+		return "", false
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v/%v\n%v\n%v", fn.ObjectMeta.UID, fn.ObjectMeta.ResourceVersion, req.Host, req.URL.RequestURI())
+	for _, h := range rc.spec.VaryByHeaders {
+		fmt.Fprintf(&b, "\n%v: %v", http.CanonicalHeaderKey(h), strings.Join(req.Header[http.CanonicalHeaderKey(h)], ","))
+	}
+	// requests authenticated by router are cached per subject
+	fmt.Fprintf(&b, "\n%v", req.Header.Get(fmt.Sprintf("%vSubject", HEADERS_FISSION_AUTH_PREFIX)))
+	return b.String(), true
+}
+
+// lookup returns the cached response of the key. A request with "no-cache"
+// directive always goes to the function, its response replaces the cached one.
+func (rc *triggerResponseCache) lookup(req *http.Request, key string) *cachedResponse {
+	if _, ok := parseCacheControl(req.Header)["no-cache"]; ok {
+		return nil
+	}
+	v, err := rc.cache.Get(key)
+	if err != nil {
+		return nil
+	}
+	return v.(*cachedResponse)
+}
+
+// ttl returns how long the response to the request can be cached for,
+// or 0 if the response is not cacheable.
+func (rc *triggerResponseCache) ttl(req *http.Request, resp *http.Response) time.Duration {
+	if resp.StatusCode != http.StatusOK {
+		return 0
+	}
+	if resp.ContentLength > maxCachedResponseBytes {
+		return 0
+	}
+	// responses setting cookies are specific to the client
+	if len(resp.Header.Get("Set-Cookie")) > 0 {
+		return 0
+	}
+
+	// the response varies on headers not in the cache key
+	for _, v := range resp.Header["Vary"] {
+		for _, h := range strings.Split(v, ",") {
+			h = strings.TrimSpace(h)
+			if len(h) == 0 {
+				continue
+			}
+			found := false
+			for _, vh := range rc.spec.VaryByHeaders {
+				if strings.EqualFold(vh, h) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return 0
+			}
+		}
+	}
+
+	cc := parseCacheControl(resp.Header)
+	for _, d := range []string{"no-store", "no-cache", "private"} {
+		if _, ok := cc[d]; ok {
+			return 0
+		}
+	}
+
+	// a shared cache must not store the responses to requests with
+	// credentials unless the response allows it, see RFC 7234 section 3.2.
+	if len(req.Header.Get("Authorization")) > 0 || len(req.Header.Get("Cookie")) > 0 {
+		_, public := cc["public"]
+		_, sMaxAge := cc["s-maxage"]
+		if !public && !sMaxAge {
+			return 0
+		}
+	}
+	for _, d := range []string{"s-maxage", "max-age"} {
+		if v, ok := cc[d]; ok {
+			secs, err := strconv.Atoi(v)
+			if err != nil || secs <= 0 {
+				return 0
+			}
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return time.Duration(rc.spec.TTL) * time.Second
+}
+
+// capture makes the response body to be stored in cache once it's read
+// completely by the reverse proxy, replacing the cached response if any.
+func (rc *triggerResponseCache) capture(req *http.Request, key string, resp *http.Response) {
+	ttl := rc.ttl(req, resp)
+	if ttl == 0 {
+		return
+	}
+
+	header := copyHeader(resp.Header)
+	header.Del(HEADER_FISSION_CACHE)
+	statusCode := resp.StatusCode
+	resp.Body = &cachingBody{
+		ReadCloser: resp.Body,
+		done: func(body []byte) {
+			rc.cache.Delete(key)
+			rc.cache.SetWithTTL(key, &cachedResponse{
+				statusCode: statusCode,
+				header:     header,
+				body:       body,
+				created:    time.Now(),
+			}, ttl)
+		},
+	}
+}
+
+func copyHeader(h http.Header) http.Header {
+	c := make(http.Header, len(h))
+	for k, v := range h {
+		c[k] = append([]string(nil), v...)
+	}
+	return c
+}
+
+func (b *cachingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if !b.overflow {
+		if b.buf.Len()+n > maxCachedResponseBytes {
+			b.overflow = true
+			b.buf = bytes.Buffer{}
+		} else {
+			b.buf.Write(p[:n])
+		}
+	}
+	if err == io.EOF && !b.overflow && b.done != nil {
+		b.done(b.buf.Bytes())
+		b.done = nil
+	}
+	return n, err
+}
+
+// serve writes the cached response to the response writer.
+func (r *cachedResponse) serve(rw http.ResponseWriter) {
+	header := rw.Header()
+	for k, v := range copyHeader(r.header) {
+		header[k] = v
+	}
+	header.Set("Age", strconv.Itoa(int(time.Since(r.created).Seconds())))
+	header.Set(HEADER_FISSION_CACHE, "HIT")
+	rw.WriteHeader(r.statusCode)
+	rw.Write(r.body)
+}
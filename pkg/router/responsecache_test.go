@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestResponseCacheTTL(t *testing.T) {
+	rc := &triggerResponseCache{spec: fv1.ResponseCache{TTL: 60, VaryByHeaders: []string{"Accept-Language"}}}
+
+	tests := []struct {
+		status    int
+		header    map[string]string
+		reqHeader map[string]string
+		want      time.Duration
+	}{
+		{http.StatusOK, nil, nil, time.Minute},
+		{http.StatusNotFound, nil, nil, 0},
+		{http.StatusOK, map[string]string{"Cache-Control": "public, max-age=5"}, nil, 5 * time.Second},
+		{http.StatusOK, map[string]string{"Cache-Control": "max-age=5, s-maxage=10"}, nil, 10 * time.Second},
+		{http.StatusOK, map[string]string{"Cache-Control": "no-store"}, nil, 0},
+		{http.StatusOK, map[string]string{"Cache-Control": "private"}, nil, 0},
+		{http.StatusOK, map[string]string{"Set-Cookie": "id=1"}, nil, 0},
+		{http.StatusOK, map[string]string{"Vary": "accept-language"}, nil, time.Minute},
+		{http.StatusOK, map[string]string{"Vary": "Accept-Encoding"}, nil, 0},
+		{http.StatusOK, nil, map[string]string{"Authorization": "Bearer x"}, 0},
+		{http.StatusOK, nil, map[string]string{"Cookie": "id=1"}, 0},
+		{http.StatusOK, map[string]string{"Cache-Control": "max-age=5"}, map[string]string{"Cookie": "id=1"}, 0},
+		{http.StatusOK, map[string]string{"Cache-Control": "public"}, map[string]string{"Authorization": "Bearer x"}, time.Minute},
+		{http.StatusOK, map[string]string{"Cache-Control": "s-maxage=10"}, map[string]string{"Cookie": "id=1"}, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+		for k, v := range tt.reqHeader {
+			req.Header.Set(k, v)
+		}
+		resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+		for k, v := range tt.header {
+			resp.Header.Set(k, v)
+		}
+		assert.Equal(t, tt.want, rc.ttl(req, resp), "status %v, header %v, request header %v", tt.status, tt.header, tt.reqHeader)
+	}
+}
+
+func TestFunctionHandlerResponseCache(t *testing.T) {
+	var calls int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		fmt.Fprintf(w, "call %v", n)
+	}))
+	defer backend.Close()
+	backendURL, err := url.Parse(backend.URL)
+	assert.Nil(t, err)
+
+	fnMeta := metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault, UID: "1", ResourceVersion: "1"}
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+
+	httpTrigger := &fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "xxx", Namespace: metav1.NamespaceDefault, UID: "2"},
+		Spec: fv1.HTTPTriggerSpec{
+			FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName},
+			ResponseCache:     &fv1.ResponseCache{TTL: 60},
+		},
+	}
+	caches := makeResponseCacheSet()
+	defer caches.retain(nil)
+
+	fh := makeTestFunctionHandler(logger, fnMeta, backendURL)
+	fh.httpTrigger = httpTrigger
+	fh.responseCache = caches.get(httpTrigger)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		fh.handler(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr
+	}
+
+	rr := get("/foo")
+	assert.Equal(t, "call 1", rr.Body.String())
+	assert.Equal(t, "MISS", rr.Header().Get(HEADER_FISSION_CACHE))
+
+	rr = get("/foo")
+	assert.Equal(t, "call 1", rr.Body.String())
+	assert.Equal(t, "HIT", rr.Header().Get(HEADER_FISSION_CACHE))
+
+	// query string is part of the cache key
+	rr = get("/foo?a=b")
+	assert.Equal(t, "call 2", rr.Body.String())
+
+	// POST requests are never cached
+	rr = httptest.NewRecorder()
+	fh.handler(rr, httptest.NewRequest(http.MethodPost, "/foo", nil))
+	assert.Equal(t, "call 3", rr.Body.String())
+	assert.Empty(t, rr.Header().Get(HEADER_FISSION_CACHE))
+
+	// a no-cache request refreshes the cached response
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	req.Header.Set("Cache-Control", "no-cache")
+	rr = httptest.NewRecorder()
+	fh.handler(rr, req)
+	assert.Equal(t, "call 4", rr.Body.String())
+	rr = get("/foo")
+	assert.Equal(t, "call 4", rr.Body.String())
+	assert.Equal(t, "HIT", rr.Header().Get(HEADER_FISSION_CACHE))
+}
@@ -53,6 +53,8 @@ import (
 	"go.opencensus.io/plugin/ochttp"
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
 	"github.com/fission/fission/pkg/crd"
 	executorClient "github.com/fission/fission/pkg/executor/client"
@@ -83,7 +85,8 @@ func serve(ctx context.Context, logger *zap.Logger, port int, tracingSamplingRat
 	mr := router(ctx, logger, httpTriggerSet, resolver)
 	url := fmt.Sprintf(":%v", port)
 
-	http.ListenAndServe(url, &ochttp.Handler{
+	// accept HTTP/2 cleartext (h2c) for the gRPC triggers
+	http.ListenAndServe(url, h2c.NewHandler(&ochttp.Handler{
 		Handler: mr,
 		GetStartOptions: func(r *http.Request) trace.StartOptions {
 			// do not trace router healthz endpoint
@@ -100,7 +103,7 @@ func serve(ctx context.Context, logger *zap.Logger, port int, tracingSamplingRat
 				Sampler: trace.ProbabilitySampler(tracingSamplingRate),
 			}
 		},
-	})
+	}, &http2.Server{}))
 }
 
 func serveMetric(logger *zap.Logger) {
@@ -127,6 +130,9 @@ func Start(logger *zap.Logger, port int, executorUrl string) {
 	}
 
 	executor := executorClient.MakeClient(logger, executorUrl)
+	executor.OnServiceGone(func(req executorClient.TapServiceRequest) {
+		fmap.removeGone(&req.FnMetadata, req.ServiceUrl)
+	})
 
 	timeoutStr := os.Getenv("ROUTER_ROUND_TRIP_TIMEOUT")
 	timeout, err := time.ParseDuration(timeoutStr)
@@ -229,7 +235,49 @@ func Start(logger *zap.Logger, port int, executorUrl string) {
 		svcAddrRetryCount: svcAddrRetryCount,
 	}, isDebugEnv, throttler.MakeThrottler(svcAddrUpdateTimeout))
 
-	resolver := makeFunctionReferenceResolver(fnStore)
+	asyncWorkers, err := strconv.Atoi(os.Getenv("ROUTER_ASYNC_WORKERS"))
+	if err != nil || asyncWorkers <= 0 {
+		asyncWorkers = defaultAsyncWorkers
+	}
+	asyncQueueSize, err := strconv.Atoi(os.Getenv("ROUTER_ASYNC_QUEUE_SIZE"))
+	if err != nil || asyncQueueSize <= 0 {
+		asyncQueueSize = defaultAsyncQueueSize
+	}
+	asyncResultTTL, err := time.ParseDuration(os.Getenv("ROUTER_ASYNC_RESULT_TTL"))
+	if err != nil || asyncResultTTL <= 0 {
+		asyncResultTTL = defaultAsyncResultTTL
+	}
+	// keep async invocations in secrets shared by router replicas, or
+	// in memory if router doesn't know its namespace, e.g. out of cluster.
+	var asyncStore invocationStore = makeMemoryInvocationStore(asyncResultTTL)
+	if podNamespace := os.Getenv("POD_NAMESPACE"); len(podNamespace) > 0 {
+		podName := os.Getenv("POD_NAME")
+		if len(podName) == 0 {
+			podName, _ = os.Hostname()
+		}
+		asyncStore = makeSecretInvocationStore(logger, kubeClient, podNamespace, podName, asyncResultTTL)
+	}
+	triggers.asyncInvoker = makeAsyncInvoker(logger, asyncStore, asyncWorkers, asyncQueueSize)
+
+	accessLogSampleRate, err := strconv.ParseFloat(os.Getenv("ROUTER_ACCESS_LOG_SAMPLE_RATE"), 64)
+	if err != nil {
+		accessLogSampleRate = 1
+	}
+	triggers.accessLogger, err = makeAccessLogger(logger, accessLogConfig{
+		Sink:       os.Getenv("ROUTER_ACCESS_LOG_SINK"),
+		Format:     os.Getenv("ROUTER_ACCESS_LOG_FORMAT"),
+		File:       os.Getenv("ROUTER_ACCESS_LOG_FILE"),
+		WebhookURL: os.Getenv("ROUTER_ACCESS_LOG_WEBHOOK_URL"),
+		SampleRate: accessLogSampleRate,
+	})
+	if err != nil {
+		logger.Fatal("failed to set up access log", zap.Error(err))
+	}
+
+	// add the Server-Timing header with the cold start breakdown to responses
+	triggers.serverTiming, _ = strconv.ParseBool(os.Getenv("ROUTER_SERVER_TIMING"))
+
+	resolver := makeFunctionReferenceResolver(fnStore, triggers.aliasStore)
 
 	go serveMetric(logger)
 
@@ -73,7 +73,7 @@ func TestRouter(t *testing.T) {
 		})
 
 	// set up the resolver's cache for this function
-	frr := makeFunctionReferenceResolver(nil)
+	frr := makeFunctionReferenceResolver(nil, nil)
 	nfr := namespacedTriggerReference{
 		namespace:              metav1.NamespaceDefault,
 		triggerName:            "xxx",
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	k8sCache "k8s.io/client-go/tools/cache"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// secretSyncTimeout is how long a request waits for the first read of a
+// Secret that wasn't watched yet.
+const secretSyncTimeout = 5 * time.Second
+
+type (
+	// secretWatcher watches the Secrets referenced by triggers, so that a
+	// change of credentials, e.g. a rotated or revoked key, takes effect
+	// right away. Only the referenced Secrets are watched, router doesn't
+	// watch the Secrets of the cluster. The last Secret seen is used while
+	// the API server is down.
+	secretWatcher struct {
+		logger *zap.Logger
+		client kubernetes.Interface
+
+		lock    sync.Mutex
+		watches map[string]*secretWatch
+	}
+
+	secretWatch struct {
+		store      k8sCache.Store
+		controller k8sCache.Controller
+		stopCh     chan struct{}
+	}
+)
+
+func makeSecretWatcher(logger *zap.Logger, client kubernetes.Interface) *secretWatcher {
+	return &secretWatcher{
+		logger:  logger.Named("secret_watcher"),
+		client:  client,
+		watches: make(map[string]*secretWatch),
+	}
+}
+
+func secretKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// watch returns the watch of a Secret, starting it if it's not watched yet.
+// It's called with the lock held.
+func (w *secretWatcher) watch(namespace, name string) *secretWatch {
+	key := secretKey(namespace, name)
+	if sw, ok := w.watches[key]; ok {
+		return sw
+	}
+
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	lw := &k8sCache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return w.client.CoreV1().Secrets(namespace).List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return w.client.CoreV1().Secrets(namespace).Watch(options)
+		},
+	}
+	sw := &secretWatch{stopCh: make(chan struct{})}
+	sw.store, sw.controller = k8sCache.NewInformer(lw, &apiv1.Secret{}, 0, k8sCache.ResourceEventHandlerFuncs{})
+	go sw.controller.Run(sw.stopCh)
+
+	w.watches[key] = sw
+	w.logger.Debug("watching secret", zap.String("secret", key))
+	return sw
+}
+
+// get returns the Secret, waiting for its first read if it's just watched.
+func (w *secretWatcher) get(namespace, name string) (*apiv1.Secret, error) {
+	w.lock.Lock()
+	sw := w.watch(namespace, name)
+	w.lock.Unlock()
+
+	if !sw.controller.HasSynced() {
+		timeout := make(chan struct{})
+		timer := time.AfterFunc(secretSyncTimeout, func() { close(timeout) })
+		synced := k8sCache.WaitForCacheSync(timeout, sw.controller.HasSynced)
+		timer.Stop()
+		if !synced {
+			return nil, errors.Errorf("timed out reading secret %v", secretKey(namespace, name))
+		}
+	}
+
+	obj, exists, err := sw.store.GetByKey(secretKey(namespace, name))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, k8serrors.NewNotFound(apiv1.Resource("secrets"), name)
+	}
+	return obj.(*apiv1.Secret), nil
+}
+
+// retain watches the Secrets referenced by triggers, and stops watching
+// the ones no longer referenced.
+func (w *secretWatcher) retain(triggers []fv1.HTTPTrigger) {
+	keys := make(map[string]struct{})
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for _, t := range triggers {
+		if t.Spec.Auth != nil {
+			w.watch(t.ObjectMeta.Namespace, t.Spec.Auth.SecretName)
+			keys[secretKey(t.ObjectMeta.Namespace, t.Spec.Auth.SecretName)] = struct{}{}
+		}
+	}
+
+	for key, sw := range w.watches {
+		if _, ok := keys[key]; !ok {
+			close(sw.stopCh)
+			delete(w.watches, key)
+		}
+	}
+}
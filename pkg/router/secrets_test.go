@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestSecretWatcher(t *testing.T) {
+	client := fake.NewSimpleClientset(&apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "creds"},
+		Data:       map[string][]byte{"key": []byte("v1")},
+	})
+	w := makeSecretWatcher(zap.NewNop(), client)
+
+	secret, err := w.get("default", "creds")
+	assert.Nil(t, err)
+	assert.Equal(t, "v1", string(secret.Data["key"]))
+
+	_, err = w.get("default", "missing")
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// a rotated key is seen without waiting for a refresh
+	_, err = client.CoreV1().Secrets("default").Update(&apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "creds"},
+		Data:       map[string][]byte{"key": []byte("v2")},
+	})
+	assert.Nil(t, err)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		secret, err = w.get("default", "creds")
+		assert.Nil(t, err)
+		if string(secret.Data["key"]) == "v2" || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, "v2", string(secret.Data["key"]))
+
+	// the secrets no longer referenced aren't watched
+	w.retain([]fv1.HTTPTrigger{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+		Spec: fv1.HTTPTriggerSpec{
+			Auth: &fv1.TriggerAuth{Type: fv1.TriggerAuthTypeAPIKey, SecretName: "creds"},
+		},
+	}})
+	w.lock.Lock()
+	assert.Len(t, w.watches, 1)
+	assert.Contains(t, w.watches, "default/creds")
+	w.lock.Unlock()
+
+	w.retain(nil)
+	w.lock.Lock()
+	assert.Empty(t, w.watches)
+	w.lock.Unlock()
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+	executorClient "github.com/fission/fission/pkg/executor/client"
+)
+
+const (
+	defaultStreamIdleTimeout = 60 * time.Second
+
+	// streamTapInterval is how often the function service of an open stream
+	// is tapped by default, see streamTapIntervalFor.
+	streamTapInterval = 30 * time.Second
+)
+
+type (
+	// streamMonitor cancels a streaming request once no data is transferred
+	// in either direction for the idle timeout, and keeps the function
+	// service from being reaped while the stream is open.
+	streamMonitor struct {
+		idleTimeout time.Duration
+		lastActive  int64 // unix nano
+		timedOut    int32
+		cancel      context.CancelFunc
+		done        chan struct{}
+		stopOnce    sync.Once
+
+		lock       sync.Mutex
+		serviceUrl *url.URL
+	}
+
+	// idleBody touches the stream monitor on each read of a streamed body.
+	idleBody struct {
+		io.ReadCloser
+		monitor *streamMonitor
+	}
+
+	// idleConn is the backend connection of an upgraded response, e.g. a
+	// WebSocket. ReverseProxy requires it to be an io.ReadWriteCloser.
+	idleConn struct {
+		io.ReadWriteCloser
+		monitor *streamMonitor
+	}
+)
+
+// monitorStream starts monitoring the stream of request, cancel is called
+// once the stream is idle for longer than idleTimeout.
+func (fh functionHandler) monitorStream(cancel context.CancelFunc, idleTimeout time.Duration) *streamMonitor {
+	m := &streamMonitor{
+		idleTimeout: idleTimeout,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}
+	m.touch()
+
+	tick := idleTimeout / 4
+	if tick > time.Second {
+		tick = time.Second
+	}
+
+	tapInterval := streamTapIntervalFor(fh.function)
+	go func() {
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+		lastTap := time.Now()
+
+		for {
+			select {
+			case <-m.done:
+				return
+			case now := <-ticker.C:
+				if now.Sub(time.Unix(0, atomic.LoadInt64(&m.lastActive))) > m.idleTimeout {
+					atomic.StoreInt32(&m.timedOut, 1)
+					fh.logger.Debug("closing idle stream", zap.Duration("idle_timeout", m.idleTimeout))
+					m.cancel()
+					return
+				}
+				if now.Sub(lastTap) >= tapInterval {
+					if u := m.service(); u != nil {
+						fh.tapService(fh.function, u)
+					}
+					lastTap = now
+				}
+			}
+		}
+	}()
+	return m
+}
+
+// streamTapIntervalFor returns how often the function service of an open
+// stream of function is tapped. The taps have to reach executor within the
+// idle timeout of function, including the time they wait to be sent in
+// batch, or the pod is released while the stream is open. A function with
+// an idle timeout shorter than the batch interval can't be kept that way.
+func streamTapIntervalFor(fn *fv1.Function) time.Duration {
+	interval := streamTapInterval
+	if fn != nil {
+		idleTimeout := time.Duration(fn.Spec.InvokeStrategy.ExecutionStrategy.IdleTimeout) * time.Second
+		if idleTimeout > 0 {
+			// tap twice within the idle timeout in case a tap is lost
+			if i := (idleTimeout - executorClient.TapBatchInterval) / 2; i < interval {
+				interval = i
+			}
+		}
+	}
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
+func (m *streamMonitor) touch() {
+	atomic.StoreInt64(&m.lastActive, time.Now().UnixNano())
+}
+
+func (m *streamMonitor) stop() {
+	m.stopOnce.Do(func() {
+		close(m.done)
+	})
+}
+
+// idled returns true if the stream was canceled for being idle.
+func (m *streamMonitor) idled() bool {
+	return atomic.LoadInt32(&m.timedOut) == 1
+}
+
+func (m *streamMonitor) setService(u *url.URL) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.serviceUrl = u
+}
+
+func (m *streamMonitor) service() *url.URL {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.serviceUrl
+}
+
+// wrapRequest tracks the data read from request body, e.g. a chunked upload.
+func (m *streamMonitor) wrapRequest(req *http.Request) {
+	if req.Body != nil {
+		req.Body = &idleBody{ReadCloser: req.Body, monitor: m}
+	}
+}
+
+// wrapResponse tracks the data of the response body, or of both directions
+// of the connection if the response is a protocol switch.
+func (m *streamMonitor) wrapResponse(ctx context.Context, resp *http.Response) {
+	m.touch()
+	if resp.StatusCode == http.StatusSwitchingProtocols {
+		if conn, ok := resp.Body.(io.ReadWriteCloser); ok {
+			resp.Body = &idleConn{ReadWriteCloser: conn, monitor: m}
+			// ReverseProxy doesn't watch the request context once the
+			// connection is upgraded, close it when the stream is canceled.
+			go func() {
+				<-ctx.Done()
+				conn.Close()
+			}()
+			return
+		}
+	}
+	resp.Body = &idleBody{ReadCloser: resp.Body, monitor: m}
+}
+
+func (b *idleBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.monitor.touch()
+	}
+	return n, err
+}
+
+func (c *idleConn) Read(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Read(p)
+	if n > 0 {
+		c.monitor.touch()
+	}
+	return n, err
+}
+
+func (c *idleConn) Write(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Write(p)
+	if n > 0 {
+		c.monitor.touch()
+	}
+	return n, err
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func makeStreamingTestHandler(t *testing.T, backend string) *functionHandler {
+	backendURL, err := url.Parse(backend)
+	assert.Nil(t, err)
+
+	fnMeta := metav1.ObjectMeta{Name: "foo", Namespace: metav1.NamespaceDefault, UID: "1"}
+	logger, err := zap.NewDevelopment()
+	assert.Nil(t, err)
+
+	fh := makeTestFunctionHandler(logger, fnMeta, backendURL)
+	// streams outlive the function timeout
+	fh.functionTimeoutMap = map[k8stypes.UID]int{"1": 1}
+	fh.httpTrigger = &fv1.HTTPTrigger{
+		ObjectMeta: metav1.ObjectMeta{Name: "xxx", Namespace: metav1.NamespaceDefault},
+		Spec: fv1.HTTPTriggerSpec{
+			FunctionReference: fv1.FunctionReference{Type: fv1.FunctionReferenceTypeFunctionName},
+			Streaming:         &fv1.StreamingConfig{IdleTimeout: 1},
+		},
+	}
+	return fh
+}
+
+func TestStreamingServerSentEvents(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		gap := 300 * time.Millisecond
+		if r.URL.Query().Get("idle") == "1" {
+			gap = 3 * time.Second
+		}
+		for i := 0; i < 5; i++ {
+			fmt.Fprintf(w, "data: %v\n\n", i)
+			w.(http.Flusher).Flush()
+			select {
+			case <-time.After(gap):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}))
+	defer backend.Close()
+
+	fh := makeStreamingTestHandler(t, backend.URL)
+	server := httptest.NewServer(http.HandlerFunc(fh.handler))
+	defer server.Close()
+
+	// events keep coming for longer than the function timeout
+	resp, err := http.Get(server.URL + "/foo")
+	assert.Nil(t, err)
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	assert.Nil(t, err)
+	assert.Equal(t, "data: 0\n", line)
+	rest, err := ioutil.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, strings.Count(line+string(rest), "data: "))
+	resp.Body.Close()
+
+	// an idle stream is closed
+	start := time.Now()
+	resp, err = http.Get(server.URL + "/foo?idle=1")
+	assert.Nil(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "data: 0\n\n", string(body))
+	assert.True(t, time.Since(start) < 3*time.Second)
+}
+
+func TestStreamingWebSocket(t *testing.T) {
+	// echoes the lines sent after the protocol switch
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "websocket" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+		rw.Flush()
+		for {
+			line, err := rw.ReadString('\n')
+			if err != nil {
+				return
+			}
+			rw.WriteString("echo " + line)
+			rw.Flush()
+		}
+	}))
+	defer backend.Close()
+
+	fh := makeStreamingTestHandler(t, backend.URL)
+	server := httptest.NewServer(http.HandlerFunc(fh.handler))
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	assert.Nil(t, err)
+	defer conn.Close()
+	fmt.Fprintf(conn, "GET /foo HTTP/1.1\r\nHost: example.com\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	// messages keep flowing past the function timeout
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(conn, "hello %v\n", i)
+		line, err := reader.ReadString('\n')
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf("echo hello %v\n", i), line)
+		time.Sleep(300 * time.Millisecond)
+	}
+
+	// the connection is closed once idle
+	conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	_, err = reader.ReadString('\n')
+	assert.NotNil(t, err)
+	netErr, ok := err.(net.Error)
+	assert.False(t, ok && netErr.Timeout(), "idle connection was not closed")
+}
+
+func TestStreamTapInterval(t *testing.T) {
+	fnWithIdleTimeout := func(seconds int) *fv1.Function {
+		fn := &fv1.Function{}
+		fn.Spec.InvokeStrategy.ExecutionStrategy.IdleTimeout = seconds
+		return fn
+	}
+	assert.Equal(t, streamTapInterval, streamTapIntervalFor(nil))
+	assert.Equal(t, streamTapInterval, streamTapIntervalFor(fnWithIdleTimeout(0)))
+	assert.Equal(t, streamTapInterval, streamTapIntervalFor(fnWithIdleTimeout(120)))
+	// the taps reach executor within the idle timeout, with the batch delay
+	assert.Equal(t, 15*time.Second, streamTapIntervalFor(fnWithIdleTimeout(35)))
+	assert.Equal(t, time.Second, streamTapIntervalFor(fnWithIdleTimeout(7)))
+	assert.Equal(t, time.Second, streamTapIntervalFor(fnWithIdleTimeout(3)))
+}
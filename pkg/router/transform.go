@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+// transformHeader applies the header transform to the given headers.
+// Headers are renamed first, then removed and set last.
+func transformHeader(t *fv1.HeaderTransform, header http.Header) {
+	if t == nil {
+		return
+	}
+	for from, to := range t.Rename {
+		if v, ok := header[http.CanonicalHeaderKey(from)]; ok {
+			header.Del(from)
+			header[http.CanonicalHeaderKey(to)] = v
+		}
+	}
+	for _, k := range t.Remove {
+		header.Del(k)
+	}
+	for k, v := range t.Set {
+		header.Set(k, v)
+	}
+}
+
+// rewritePathPrefix replaces the prefix of the path if it matches. A prefix
+// only matches whole path segments, "/api" matches "/api/users" but not "/apis".
+func rewritePathPrefix(rw *fv1.PathPrefixRewrite, p string) string {
+	prefix := strings.TrimSuffix(rw.Prefix, "/")
+	if p != prefix && !strings.HasPrefix(p, prefix+"/") {
+		return p
+	}
+	rest := strings.TrimPrefix(p, prefix)
+	if len(rest) == 0 {
+		return rw.Replacement
+	}
+	result := path.Join(rw.Replacement, rest)
+	// path.Join drops the trailing slash
+	if strings.HasSuffix(rest, "/") && !strings.HasSuffix(result, "/") {
+		result += "/"
+	}
+	return result
+}
+
+// transformRequest applies the request part of trigger transform to the
+// request that's going to be proxied to the function.
+func transformRequest(t *fv1.HTTPTransform, req *http.Request) {
+	if t == nil {
+		return
+	}
+
+	transformHeader(t.RequestHeaders, req.Header)
+
+	urlChanged := false
+	if t.PathPrefix != nil {
+		if p := rewritePathPrefix(t.PathPrefix, req.URL.Path); p != req.URL.Path {
+			req.URL.Path = p
+			req.URL.RawPath = ""
+			urlChanged = true
+		}
+	}
+
+	if len(t.Query) > 0 {
+		q := req.URL.Query()
+		for k, v := range t.Query {
+			q.Set(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+		urlChanged = true
+	}
+
+	// functions only receive "/" as request path, so let them
+	// know about the rewritten URL through the full URL header.
+	if urlChanged {
+		req.Header.Set("X-Fission-Full-Url", req.URL.String())
+	}
+}
+
+// transformResponse applies the response part of trigger transform to the
+// response returned by the function.
+func transformResponse(t *fv1.HTTPTransform, resp *http.Response) {
+	if t == nil {
+		return
+	}
+	transformHeader(t.ResponseHeaders, resp.Header)
+}
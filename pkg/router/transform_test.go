@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Fission Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
+)
+
+func TestTransformHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Old", "1")
+	header.Set("X-Secret", "2")
+	header.Set("X-Keep", "3")
+
+	transformHeader(&fv1.HeaderTransform{
+		Set:    map[string]string{"x-added": "4"},
+		Remove: []string{"x-secret"},
+		Rename: map[string]string{"x-old": "X-New"},
+	}, header)
+
+	assert.Equal(t, "", header.Get("X-Old"))
+	assert.Equal(t, "1", header.Get("X-New"))
+	assert.Equal(t, "", header.Get("X-Secret"))
+	assert.Equal(t, "3", header.Get("X-Keep"))
+	assert.Equal(t, "4", header.Get("X-Added"))
+}
+
+func TestRewritePathPrefix(t *testing.T) {
+	tests := []struct {
+		prefix, replacement, path, want string
+	}{
+		{"/api/v1", "/", "/api/v1/users", "/users"},
+		{"/api/v1/", "/", "/api/v1/users/", "/users/"},
+		{"/api/v1", "/v2", "/api/v1", "/v2"},
+		{"/api", "/", "/apis/users", "/apis/users"},
+		{"/api", "/internal", "/other", "/other"},
+	}
+
+	for _, tt := range tests {
+		rw := &fv1.PathPrefixRewrite{Prefix: tt.prefix, Replacement: tt.replacement}
+		assert.Equal(t, tt.want, rewritePathPrefix(rw, tt.path), "prefix %v, path %v", tt.prefix, tt.path)
+	}
+}
+
+func TestTransformRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/users?id=1&version=0", nil)
+	req.Header.Set("X-Fission-Full-Url", req.URL.String())
+
+	transformRequest(&fv1.HTTPTransform{
+		PathPrefix: &fv1.PathPrefixRewrite{Prefix: "/api", Replacement: "/"},
+		Query:      map[string]string{"version": "2"},
+	}, req)
+
+	assert.Equal(t, "/users", req.URL.Path)
+	assert.Equal(t, "1", req.URL.Query().Get("id"))
+	assert.Equal(t, "2", req.URL.Query().Get("version"))
+	assert.Equal(t, "/users?id=1&version=2", req.Header.Get("X-Fission-Full-Url"))
+}
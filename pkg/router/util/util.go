@@ -24,6 +24,8 @@ import (
 	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 )
 
+// GetIngressSpec returns the Ingress of the trigger. Ingress rules match on host and
+// path only, so a single Ingress forwards requests of all methods of the trigger to router.
 func GetIngressSpec(namespace string, trigger *fv1.HTTPTrigger) *v1beta1.Ingress {
 	// TODO: remove backward compatibility
 	host, path := trigger.Spec.Host, trigger.Spec.RelativeURL
@@ -4,6 +4,13 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fv1 "github.com/fission/fission/pkg/apis/core/v1"
 )
 
 func testRequest(targetUrl string, expectedResponse string) {
@@ -34,3 +41,24 @@ func panicIf(err error) {
 		log.Panicf("Error: %v", err)
 	}
 }
+
+// makeTestFunctionHandler returns a handler of the function of fnMeta, whose
+// function service is at serviceURL if it's not nil, retrying the service
+// with short timeouts. The tests set the trigger and features they need.
+func makeTestFunctionHandler(logger *zap.Logger, fnMeta metav1.ObjectMeta, serviceURL *url.URL) *functionHandler {
+	fmap := makeFunctionServiceMap(logger, 0)
+	if serviceURL != nil {
+		fmap.assign(&fnMeta, serviceURL)
+	}
+
+	return &functionHandler{
+		logger:   logger,
+		fmap:     fmap,
+		function: &fv1.Function{ObjectMeta: fnMeta},
+		tsRoundTripperParams: &tsRoundTripperParams{
+			timeout:         50 * time.Millisecond,
+			timeoutExponent: 2,
+			maxRetries:      10,
+		},
+	}
+}
@@ -135,7 +135,14 @@ func (timer *Timer) newCron(t fv1.TimeTrigger) *cron.Cron {
 		// with the addition of multi-tenancy, the users can create functions in any namespace. however,
 		// the triggers can only be created in the same namespace as the function.
 		// so essentially, function namespace = trigger namespace.
-		(*timer.publisher).Publish("", headers, utils.UrlForFunction(t.Spec.FunctionReference.Name, t.ObjectMeta.Namespace))
+		url, err := utils.UrlForFunctionReference(t.Spec.FunctionReference, t.ObjectMeta.Namespace)
+		if err != nil {
+			timer.logger.Error("unsupported function reference type - cannot publish event",
+				zap.Any("type", t.Spec.FunctionReference.Type),
+				zap.String("trigger", t.ObjectMeta.Name))
+			return
+		}
+		(*timer.publisher).Publish("", headers, url)
 	})
 	c.Start()
 	timer.logger.Info("added new cron for time trigger", zap.String("trigger", t.ObjectMeta.Name))
@@ -47,6 +47,29 @@ func UrlForFunction(name, namespace string) string {
 	return fmt.Sprintf("%v/%v", prefix, name)
 }
 
+// UrlForFunctionAlias returns the router path of a function alias, the
+// requests to it are sent to the functions the alias points to.
+func UrlForFunctionAlias(name, namespace string) string {
+	prefix := "/fission-function-alias"
+	if namespace != metav1.NamespaceDefault {
+		prefix = fmt.Sprintf("/fission-function-alias/%s", namespace)
+	}
+	return fmt.Sprintf("%v/%v", prefix, name)
+}
+
+// UrlForFunctionReference returns the router path of the function
+// reference of a non-http trigger in namespace.
+func UrlForFunctionReference(ref fv1.FunctionReference, namespace string) (string, error) {
+	switch ref.Type {
+	case fv1.FunctionReferenceTypeFunctionName:
+		return UrlForFunction(ref.Name, namespace), nil
+	case fv1.FunctionReferenceTypeFunctionAlias:
+		return UrlForFunctionAlias(ref.Name, namespace), nil
+	default:
+		return "", errors.Errorf("unsupported function reference type %v", ref.Type)
+	}
+}
+
 // IsNetworkError returns true if an error is a network error, and false otherwise.
 func IsNetworkError(err error) bool {
 	_, ok := err.(net.Error)